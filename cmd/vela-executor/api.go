@@ -0,0 +1,65 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-vela/pkg-executor/executor"
+	"github.com/go-vela/pkg-executor/executor/api"
+
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+// helper function to serve the API for the executor from the CLI arguments.
+func setupAPI(c *cli.Context, e executor.Engine) *http.Server {
+	logrus.Debug("creating executor API from CLI configuration")
+
+	// check if a secret was provided for the API
+	if len(c.String("server.secret")) == 0 {
+		logrus.Warn("no server secret provided, all API requests will be rejected")
+	}
+
+	// create the server for the API
+	//
+	// https://pkg.go.dev/net/http?tab=doc#Server
+	srv := &http.Server{
+		Addr:    c.String("api.addr"),
+		Handler: api.Router(e, c.String("server.secret")),
+	}
+
+	go func() {
+		logrus.Infof("serving executor API on %s", srv.Addr)
+
+		// serve the API until the server is shut down
+		err := srv.ListenAndServe()
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logrus.Errorf("unable to serve executor API: %v", err)
+		}
+	}()
+
+	return srv
+}
+
+// helper function to shut down the API for the executor.
+func shutdownAPI(srv *http.Server) {
+	logrus.Debug("shutting down executor API")
+
+	// create a context to bound the requests still in flight
+	//
+	// https://pkg.go.dev/context?tab=doc#WithTimeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// https://pkg.go.dev/net/http?tab=doc#Server.Shutdown
+	err := srv.Shutdown(ctx)
+	if err != nil {
+		logrus.Errorf("unable to shut down executor API: %v", err)
+	}
+}
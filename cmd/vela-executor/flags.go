@@ -21,6 +21,15 @@ func flags() []cli.Flag {
 			Value:   "testdata/steps.yml",
 		},
 
+		// API Flags
+
+		&cli.StringFlag{
+			EnvVars: []string{"EXECUTOR_API_ADDR", "VELA_API_ADDR", "API_ADDR"},
+			Name:    "api.addr",
+			Usage:   "address the API for the executor listens on",
+			Value:   ":8080",
+		},
+
 		// Compiler Flags
 
 		&cli.BoolFlag{
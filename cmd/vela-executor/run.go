@@ -8,6 +8,10 @@ import (
 	"fmt"
 
 	"github.com/go-vela/pkg-executor/executor"
+	"github.com/go-vela/pkg-executor/executor/limit"
+	"github.com/go-vela/pkg-executor/executor/lock"
+	"github.com/go-vela/pkg-executor/executor/mirror"
+	"github.com/go-vela/pkg-executor/executor/policy"
 
 	"github.com/go-vela/pkg-runtime/runtime"
 
@@ -71,15 +75,47 @@ func run(c *cli.Context) error {
 
 	fmt.Println("Runtime: ", r)
 
+	// setup the registry mirror rules
+	mirrors, err := mirror.Parse(c.StringSlice("executor.mirrors"))
+	if err != nil {
+		return err
+	}
+
+	// setup the container policy
+	_policy := &policy.Policy{
+		AllowedImages:  c.StringSlice("executor.policy.allowed.images"),
+		DeniedImages:   c.StringSlice("executor.policy.denied.images"),
+		Privileged:     c.String("executor.policy.privileged"),
+		AllowedVolumes: c.StringSlice("executor.policy.allowed.volumes"),
+		MaxServices:    c.Int("executor.policy.max.services"),
+		MaxSteps:       c.Int("executor.policy.max.steps"),
+	}
+
 	// setup the executor
 	e, err := executor.New(&executor.Setup{
-		Driver:   c.String("executor.driver"),
-		Client:   vela,
-		Runtime:  r,
-		Build:    setupBuild(),
-		Pipeline: p,
-		Repo:     setupRepo(),
-		User:     setupUser(),
+		Driver:           c.String("executor.driver"),
+		Client:           vela,
+		Runtime:          r,
+		StopSignal:       c.String("executor.stop.signal"),
+		StopTimeout:      c.Duration("executor.stop.timeout"),
+		ApprovalTimeout:  c.Duration("executor.approval.timeout"),
+		FinalizerTimeout: c.Duration("executor.finalizer.timeout"),
+		ReadinessTimeout: c.Duration("executor.readiness.timeout"),
+		ProbeImage:       c.String("executor.probe.image"),
+		StageFailure:     c.String("executor.stage.failure"),
+		MaxStages:        c.Int("executor.max.stages"),
+		MaxPulls:         c.Int("executor.max.pulls"),
+		PullRetries:      c.Int("executor.pull.retries"),
+		PullBackoff:      c.Duration("executor.pull.backoff"),
+		PullTimeout:      c.Duration("executor.pull.timeout"),
+		Mirrors:          mirrors,
+		Policy:           _policy,
+		ContainerLimit:   limit.New(c.Int("executor.max.containers")),
+		LockRegistry:     lock.NewRegistry(),
+		Build:            setupBuild(),
+		Pipeline:         p,
+		Repo:             setupRepo(),
+		User:             setupUser(),
 	})
 	if err != nil {
 		return err
@@ -87,5 +123,11 @@ func run(c *cli.Context) error {
 
 	fmt.Println("Executor: ", e)
 
+	// setup the API for the executor
+	srv := setupAPI(c, e)
+
+	// shut down the API once the executor is finished
+	defer shutdownAPI(srv)
+
 	return nil
 }
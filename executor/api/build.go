@@ -0,0 +1,160 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetBuild represents the API handler to capture
+// the build currently running on the executor.
+func GetBuild(c *gin.Context) {
+	e, ok := engine(c)
+	if !ok {
+		return
+	}
+
+	// capture the build from the executor
+	b, err := e.GetBuild()
+	if err != nil {
+		abort(c, http.StatusInternalServerError, fmt.Errorf("unable to get build: %w", err))
+
+		return
+	}
+
+	c.JSON(http.StatusOK, b)
+}
+
+// GetPhase represents the API handler to capture the
+// phase of the build currently running on the executor.
+func GetPhase(c *gin.Context) {
+	e, ok := engine(c)
+	if !ok {
+		return
+	}
+
+	// capture the build phase from the executor
+	phase, err := e.GetPhase()
+	if err != nil {
+		abort(c, http.StatusNotFound, fmt.Errorf("unable to get build phase: %w", err))
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"phase": phase})
+}
+
+// GetStatus represents the API handler to capture a summary
+// of the build currently running on the executor.
+func GetStatus(c *gin.Context) {
+	e, ok := engine(c)
+	if !ok {
+		return
+	}
+
+	// capture the build status from the executor
+	s, err := e.GetStatus()
+	if err != nil {
+		abort(c, http.StatusInternalServerError, fmt.Errorf("unable to get build status: %w", err))
+
+		return
+	}
+
+	c.JSON(http.StatusOK, s)
+}
+
+// CancelBuild represents the API handler to cancel
+// the build currently running on the executor.
+func CancelBuild(c *gin.Context) {
+	e, ok := engine(c)
+	if !ok {
+		return
+	}
+
+	// cancel the build on the executor
+	b, err := e.CancelBuild()
+	if err != nil {
+		abort(c, http.StatusInternalServerError, fmt.Errorf("unable to cancel build: %w", err))
+
+		return
+	}
+
+	c.JSON(http.StatusOK, b)
+}
+
+// GetPause represents the API handler to capture the user
+// that paused the build currently running on the executor.
+func GetPause(c *gin.Context) {
+	e, ok := engine(c)
+	if !ok {
+		return
+	}
+
+	// capture the paused state from the executor
+	user, err := e.GetPause()
+	if err != nil {
+		abort(c, http.StatusNotFound, fmt.Errorf("unable to get build pause: %w", err))
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"paused": true, "user": user})
+}
+
+// PauseBuild represents the API handler to pause
+// the build currently running on the executor.
+func PauseBuild(c *gin.Context) {
+	e, ok := engine(c)
+	if !ok {
+		return
+	}
+
+	// capture the user pausing the build
+	user := c.Query("user")
+
+	// capture if the running containers should be frozen
+	freeze, err := strconv.ParseBool(c.DefaultQuery("freeze", "false"))
+	if err != nil {
+		abort(c, http.StatusBadRequest, fmt.Errorf("invalid freeze provided: %w", err))
+
+		return
+	}
+
+	// pause the build on the executor
+	err = e.PauseBuild(user, freeze)
+	if err != nil {
+		abort(c, http.StatusConflict, fmt.Errorf("unable to pause build: %w", err))
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"paused": true, "user": user})
+}
+
+// ResumeBuild represents the API handler to resume
+// the build currently paused on the executor.
+func ResumeBuild(c *gin.Context) {
+	e, ok := engine(c)
+	if !ok {
+		return
+	}
+
+	// capture the user resuming the build
+	user := c.Query("user")
+
+	// resume the build on the executor
+	err := e.ResumeBuild(user)
+	if err != nil {
+		abort(c, http.StatusConflict, fmt.Errorf("unable to resume build: %w", err))
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"paused": false, "user": user})
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/go-vela/types/constants"
+)
+
+func TestAPI_GetBuild(t *testing.T) {
+	// setup types
+	_engine := testEngine(t)
+
+	// run test
+	got := testRequest(Router(_engine, "superSecret"), http.MethodGet, "/api/v1/executor/build", "superSecret")
+
+	if got.Code != http.StatusOK {
+		t.Errorf("GetBuild is %d, want %d", got.Code, http.StatusOK)
+	}
+}
+
+func TestAPI_GetPhase(t *testing.T) {
+	// setup types
+	_engine := testEngine(t)
+
+	// setup tests
+	tests := []struct {
+		phase bool
+		want  int
+	}{
+		{ // build without a phase started
+			phase: false,
+			want:  http.StatusNotFound,
+		},
+		{ // build with a phase started
+			phase: true,
+			want:  http.StatusOK,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		if test.phase {
+			// planning the build starts the plan phase
+			_ = _engine.PlanBuild(context.Background())
+		}
+
+		got := testRequest(Router(_engine, "superSecret"), http.MethodGet, "/api/v1/executor/build/phase", "superSecret")
+
+		if got.Code != test.want {
+			t.Errorf("GetPhase is %d, want %d", got.Code, test.want)
+		}
+	}
+}
+
+func TestAPI_CancelBuild(t *testing.T) {
+	// setup types
+	_engine := testEngine(t)
+
+	// run test
+	got := testRequest(Router(_engine, "superSecret"), http.MethodDelete, "/api/v1/executor/build/cancel", "superSecret")
+
+	if got.Code != http.StatusOK {
+		t.Errorf("CancelBuild is %d, want %d", got.Code, http.StatusOK)
+	}
+
+	b, err := _engine.GetBuild()
+	if err != nil {
+		t.Errorf("GetBuild returned err: %v", err)
+	}
+
+	if b.GetStatus() != constants.StatusCanceled {
+		t.Errorf("CancelBuild status is %s, want %s", b.GetStatus(), constants.StatusCanceled)
+	}
+}
+
+func TestAPI_PauseBuild(t *testing.T) {
+	// setup types
+	_engine := testEngine(t)
+
+	// setup tests
+	tests := []struct {
+		method string
+		path   string
+		want   int
+	}{
+		{ // build not paused
+			method: http.MethodGet,
+			path:   "/api/v1/executor/build/pause",
+			want:   http.StatusNotFound,
+		},
+		{ // pause with an invalid freeze
+			method: http.MethodPost,
+			path:   "/api/v1/executor/build/pause?user=octocat&freeze=foo",
+			want:   http.StatusBadRequest,
+		},
+		{ // pause the build
+			method: http.MethodPost,
+			path:   "/api/v1/executor/build/pause?user=octocat",
+			want:   http.StatusOK,
+		},
+		{ // build already paused
+			method: http.MethodPost,
+			path:   "/api/v1/executor/build/pause?user=octocat",
+			want:   http.StatusConflict,
+		},
+		{ // build paused
+			method: http.MethodGet,
+			path:   "/api/v1/executor/build/pause",
+			want:   http.StatusOK,
+		},
+		{ // resume the build
+			method: http.MethodPost,
+			path:   "/api/v1/executor/build/resume?user=octocat",
+			want:   http.StatusOK,
+		},
+		{ // build not paused
+			method: http.MethodPost,
+			path:   "/api/v1/executor/build/resume?user=octocat",
+			want:   http.StatusConflict,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got := testRequest(Router(_engine, "superSecret"), test.method, test.path, "superSecret")
+
+		if got.Code != test.want {
+			t.Errorf("%s %s is %d, want %d", test.method, test.path, got.Code, test.want)
+		}
+	}
+}
+
+func TestAPI_GetStatus(t *testing.T) {
+	// setup types
+	_engine := testEngine(t)
+
+	// run test
+	got := testRequest(Router(_engine, "superSecret"), http.MethodGet, "/api/v1/executor/build/status", "superSecret")
+
+	if got.Code != http.StatusOK {
+		t.Errorf("GetStatus is %d, want %d", got.Code, http.StatusOK)
+	}
+}
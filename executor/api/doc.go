@@ -0,0 +1,11 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+// Package api provides the ability for Vela to
+// expose the executor Engine over HTTP.
+//
+// Usage:
+//
+// 	import "github.com/go-vela/pkg-executor/executor/api"
+package api
@@ -0,0 +1,159 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/go-vela/pkg-executor/executor/event"
+)
+
+// StreamStepLogs represents the API handler to follow the
+// masked output for a step, by name and optional stage
+// query parameter, as a stream of server-sent events.
+//
+// The retained output is replayed on connect and the
+// stream can be resumed with the offset query parameter
+// or the Last-Event-ID header sent by the client.
+func StreamStepLogs(c *gin.Context) {
+	e, ok := engine(c)
+	if !ok {
+		return
+	}
+
+	// capture the step name and stage from the request
+	name := c.Param("step")
+	stage := c.Query("stage")
+
+	// capture the offset to resume from
+	offset, err := resume(c)
+	if err != nil {
+		abort(c, http.StatusBadRequest, err)
+
+		return
+	}
+
+	// follow the output for the step
+	lines, cancel, err := e.FollowStep(stage, name, offset)
+	if err != nil {
+		abort(c, http.StatusNotFound, fmt.Errorf("unable to follow step %s: %w", name, err))
+
+		return
+	}
+	defer cancel()
+
+	stream(c, lines)
+}
+
+// StreamServiceLogs represents the API handler to follow the
+// masked output for a service, by name, as a stream of
+// server-sent events.
+//
+// The retained output is replayed on connect and the
+// stream can be resumed with the offset query parameter
+// or the Last-Event-ID header sent by the client.
+func StreamServiceLogs(c *gin.Context) {
+	e, ok := engine(c)
+	if !ok {
+		return
+	}
+
+	// capture the service name from the request
+	name := c.Param("service")
+
+	// capture the offset to resume from
+	offset, err := resume(c)
+	if err != nil {
+		abort(c, http.StatusBadRequest, err)
+
+		return
+	}
+
+	// follow the output for the service
+	lines, cancel, err := e.FollowService(name, offset)
+	if err != nil {
+		abort(c, http.StatusNotFound, fmt.Errorf("unable to follow service %s: %w", name, err))
+
+		return
+	}
+	defer cancel()
+
+	stream(c, lines)
+}
+
+// resume captures the line to resume the output from. The
+// Last-Event-ID header takes precedence since it is sent
+// when the client automatically reconnects to the stream.
+func resume(c *gin.Context) (int, error) {
+	// check if the client is reconnecting to the stream
+	//
+	// https://html.spec.whatwg.org/multipage/server-sent-events.html#the-last-event-id-header
+	id := c.GetHeader("Last-Event-ID")
+	if len(id) > 0 {
+		last, err := strconv.Atoi(id)
+		if err != nil {
+			return 0, fmt.Errorf("invalid Last-Event-ID header provided: %s", id)
+		}
+
+		// resume after the last line received
+		return last + 1, nil
+	}
+
+	// check if an offset was provided
+	value := c.Query("offset")
+	if len(value) == 0 {
+		return 0, nil
+	}
+
+	offset, err := strconv.Atoi(value)
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("invalid offset query parameter provided: %s", value)
+	}
+
+	return offset, nil
+}
+
+// stream writes each line as a server-sent event until
+// the output ends or the client disconnects.
+func stream(c *gin.Context, lines <-chan *event.Line) {
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+
+	// send the headers to the client before any output
+	c.Status(http.StatusOK)
+	c.Writer.WriteHeaderNow()
+	c.Writer.Flush()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case line, ok := <-lines:
+			// check if the output has ended
+			if !ok {
+				return
+			}
+
+			data, err := json.Marshal(line)
+			if err != nil {
+				return
+			}
+
+			// https://html.spec.whatwg.org/multipage/server-sent-events.html#event-stream-interpretation
+			_, err = fmt.Fprintf(c.Writer, "id: %d\nevent: log\ndata: %s\n\n", line.Offset, data)
+			if err != nil {
+				return
+			}
+
+			c.Writer.Flush()
+		}
+	}
+}
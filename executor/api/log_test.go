@@ -0,0 +1,115 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPI_StreamStepLogs(t *testing.T) {
+	// setup types
+	_engine := testEngine(t)
+
+	// destroying the build ends the output for all containers
+	err := _engine.DestroyBuild(context.Background())
+	if err != nil {
+		t.Errorf("unable to destroy build: %v", err)
+	}
+
+	// setup tests
+	tests := []struct {
+		path string
+		want int
+	}{
+		{ // step in pipeline
+			path: "/api/v1/executor/steps/echo/logs",
+			want: http.StatusOK,
+		},
+		{ // step in pipeline with offset
+			path: "/api/v1/executor/steps/echo/logs?offset=5",
+			want: http.StatusOK,
+		},
+		{ // step with invalid offset
+			path: "/api/v1/executor/steps/echo/logs?offset=foo",
+			want: http.StatusBadRequest,
+		},
+		{ // step not in pipeline
+			path: "/api/v1/executor/steps/foo/logs",
+			want: http.StatusNotFound,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got := testRequest(Router(_engine, "superSecret"), http.MethodGet, test.path, "superSecret")
+
+		if got.Code != test.want {
+			t.Errorf("StreamStepLogs for %s is %d, want %d", test.path, got.Code, test.want)
+		}
+
+		if test.want == http.StatusOK && got.Header().Get("Content-Type") != "text/event-stream" {
+			t.Errorf("StreamStepLogs for %s should have returned an event stream", test.path)
+		}
+	}
+}
+
+func TestAPI_StreamServiceLogs(t *testing.T) {
+	// setup types
+	_engine := testEngine(t)
+
+	// destroying the build ends the output for all containers
+	err := _engine.DestroyBuild(context.Background())
+	if err != nil {
+		t.Errorf("unable to destroy build: %v", err)
+	}
+
+	// setup tests
+	tests := []struct {
+		path string
+		id   string
+		want int
+	}{
+		{ // service in pipeline
+			path: "/api/v1/executor/services/postgres/logs",
+			want: http.StatusOK,
+		},
+		{ // service in pipeline reconnecting
+			path: "/api/v1/executor/services/postgres/logs",
+			id:   "10",
+			want: http.StatusOK,
+		},
+		{ // service with invalid last event
+			path: "/api/v1/executor/services/postgres/logs",
+			id:   "foo",
+			want: http.StatusBadRequest,
+		},
+		{ // service not in pipeline
+			path: "/api/v1/executor/services/redis/logs",
+			want: http.StatusNotFound,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+
+		r := httptest.NewRequest(http.MethodGet, test.path, nil)
+		r.Header.Set("Authorization", "Bearer superSecret")
+
+		// check if the client is reconnecting
+		if len(test.id) > 0 {
+			r.Header.Set("Last-Event-ID", test.id)
+		}
+
+		Router(_engine, "superSecret").ServeHTTP(w, r)
+
+		if w.Code != test.want {
+			t.Errorf("StreamServiceLogs for %s is %d, want %d", test.path, w.Code, test.want)
+		}
+	}
+}
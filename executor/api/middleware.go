@@ -0,0 +1,80 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package api
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/go-vela/pkg-executor/executor"
+
+	"github.com/go-vela/types"
+)
+
+// Establish is a middleware function that inserts
+// the executor Engine into the gin.Context.
+func Establish(e executor.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// set the executor Engine in the gin.Context
+		//
+		// https://pkg.go.dev/github.com/go-vela/pkg-executor/executor?tab=doc#WithGinContext
+		executor.WithGinContext(c, e)
+
+		c.Next()
+	}
+}
+
+// MustSecret is a middleware function that rejects any request
+// without the secret provided as a bearer token.
+//
+// An empty secret rejects every request.
+func MustSecret(secret string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// capture the token from the authorization header
+		//
+		// https://pkg.go.dev/github.com/gin-gonic/gin?tab=doc#Context.GetHeader
+		token := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+
+		// check if the token matches the secret
+		//
+		// https://pkg.go.dev/crypto/subtle?tab=doc#ConstantTimeCompare
+		if len(secret) == 0 || subtle.ConstantTimeCompare([]byte(token), []byte(secret)) != 1 {
+			msg := "unable to authenticate request: invalid token provided"
+
+			c.AbortWithStatusJSON(http.StatusUnauthorized, types.Error{Message: &msg})
+
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// engine retrieves the executor Engine from the gin.Context
+// and aborts the request when one is not available.
+func engine(c *gin.Context) (executor.Engine, bool) {
+	// capture the executor Engine from the gin.Context
+	//
+	// https://pkg.go.dev/github.com/go-vela/pkg-executor/executor?tab=doc#FromGinContext
+	e := executor.FromGinContext(c)
+	if e == nil {
+		abort(c, http.StatusInternalServerError, fmt.Errorf("no running executor found"))
+
+		return nil, false
+	}
+
+	return e, true
+}
+
+// abort ends the request with the status code and error provided.
+func abort(c *gin.Context, code int, err error) {
+	msg := err.Error()
+
+	c.AbortWithStatusJSON(code, types.Error{Message: &msg})
+}
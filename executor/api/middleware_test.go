@@ -0,0 +1,86 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/go-vela/pkg-executor/executor"
+)
+
+func TestAPI_Establish(t *testing.T) {
+	// setup types
+	want := testEngine(t)
+
+	var got executor.Engine
+
+	_, engine := gin.CreateTestContext(httptest.NewRecorder())
+
+	// setup mock server
+	engine.Use(Establish(want))
+	engine.GET("/health", func(c *gin.Context) {
+		got = executor.FromGinContext(c)
+
+		c.Status(http.StatusOK)
+	})
+
+	// run test
+	resp := testRequest(engine, http.MethodGet, "/health", "")
+
+	if resp.Code != http.StatusOK {
+		t.Errorf("Establish returned %v, want %v", resp.Code, http.StatusOK)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Establish is %v, want %v", got, want)
+	}
+}
+
+func TestAPI_MustSecret(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		secret string
+		token  string
+		want   int
+	}{
+		{ // matching token
+			secret: "superSecret",
+			token:  "superSecret",
+			want:   http.StatusOK,
+		},
+		{ // invalid token
+			secret: "superSecret",
+			token:  "foo",
+			want:   http.StatusUnauthorized,
+		},
+		{ // empty secret
+			secret: "",
+			token:  "",
+			want:   http.StatusUnauthorized,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		_, engine := gin.CreateTestContext(httptest.NewRecorder())
+
+		// setup mock server
+		engine.Use(MustSecret(test.secret))
+		engine.GET("/health", func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+
+		got := testRequest(engine, http.MethodGet, "/health", test.token)
+
+		if got.Code != test.want {
+			t.Errorf("MustSecret returned %v, want %v", got.Code, test.want)
+		}
+	}
+}
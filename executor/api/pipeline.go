@@ -0,0 +1,31 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetPipeline represents the API handler to capture
+// the pipeline currently running on the executor.
+func GetPipeline(c *gin.Context) {
+	e, ok := engine(c)
+	if !ok {
+		return
+	}
+
+	// capture the pipeline from the executor
+	p, err := e.GetPipeline()
+	if err != nil {
+		abort(c, http.StatusInternalServerError, fmt.Errorf("unable to get pipeline: %w", err))
+
+		return
+	}
+
+	c.JSON(http.StatusOK, p)
+}
@@ -0,0 +1,31 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetRepo represents the API handler to capture
+// the repo currently running on the executor.
+func GetRepo(c *gin.Context) {
+	e, ok := engine(c)
+	if !ok {
+		return
+	}
+
+	// capture the repo from the executor
+	r, err := e.GetRepo()
+	if err != nil {
+		abort(c, http.StatusInternalServerError, fmt.Errorf("unable to get repo: %w", err))
+
+		return
+	}
+
+	c.JSON(http.StatusOK, r)
+}
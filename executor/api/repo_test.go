@@ -0,0 +1,35 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package api
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestAPI_GetRepo(t *testing.T) {
+	// setup types
+	_engine := testEngine(t)
+
+	// setup tests
+	tests := []struct {
+		path string
+		want int
+	}{
+		{ // repo in execution
+			path: "/api/v1/executor/repo",
+			want: http.StatusOK,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got := testRequest(Router(_engine, "superSecret"), http.MethodGet, test.path, "superSecret")
+
+		if got.Code != test.want {
+			t.Errorf("GetRepo for %s is %d, want %d", test.path, got.Code, test.want)
+		}
+	}
+}
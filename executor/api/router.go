@@ -0,0 +1,112 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/go-vela/pkg-executor/executor"
+)
+
+// Mount registers the API handlers for the executor Engine
+// on the group provided. Every request must be authenticated
+// with the secret provided as a bearer token.
+//
+// * Build
+// GET    /build
+// GET    /build/phase
+// GET    /build/status
+// GET    /build/pause
+// POST   /build/pause?user=<user>&freeze=<freeze>
+// POST   /build/resume?user=<user>
+// DELETE /build/cancel
+// * Pipeline
+// GET    /pipeline
+// * Repo
+// GET    /repo
+// * Stages
+// POST   /stages/:stage/approve?user=<user>
+// POST   /stages/:stage/reject?user=<user>
+// DELETE /stages/:stage/cancel
+// * Services
+// GET    /services
+// GET    /services/:service
+// GET    /services/:service/logs?offset=<offset>
+// * Steps
+// GET    /steps
+// GET    /steps/:step?stage=<stage>
+// GET    /steps/:step/logs?stage=<stage>&offset=<offset>
+// POST   /steps/:step/approve?stage=<stage>&user=<user>
+// POST   /steps/:step/reject?stage=<stage>&user=<user>
+// DELETE /steps/:step/cancel?stage=<stage>
+func Mount(base *gin.RouterGroup, e executor.Engine, secret string) {
+	// add the middleware for all executor routes
+	base.Use(MustSecret(secret), Establish(e))
+
+	// build endpoints
+	build := base.Group("/build")
+	{
+		build.GET("", GetBuild)
+		build.GET("/phase", GetPhase)
+		build.GET("/status", GetStatus)
+		build.GET("/pause", GetPause)
+		build.POST("/pause", PauseBuild)
+		build.POST("/resume", ResumeBuild)
+		build.DELETE("/cancel", CancelBuild)
+	} // end of build endpoints
+
+	// pipeline endpoints
+	base.GET("/pipeline", GetPipeline)
+
+	// repo endpoints
+	base.GET("/repo", GetRepo)
+
+	// stage endpoints
+	stages := base.Group("/stages/:stage")
+	{
+		stages.POST("/approve", ApproveStage)
+		stages.POST("/reject", RejectStage)
+		stages.DELETE("/cancel", CancelStage)
+	} // end of stage endpoints
+
+	// service endpoints
+	base.GET("/services", GetServices)
+
+	services := base.Group("/services/:service")
+	{
+		services.GET("", GetService)
+		services.GET("/logs", StreamServiceLogs)
+	} // end of service endpoints
+
+	// step endpoints
+	base.GET("/steps", GetSteps)
+
+	steps := base.Group("/steps/:step")
+	{
+		steps.GET("", GetStep)
+		steps.GET("/logs", StreamStepLogs)
+		steps.POST("/approve", ApproveStep)
+		steps.POST("/reject", RejectStep)
+		steps.DELETE("/cancel", CancelStep)
+	} // end of step endpoints
+}
+
+// Router creates a gin.Engine serving the API for the
+// executor Engine under the /api/v1/executor path.
+func Router(e executor.Engine, secret string) *gin.Engine {
+	// create a gin engine without the default logger
+	//
+	// https://pkg.go.dev/github.com/gin-gonic/gin?tab=doc#New
+	r := gin.New()
+
+	// recover from any panics in the handlers
+	//
+	// https://pkg.go.dev/github.com/gin-gonic/gin?tab=doc#Recovery
+	r.Use(gin.Recovery())
+
+	Mount(r.Group("/api/v1/executor"), e, secret)
+
+	return r
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/go-vela/mock/server"
+
+	"github.com/go-vela/pkg-executor/executor"
+	"github.com/go-vela/pkg-executor/executor/linux"
+
+	"github.com/go-vela/pkg-runtime/runtime/docker"
+
+	"github.com/go-vela/sdk-go/vela"
+
+	"github.com/go-vela/types/constants"
+	"github.com/go-vela/types/library"
+	"github.com/go-vela/types/pipeline"
+)
+
+func TestAPI_Router(t *testing.T) {
+	// setup types
+	_engine := testEngine(t)
+
+	// setup tests
+	tests := []struct {
+		method string
+		path   string
+		want   int
+	}{
+		{method: http.MethodGet, path: "/api/v1/executor/build", want: http.StatusOK},
+		{method: http.MethodGet, path: "/api/v1/executor/build/phase", want: http.StatusNotFound},
+		{method: http.MethodGet, path: "/api/v1/executor/pipeline", want: http.StatusOK},
+		{method: http.MethodGet, path: "/api/v1/executor/repo", want: http.StatusOK},
+		{method: http.MethodGet, path: "/api/v1/executor/services/postgres", want: http.StatusOK},
+		{method: http.MethodGet, path: "/api/v1/executor/steps/echo", want: http.StatusOK},
+		{method: http.MethodGet, path: "/api/v1/executor/foo", want: http.StatusNotFound},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got := testRequest(Router(_engine, "superSecret"), test.method, test.path, "superSecret")
+
+		if got.Code != test.want {
+			t.Errorf("Router %s %s is %d, want %d", test.method, test.path, got.Code, test.want)
+		}
+	}
+}
+
+// testEngine is a test helper function to create an
+// executor Engine with a running step and service.
+func testEngine(t *testing.T) executor.Engine {
+	gin.SetMode(gin.TestMode)
+
+	s := httptest.NewServer(server.FakeHandler())
+	t.Cleanup(s.Close)
+
+	_client, err := vela.NewClient(s.URL, "", nil)
+	if err != nil {
+		t.Errorf("unable to create Vela API client: %v", err)
+	}
+
+	_runtime, err := docker.NewMock()
+	if err != nil {
+		t.Errorf("unable to create runtime engine: %v", err)
+	}
+
+	_build := new(library.Build)
+	_build.SetNumber(1)
+	_build.SetStatus(constants.StatusRunning)
+
+	_repo := new(library.Repo)
+	_repo.SetOrg("github")
+	_repo.SetName("octocat")
+	_repo.SetFullName("github/octocat")
+
+	_pipeline := &pipeline.Build{
+		Version: "1",
+		ID:      "github_octocat_1",
+		Services: pipeline.ContainerSlice{
+			{
+				ID:          "service_github_octocat_1_postgres",
+				Environment: map[string]string{"FOO": "bar"},
+				Image:       "postgres:12-alpine",
+				Name:        "postgres",
+				Number:      1,
+			},
+		},
+		Steps: pipeline.ContainerSlice{
+			{
+				ID:          "step_github_octocat_1_echo",
+				Commands:    []string{"echo hello"},
+				Environment: map[string]string{"FOO": "bar"},
+				Image:       "alpine:latest",
+				Name:        "echo",
+				Number:      1,
+			},
+		},
+	}
+
+	_engine, err := linux.New(
+		linux.WithBuild(_build),
+		linux.WithPipeline(_pipeline),
+		linux.WithRepo(_repo),
+		linux.WithRuntime(_runtime),
+		linux.WithUser(new(library.User)),
+		linux.WithVelaClient(_client),
+	)
+	if err != nil {
+		t.Errorf("unable to create executor engine: %v", err)
+	}
+
+	// create the step and service to track them in the engine
+	err = _engine.CreateService(context.Background(), _pipeline.Services[0])
+	if err != nil {
+		t.Errorf("unable to create service: %v", err)
+	}
+
+	err = _engine.PlanService(context.Background(), _pipeline.Services[0])
+	if err != nil {
+		t.Errorf("unable to plan service: %v", err)
+	}
+
+	err = _engine.CreateStep(context.Background(), _pipeline.Steps[0])
+	if err != nil {
+		t.Errorf("unable to create step: %v", err)
+	}
+
+	err = _engine.PlanStep(context.Background(), _pipeline.Steps[0])
+	if err != nil {
+		t.Errorf("unable to plan step: %v", err)
+	}
+
+	return _engine
+}
+
+// testRequest is a test helper function to send a request,
+// authenticated with the token provided, to the handler.
+func testRequest(h http.Handler, method, path, token string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+
+	r := httptest.NewRequest(method, path, nil)
+	r.Header.Set("Authorization", "Bearer "+token)
+
+	h.ServeHTTP(w, r)
+
+	return w
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetServices represents the API handler to capture the
+// services from the build currently running on the executor.
+func GetServices(c *gin.Context) {
+	e, ok := engine(c)
+	if !ok {
+		return
+	}
+
+	// capture the services from the executor
+	s, err := e.GetServices()
+	if err != nil {
+		abort(c, http.StatusInternalServerError, fmt.Errorf("unable to get services: %w", err))
+
+		return
+	}
+
+	c.JSON(http.StatusOK, s)
+}
+
+// GetService represents the API handler to capture a
+// service, by name, from the build currently running
+// on the executor.
+func GetService(c *gin.Context) {
+	e, ok := engine(c)
+	if !ok {
+		return
+	}
+
+	// capture the service name from the request
+	name := c.Param("service")
+
+	// capture the service from the executor
+	s, err := e.GetService(name)
+	if err != nil {
+		abort(c, http.StatusNotFound, fmt.Errorf("unable to get service %s: %w", name, err))
+
+		return
+	}
+
+	c.JSON(http.StatusOK, s)
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package api
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestAPI_GetService(t *testing.T) {
+	// setup types
+	_engine := testEngine(t)
+
+	// setup tests
+	tests := []struct {
+		path string
+		want int
+	}{
+		{ // service in execution
+			path: "/api/v1/executor/services/postgres",
+			want: http.StatusOK,
+		},
+		{ // service not in pipeline
+			path: "/api/v1/executor/services/redis",
+			want: http.StatusNotFound,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got := testRequest(Router(_engine, "superSecret"), http.MethodGet, test.path, "superSecret")
+
+		if got.Code != test.want {
+			t.Errorf("GetService for %s is %d, want %d", test.path, got.Code, test.want)
+		}
+	}
+}
+
+func TestAPI_GetServices(t *testing.T) {
+	// setup types
+	_engine := testEngine(t)
+
+	// run test
+	got := testRequest(Router(_engine, "superSecret"), http.MethodGet, "/api/v1/executor/services", "superSecret")
+
+	if got.Code != http.StatusOK {
+		t.Errorf("GetServices is %d, want %d", got.Code, http.StatusOK)
+	}
+}
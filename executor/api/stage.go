@@ -0,0 +1,76 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CancelStage represents the API handler to cancel the
+// steps for a stage, by name, from the build currently
+// running on the executor.
+func CancelStage(c *gin.Context) {
+	e, ok := engine(c)
+	if !ok {
+		return
+	}
+
+	// capture the stage name from the request
+	name := c.Param("stage")
+
+	// cancel the stage on the executor
+	steps, err := e.CancelStage(name)
+	if err != nil {
+		abort(c, http.StatusNotFound, fmt.Errorf("unable to cancel stage %s: %w", name, err))
+
+		return
+	}
+
+	c.JSON(http.StatusOK, steps)
+}
+
+// ApproveStage represents the API handler to approve the
+// stage, by name, waiting on the executor.
+func ApproveStage(c *gin.Context) {
+	decide(c, c.Param("stage"), "", true)
+}
+
+// RejectStage represents the API handler to reject the
+// stage, by name, waiting on the executor.
+func RejectStage(c *gin.Context) {
+	decide(c, c.Param("stage"), "", false)
+}
+
+// decide records the decision, by the user from the request,
+// for the gate waiting for a stage or a step by stage and name.
+func decide(c *gin.Context, stage, name string, approved bool) {
+	e, ok := engine(c)
+	if !ok {
+		return
+	}
+
+	// capture the user deciding the gate
+	user := c.Query("user")
+
+	var err error
+
+	// check if the gate is being approved
+	if approved {
+		err = e.ApproveGate(stage, name, user)
+	} else {
+		err = e.RejectGate(stage, name, user)
+	}
+
+	if err != nil {
+		abort(c, http.StatusConflict, fmt.Errorf("unable to decide approval: %w", err))
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"approved": approved, "user": user})
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package api
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestAPI_CancelStage(t *testing.T) {
+	// setup types
+	_engine := testEngine(t)
+
+	// run test
+	got := testRequest(Router(_engine, "superSecret"), http.MethodDelete, "/api/v1/executor/stages/test/cancel", "superSecret")
+
+	// the test engine runs a steps pipeline without stages
+	if got.Code != http.StatusNotFound {
+		t.Errorf("CancelStage is %d, want %d", got.Code, http.StatusNotFound)
+	}
+}
+
+func TestAPI_ApproveStage(t *testing.T) {
+	// setup types
+	_engine := testEngine(t)
+
+	// setup tests
+	tests := []struct {
+		path string
+		want int
+	}{
+		{ // approve a stage not waiting for approval
+			path: "/api/v1/executor/stages/test/approve?user=octocat",
+			want: http.StatusConflict,
+		},
+		{ // reject a stage not waiting for approval
+			path: "/api/v1/executor/stages/test/reject?user=octocat",
+			want: http.StatusConflict,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got := testRequest(Router(_engine, "superSecret"), http.MethodPost, test.path, "superSecret")
+
+		if got.Code != test.want {
+			t.Errorf("POST %s is %d, want %d", test.path, got.Code, test.want)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetSteps represents the API handler to capture the
+// steps from the build currently running on the executor.
+func GetSteps(c *gin.Context) {
+	e, ok := engine(c)
+	if !ok {
+		return
+	}
+
+	// capture the steps from the executor
+	s, err := e.GetSteps()
+	if err != nil {
+		abort(c, http.StatusInternalServerError, fmt.Errorf("unable to get steps: %w", err))
+
+		return
+	}
+
+	c.JSON(http.StatusOK, s)
+}
+
+// GetStep represents the API handler to capture a step,
+// by name and optional stage query parameter, from the
+// build currently running on the executor.
+func GetStep(c *gin.Context) {
+	e, ok := engine(c)
+	if !ok {
+		return
+	}
+
+	// capture the step name and stage from the request
+	name := c.Param("step")
+	stage := c.Query("stage")
+
+	// capture the step from the executor
+	s, err := e.GetStep(stage, name)
+	if err != nil {
+		abort(c, http.StatusNotFound, fmt.Errorf("unable to get step %s: %w", name, err))
+
+		return
+	}
+
+	c.JSON(http.StatusOK, s)
+}
+
+// CancelStep represents the API handler to cancel a step,
+// by name and optional stage query parameter, from the
+// build currently running on the executor.
+func CancelStep(c *gin.Context) {
+	e, ok := engine(c)
+	if !ok {
+		return
+	}
+
+	// capture the step name and stage from the request
+	name := c.Param("step")
+	stage := c.Query("stage")
+
+	// cancel the step on the executor
+	s, err := e.CancelStep(stage, name)
+	if err != nil {
+		abort(c, http.StatusNotFound, fmt.Errorf("unable to cancel step %s: %w", name, err))
+
+		return
+	}
+
+	c.JSON(http.StatusOK, s)
+}
+
+// ApproveStep represents the API handler to approve the
+// step, by stage and name, waiting on the executor.
+func ApproveStep(c *gin.Context) {
+	decide(c, c.Query("stage"), c.Param("step"), true)
+}
+
+// RejectStep represents the API handler to reject the
+// step, by stage and name, waiting on the executor.
+func RejectStep(c *gin.Context) {
+	decide(c, c.Query("stage"), c.Param("step"), false)
+}
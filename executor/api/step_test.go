@@ -0,0 +1,113 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package api
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestAPI_GetStep(t *testing.T) {
+	// setup types
+	_engine := testEngine(t)
+
+	// setup tests
+	tests := []struct {
+		path string
+		want int
+	}{
+		{ // step in execution
+			path: "/api/v1/executor/steps/echo",
+			want: http.StatusOK,
+		},
+		{ // step in unknown stage
+			path: "/api/v1/executor/steps/echo?stage=test",
+			want: http.StatusNotFound,
+		},
+		{ // step not in pipeline
+			path: "/api/v1/executor/steps/foo",
+			want: http.StatusNotFound,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got := testRequest(Router(_engine, "superSecret"), http.MethodGet, test.path, "superSecret")
+
+		if got.Code != test.want {
+			t.Errorf("GetStep for %s is %d, want %d", test.path, got.Code, test.want)
+		}
+	}
+}
+
+func TestAPI_CancelStep(t *testing.T) {
+	// setup types
+	_engine := testEngine(t)
+
+	// setup tests
+	tests := []struct {
+		path string
+		want int
+	}{
+		{ // step in execution
+			path: "/api/v1/executor/steps/echo/cancel",
+			want: http.StatusOK,
+		},
+		{ // step not in pipeline
+			path: "/api/v1/executor/steps/foo/cancel",
+			want: http.StatusNotFound,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got := testRequest(Router(_engine, "superSecret"), http.MethodDelete, test.path, "superSecret")
+
+		if got.Code != test.want {
+			t.Errorf("CancelStep for %s is %d, want %d", test.path, got.Code, test.want)
+		}
+	}
+}
+
+func TestAPI_ApproveStep(t *testing.T) {
+	// setup types
+	_engine := testEngine(t)
+
+	// setup tests
+	tests := []struct {
+		path string
+		want int
+	}{
+		{ // approve a step not waiting for approval
+			path: "/api/v1/executor/steps/echo/approve?user=octocat",
+			want: http.StatusConflict,
+		},
+		{ // reject a step not waiting for approval
+			path: "/api/v1/executor/steps/echo/reject?user=octocat",
+			want: http.StatusConflict,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got := testRequest(Router(_engine, "superSecret"), http.MethodPost, test.path, "superSecret")
+
+		if got.Code != test.want {
+			t.Errorf("POST %s is %d, want %d", test.path, got.Code, test.want)
+		}
+	}
+}
+
+func TestAPI_GetSteps(t *testing.T) {
+	// setup types
+	_engine := testEngine(t)
+
+	// run test
+	got := testRequest(Router(_engine, "superSecret"), http.MethodGet, "/api/v1/executor/steps", "superSecret")
+
+	if got.Code != http.StatusOK {
+		t.Errorf("GetSteps is %d, want %d", got.Code, http.StatusOK)
+	}
+}
@@ -8,6 +8,9 @@ import (
 	"context"
 	"sync"
 
+	"github.com/go-vela/pkg-executor/executor/event"
+	"github.com/go-vela/pkg-executor/executor/status"
+
 	"github.com/go-vela/types/library"
 	"github.com/go-vela/types/pipeline"
 )
@@ -33,9 +36,82 @@ type Engine interface {
 	// GetRepo defines a function for the API
 	// that gets the current repo in execution.
 	GetRepo() (*library.Repo, error)
+	// GetPhase defines a function for the API
+	// that gets the current phase of the build
+	// in execution.
+	GetPhase() (string, error)
+	// GetStep defines a function for the API
+	// that gets a step, by stage and name, from
+	// the current build in execution.
+	GetStep(string, string) (*library.Step, error)
+	// GetService defines a function for the API
+	// that gets a service, by name, from the
+	// current build in execution.
+	GetService(string) (*library.Service, error)
+	// GetServices defines a function for the API
+	// that gets a copy of the services for the
+	// current build in execution.
+	GetServices() ([]*library.Service, error)
+	// GetSteps defines a function for the API
+	// that gets a copy of the steps for the
+	// current build in execution.
+	GetSteps() ([]*library.Step, error)
+	// GetStatus defines a function for the API
+	// that gets a summary of the progress for
+	// the current build in execution.
+	GetStatus() (*status.Summary, error)
+	// GetPause defines a function for the API
+	// that gets the user that paused the current
+	// build in execution.
+	GetPause() (string, error)
 	// CancelBuild defines a function for the API
 	// that Cancels the current build in execution.
 	CancelBuild() (*library.Build, error)
+	// CancelStep defines a function for the API
+	// that cancels a step, by stage and name, from
+	// the current build in execution.
+	CancelStep(string, string) (*library.Step, error)
+	// CancelStage defines a function for the API
+	// that cancels the steps for a stage, by name,
+	// from the current build in execution.
+	CancelStage(string) ([]*library.Step, error)
+	// PauseBuild defines a function for the API
+	// that stops scheduling steps and stages, and
+	// optionally freezes the running containers,
+	// for the current build in execution.
+	PauseBuild(string, bool) error
+	// ResumeBuild defines a function for the API
+	// that continues scheduling steps and stages
+	// for the current build in execution.
+	ResumeBuild(string) error
+	// ApproveGate defines a function for the API
+	// that approves, with the user provided, the
+	// gate waiting for a stage or a step by stage
+	// and name.
+	ApproveGate(string, string, string) error
+	// RejectGate defines a function for the API
+	// that rejects, with the user provided, the
+	// gate waiting for a stage or a step by stage
+	// and name.
+	RejectGate(string, string, string) error
+
+	// Event Engine Interface Functions
+
+	// Subscribe defines a function that creates a
+	// channel, with the provided buffer size, that
+	// receives the events published for the build
+	// and a function to end the subscription.
+	Subscribe(int) (<-chan *event.Event, func())
+	// FollowStep defines a function that creates a
+	// channel receiving the masked output, from the
+	// provided offset, for a step by stage and name
+	// and a function to stop following the output.
+	FollowStep(string, string, int) (<-chan *event.Line, func(), error)
+	// FollowService defines a function that creates a
+	// channel receiving the masked output, from the
+	// provided offset, for a service by name and a
+	// function to stop following the output.
+	FollowService(string, int) (<-chan *event.Line, func(), error)
 
 	// Build Engine interface functions
 
@@ -52,6 +128,11 @@ type Engine interface {
 	AssembleBuild(context.Context) error
 	// ExecBuild defines a function that
 	// runs a pipeline for a build.
+	//
+	// The build is limited to the timeout for the repo,
+	// not counting the time it spends paused, so the
+	// context provided should not also be bounded by
+	// the timeout or paused time still counts towards it.
 	ExecBuild(context.Context) error
 	// DestroyBuild defines a function that
 	// cleans up the build after execution.
@@ -0,0 +1,100 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package event
+
+import (
+	"sync"
+)
+
+// Bus fans out the events published for a build to every subscriber.
+//
+// The zero value is ready to use. Publishing never blocks the executor,
+// so events are dropped for a subscriber that is not keeping up.
+type Bus struct {
+	mu          sync.RWMutex
+	closed      bool
+	subscribers map[chan *Event]struct{}
+}
+
+// Publish sends the event to every subscriber of the bus.
+func (b *Bus) Publish(e *Event) {
+	// check if the event provided is empty
+	if e == nil {
+		return
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	// iterate through all subscribers for the bus
+	for sub := range b.subscribers {
+		// attempt to send the event without blocking
+		select {
+		case sub <- e:
+		default:
+		}
+	}
+}
+
+// Subscribe creates a channel, buffered with the provided size,
+// which receives every event published after the call. The
+// returned function removes the subscription and closes the channel.
+func (b *Bus) Subscribe(size int) (<-chan *Event, func()) {
+	// check if the size provided is invalid
+	if size < 0 {
+		size = 0
+	}
+
+	sub := make(chan *Event, size)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// check if the bus has already been closed
+	if b.closed {
+		close(sub)
+
+		return sub, func() {}
+	}
+
+	// check if the subscribers have been initialized
+	if b.subscribers == nil {
+		b.subscribers = make(map[chan *Event]struct{})
+	}
+
+	b.subscribers[sub] = struct{}{}
+
+	return sub, func() { b.unsubscribe(sub) }
+}
+
+// Close removes every subscription from the bus and closes
+// their channels. Subscribing after a close returns a closed
+// channel and publishing becomes a no-op.
+func (b *Bus) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+
+	// iterate through all subscribers for the bus
+	for sub := range b.subscribers {
+		delete(b.subscribers, sub)
+		close(sub)
+	}
+}
+
+// unsubscribe removes the subscription from the bus.
+func (b *Bus) unsubscribe(sub chan *Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// check if the subscription was already removed
+	if _, ok := b.subscribers[sub]; !ok {
+		return
+	}
+
+	delete(b.subscribers, sub)
+	close(sub)
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package event
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEvent_Bus_Publish(t *testing.T) {
+	// setup types
+	_event := &Event{
+		Type:  StepStarted,
+		Build: 1,
+		Name:  "echo",
+	}
+
+	// setup tests
+	tests := []struct {
+		event *Event
+		size  int
+		want  int
+	}{
+		{ // event with a buffered subscriber
+			event: _event,
+			size:  1,
+			want:  1,
+		},
+		{ // event with a full subscriber
+			event: _event,
+			size:  0,
+			want:  0,
+		},
+		{ // empty event
+			event: nil,
+			size:  1,
+			want:  0,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		bus := new(Bus)
+
+		sub, cancel := bus.Subscribe(test.size)
+
+		bus.Publish(test.event)
+
+		if len(sub) != test.want {
+			t.Errorf("Publish sent %d events, want %d", len(sub), test.want)
+		}
+
+		if test.want > 0 {
+			got := <-sub
+
+			if !reflect.DeepEqual(got, test.event) {
+				t.Errorf("Publish is %v, want %v", got, test.event)
+			}
+		}
+
+		cancel()
+	}
+}
+
+func TestEvent_Bus_Subscribe(t *testing.T) {
+	// setup types
+	bus := new(Bus)
+
+	first, cancelFirst := bus.Subscribe(1)
+	second, cancelSecond := bus.Subscribe(1)
+
+	// run test
+	cancelFirst()
+
+	// cancel should be safe to call more than once
+	cancelFirst()
+
+	bus.Publish(&Event{Type: StageStarted, Name: "test"})
+
+	_, ok := <-first
+	if ok {
+		t.Errorf("Subscribe channel should be closed after cancel")
+	}
+
+	got := <-second
+	if got.Type != StageStarted {
+		t.Errorf("Subscribe received %s, want %s", got.Type, StageStarted)
+	}
+
+	cancelSecond()
+}
+
+func TestEvent_Bus_Close(t *testing.T) {
+	// setup types
+	bus := new(Bus)
+
+	sub, cancel := bus.Subscribe(1)
+
+	// run test
+	bus.Close()
+
+	// cancel after close should not panic
+	cancel()
+
+	_, ok := <-sub
+	if ok {
+		t.Errorf("Close should have closed the subscriber channel")
+	}
+
+	late, _ := bus.Subscribe(1)
+
+	_, ok = <-late
+	if ok {
+		t.Errorf("Subscribe after Close should return a closed channel")
+	}
+
+	// publish after close should not panic
+	bus.Publish(&Event{Type: LogChunk})
+}
@@ -0,0 +1,11 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+// Package event provides the ability for Vela to
+// publish the progress of a build to subscribers.
+//
+// Usage:
+//
+// 	import "github.com/go-vela/pkg-executor/executor/event"
+package event
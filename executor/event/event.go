@@ -0,0 +1,148 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package event
+
+// Type represents the kind of progress an Event records.
+type Type string
+
+// Build event types.
+const (
+	// BuildPhaseStarted defines the event type when
+	// a phase of the build has started.
+	BuildPhaseStarted Type = "build:phase:started"
+
+	// BuildPhaseFinished defines the event type when
+	// a phase of the build has finished.
+	BuildPhaseFinished Type = "build:phase:finished"
+
+	// BuildPaused defines the event type when the
+	// build has stopped scheduling steps and stages.
+	BuildPaused Type = "build:paused"
+
+	// BuildResumed defines the event type when a paused
+	// build has continued scheduling steps and stages.
+	BuildResumed Type = "build:resumed"
+)
+
+// Stage event types.
+const (
+	// StageWaiting defines the event type when a stage
+	// is waiting for the stages it needs to complete.
+	StageWaiting Type = "stage:waiting"
+
+	// StageQueued defines the event type when a stage
+	// is waiting for a slot to run with other stages.
+	StageQueued Type = "stage:queued"
+
+	// StageStarted defines the event type when a
+	// stage has started executing its steps.
+	StageStarted Type = "stage:started"
+
+	// StageFinished defines the event type when a
+	// stage has finished executing its steps.
+	StageFinished Type = "stage:finished"
+
+	// StageSkipped defines the event type when a stage is
+	// not run since a stage it needs did not succeed.
+	StageSkipped Type = "stage:skipped"
+)
+
+// Step event types.
+const (
+	// StepPlanned defines the event type when a
+	// step has been prepared for execution.
+	StepPlanned Type = "step:planned"
+
+	// StepStarted defines the event type when the
+	// container for a step has started running.
+	StepStarted Type = "step:started"
+
+	// StepExited defines the event type when the
+	// container for a step has exited.
+	StepExited Type = "step:exited"
+
+	// StepSkipped defines the event type when a step
+	// does not match the ruleset for the build.
+	StepSkipped Type = "step:skipped"
+
+	// StepCanceled defines the event type when a step
+	// has been canceled without canceling the build.
+	StepCanceled Type = "step:canceled"
+)
+
+// Gate event types.
+const (
+	// GateWaiting defines the event type when a stage
+	// or step is waiting for a manual approval.
+	GateWaiting Type = "gate:waiting"
+
+	// GateApproved defines the event type when a stage
+	// or step has been approved for execution.
+	GateApproved Type = "gate:approved"
+
+	// GateRejected defines the event type when a stage
+	// or step has been rejected or timed out.
+	GateRejected Type = "gate:rejected"
+)
+
+// Service event types.
+const (
+	// ServiceStarted defines the event type when the
+	// container for a service has started running.
+	ServiceStarted Type = "service:started"
+
+	// ServiceDied defines the event type when the
+	// container for a service has stopped running.
+	ServiceDied Type = "service:died"
+
+	// ServiceCrashed defines the event type when the container
+	// for a service has exited before the build has finished.
+	ServiceCrashed Type = "service:crashed"
+)
+
+// Secret and log event types.
+const (
+	// SecretResolved defines the event type when a
+	// secret has been pulled or produced by a plugin.
+	SecretResolved Type = "secret:resolved"
+
+	// LogChunk defines the event type when a container
+	// has produced output for a step or service.
+	LogChunk Type = "log:chunk"
+)
+
+// Build phases reported with the build event types.
+const (
+	// PhaseCreate defines the phase for CreateBuild.
+	PhaseCreate = "create"
+
+	// PhasePlan defines the phase for PlanBuild.
+	PhasePlan = "plan"
+
+	// PhaseAssemble defines the phase for AssembleBuild.
+	PhaseAssemble = "assemble"
+
+	// PhaseExec defines the phase for ExecBuild.
+	PhaseExec = "exec"
+
+	// PhaseDestroy defines the phase for DestroyBuild.
+	PhaseDestroy = "destroy"
+)
+
+// Event represents a moment in time record of
+// the progress made by the executor for a build.
+type Event struct {
+	Type      Type   `json:"type"`
+	Build     int    `json:"build,omitempty"`
+	Phase     string `json:"phase,omitempty"`
+	Stage     string `json:"stage,omitempty"`
+	Name      string `json:"name,omitempty"`
+	User      string `json:"user,omitempty"`
+	Status    string `json:"status,omitempty"`
+	ExitCode  int    `json:"exit_code,omitempty"`
+	Error     string `json:"error,omitempty"`
+	Data      []byte `json:"data,omitempty"`
+	Timestamp int64  `json:"timestamp"`
+}
@@ -0,0 +1,268 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package event
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+)
+
+const (
+	// HistorySize defines the number of lines
+	// retained for a container to replay to
+	// followers when they connect.
+	HistorySize = 1000
+
+	// FollowSize defines the number of lines buffered
+	// for a follower before it is considered too slow
+	// and disconnected.
+	FollowSize = 100
+
+	// mask defines the value secrets are replaced
+	// with in the output for a container.
+	mask = "***"
+)
+
+// Line represents a single line of output from a container.
+type Line struct {
+	Offset int    `json:"offset"`
+	Data   string `json:"data"`
+}
+
+// Log buffers the masked output of a container by line
+// and fans out every new line to the followers of the log.
+//
+// The zero value is ready to use but retains no history.
+type Log struct {
+	mu        sync.Mutex
+	closed    bool
+	size      int
+	offset    int
+	partial   []byte
+	history   []*Line
+	replacer  *strings.Replacer
+	notify    func(*Line)
+	followers map[chan *Line]struct{}
+}
+
+// NewLog creates a log retaining the provided number of lines.
+func NewLog(size int) *Log {
+	return &Log{size: size}
+}
+
+// Mask configures the secret values that are
+// replaced in every line written to the log.
+func (l *Log) Mask(secrets ...string) {
+	pairs := []string{}
+
+	// iterate through all secrets provided
+	for _, secret := range secrets {
+		// skip empty secrets to avoid masking every character
+		if len(strings.TrimSpace(secret)) == 0 {
+			continue
+		}
+
+		pairs = append(pairs, secret, mask)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// check if no secrets need to be masked
+	if len(pairs) == 0 {
+		l.replacer = nil
+
+		return
+	}
+
+	// https://pkg.go.dev/strings?tab=doc#NewReplacer
+	l.replacer = strings.NewReplacer(pairs...)
+}
+
+// Notify configures the function called with every
+// masked line as it is recorded in the log.
+//
+// The function is called while holding the lock
+// for the log so it must not block or use the log.
+func (l *Log) Notify(fn func(*Line)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.notify = fn
+}
+
+// Write splits the output into lines which are recorded
+// and sent to every follower of the log. A trailing partial
+// line is held until it is completed or the log is closed.
+//
+// Writing never fails so the log can safely be teed
+// from the stream of output for a container.
+func (l *Log) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// check if the log has already been closed
+	if l.closed {
+		return len(p), nil
+	}
+
+	data := append(l.partial, p...)
+
+	// iterate through all complete lines in the output
+	for {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			break
+		}
+
+		l.add(string(data[:i]))
+
+		data = data[i+1:]
+	}
+
+	// hold on to the partial line for the next write
+	l.partial = append([]byte(nil), data...)
+
+	return len(p), nil
+}
+
+// Follow creates a channel receiving the retained lines
+// starting from the offset provided followed by every
+// new line written to the log. The returned function
+// removes the follower and closes the channel.
+//
+// The channel is closed once the log is closed, or when
+// the follower falls too far behind. A follower may then
+// resume from the offset after the last line it received.
+func (l *Log) Follow(offset int) (<-chan *Line, func()) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	history := []*Line{}
+
+	retained := l.history
+
+	// only replay up to the size of the history
+	if len(retained) > l.size {
+		retained = retained[len(retained)-l.size:]
+	}
+
+	// capture the retained lines from the offset provided
+	for _, line := range retained {
+		if line.Offset >= offset {
+			history = append(history, line)
+		}
+	}
+
+	f := make(chan *Line, len(history)+FollowSize)
+
+	// send the retained lines to the follower
+	for _, line := range history {
+		f <- line
+	}
+
+	// check if the log has already been closed
+	if l.closed {
+		close(f)
+
+		return f, func() {}
+	}
+
+	// check if the followers have been initialized
+	if l.followers == nil {
+		l.followers = make(map[chan *Line]struct{})
+	}
+
+	l.followers[f] = struct{}{}
+
+	return f, func() { l.unfollow(f) }
+}
+
+// Close records any partial line left in the log
+// and closes the channels for all followers.
+func (l *Log) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// check if the log has already been closed
+	if l.closed {
+		return nil
+	}
+
+	// check if a partial line was left in the log
+	if len(l.partial) > 0 {
+		l.add(string(l.partial))
+
+		l.partial = nil
+	}
+
+	l.closed = true
+
+	// iterate through all followers for the log
+	for f := range l.followers {
+		delete(l.followers, f)
+		close(f)
+	}
+
+	return nil
+}
+
+// add masks and records the line before sending
+// it to the followers of the log.
+//
+// The caller must hold the lock for the log.
+func (l *Log) add(data string) {
+	// check if secrets need to be masked
+	if l.replacer != nil {
+		data = l.replacer.Replace(data)
+	}
+
+	line := &Line{Offset: l.offset, Data: data}
+
+	l.offset++
+
+	// check if the line should be sent to the notify function
+	if l.notify != nil {
+		l.notify(line)
+	}
+
+	// check if the line should be retained
+	if l.size > 0 {
+		l.history = append(l.history, line)
+
+		// compact the history once it doubles the size
+		// to avoid growing the underlying array forever
+		if len(l.history) >= 2*l.size {
+			l.history = append([]*Line(nil), l.history[len(l.history)-l.size:]...)
+		}
+	}
+
+	// iterate through all followers for the log
+	for f := range l.followers {
+		// attempt to send the line without blocking
+		select {
+		case f <- line:
+		default:
+			// disconnect the follower since it is too far behind
+			delete(l.followers, f)
+			close(f)
+		}
+	}
+}
+
+// unfollow removes the follower from the log.
+func (l *Log) unfollow(f chan *Line) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// check if the follower was already removed
+	if _, ok := l.followers[f]; !ok {
+		return
+	}
+
+	delete(l.followers, f)
+	close(f)
+}
@@ -0,0 +1,218 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package event
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEvent_Log_Write(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		secrets []string
+		writes  []string
+		want    []*Line
+	}{
+		{ // complete lines
+			writes: []string{"hello\nworld\n"},
+			want: []*Line{
+				{Offset: 0, Data: "hello"},
+				{Offset: 1, Data: "world"},
+			},
+		},
+		{ // lines split across writes
+			writes: []string{"hel", "lo\nwor", "ld\n"},
+			want: []*Line{
+				{Offset: 0, Data: "hello"},
+				{Offset: 1, Data: "world"},
+			},
+		},
+		{ // lines with secrets
+			secrets: []string{"superSecret", " "},
+			writes:  []string{"token is superSecret\n"},
+			want: []*Line{
+				{Offset: 0, Data: "token is ***"},
+			},
+		},
+		{ // partial line
+			writes: []string{"hello"},
+			want:   []*Line{},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		l := NewLog(HistorySize)
+
+		l.Mask(test.secrets...)
+
+		for _, w := range test.writes {
+			n, err := l.Write([]byte(w))
+			if err != nil {
+				t.Errorf("Write returned err: %v", err)
+			}
+
+			if n != len(w) {
+				t.Errorf("Write is %d, want %d", n, len(w))
+			}
+		}
+
+		lines, cancel := l.Follow(0)
+
+		got := []*Line{}
+		for len(lines) > 0 {
+			got = append(got, <-lines)
+		}
+
+		cancel()
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Write is %v, want %v", got, test.want)
+		}
+	}
+}
+
+func TestEvent_Log_Follow(t *testing.T) {
+	// setup types
+	l := NewLog(2)
+
+	_, _ = l.Write([]byte("one\ntwo\nthree\n"))
+
+	// setup tests
+	tests := []struct {
+		offset int
+		want   []*Line
+	}{
+		{ // replay the retained history
+			offset: 0,
+			want: []*Line{
+				{Offset: 1, Data: "two"},
+				{Offset: 2, Data: "three"},
+			},
+		},
+		{ // resume from an offset
+			offset: 2,
+			want: []*Line{
+				{Offset: 2, Data: "three"},
+			},
+		},
+		{ // resume past the end
+			offset: 3,
+			want:   []*Line{},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		lines, cancel := l.Follow(test.offset)
+
+		got := []*Line{}
+		for len(lines) > 0 {
+			got = append(got, <-lines)
+		}
+
+		cancel()
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Follow is %v, want %v", got, test.want)
+		}
+	}
+}
+
+func TestEvent_Log_Follow_Live(t *testing.T) {
+	// setup types
+	l := NewLog(HistorySize)
+
+	lines, cancel := l.Follow(0)
+	defer cancel()
+
+	// run test
+	_, _ = l.Write([]byte("hello\n"))
+
+	got := <-lines
+	if got.Data != "hello" {
+		t.Errorf("Follow received %s, want %s", got.Data, "hello")
+	}
+
+	// slow followers should be disconnected
+	for i := 0; i <= FollowSize; i++ {
+		_, _ = l.Write([]byte("line\n"))
+	}
+
+	count := 0
+	for range lines {
+		count++
+	}
+
+	if count != FollowSize {
+		t.Errorf("Follow received %d lines, want %d", count, FollowSize)
+	}
+}
+
+func TestEvent_Log_Notify(t *testing.T) {
+	// setup types
+	l := NewLog(HistorySize)
+
+	l.Mask("superSecret")
+
+	got := []*Line{}
+
+	l.Notify(func(line *Line) { got = append(got, line) })
+
+	want := []*Line{
+		{Offset: 0, Data: "hello"},
+		{Offset: 1, Data: "token is ***"},
+	}
+
+	// run test
+	_, _ = l.Write([]byte("hello\ntoken is super"))
+	_, _ = l.Write([]byte("Secret\n"))
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Notify is %v, want %v", got, want)
+	}
+}
+
+func TestEvent_Log_Close(t *testing.T) {
+	// setup types
+	l := NewLog(HistorySize)
+
+	lines, cancel := l.Follow(0)
+
+	_, _ = l.Write([]byte("partial"))
+
+	// run test
+	err := l.Close()
+	if err != nil {
+		t.Errorf("Close returned err: %v", err)
+	}
+
+	// cancel after close should not panic
+	cancel()
+
+	got := <-lines
+	if got == nil || got.Data != "partial" {
+		t.Errorf("Close should have flushed the partial line")
+	}
+
+	_, ok := <-lines
+	if ok {
+		t.Errorf("Close should have closed the follower channel")
+	}
+
+	// write after close should be ignored
+	_, err = l.Write([]byte("ignored\n"))
+	if err != nil {
+		t.Errorf("Write after Close returned err: %v", err)
+	}
+
+	late, _ := l.Follow(1)
+
+	_, ok = <-late
+	if ok {
+		t.Errorf("Follow after Close should return a closed channel")
+	}
+}
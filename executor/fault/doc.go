@@ -0,0 +1,11 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+// Package fault provides the ability for Vela to tell the
+// infrastructure errors for a build apart from failed steps.
+//
+// Usage:
+//
+// 	import "github.com/go-vela/pkg-executor/executor/fault"
+package fault
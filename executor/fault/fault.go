@@ -0,0 +1,92 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package fault
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrImagePull defines the error returned when
+	// the image for a container can not be pulled.
+	ErrImagePull = errors.New("image pull failed")
+
+	// ErrContainerStart defines the error returned
+	// when a container can not be started.
+	ErrContainerStart = errors.New("container start failed")
+
+	// ErrRuntimeUnavailable defines the error returned when
+	// the runtime can not report on a running container.
+	ErrRuntimeUnavailable = errors.New("runtime unavailable")
+
+	// ErrSecretResolution defines the error returned
+	// when a secret for the build can not be resolved.
+	ErrSecretResolution = errors.New("secret resolution failed")
+
+	// ErrUpload defines the error returned when the state
+	// of the build can not be uploaded to the server.
+	ErrUpload = errors.New("api upload failed")
+
+	// ErrTimeout defines the error returned when the
+	// build exceeds the time limit for the repo.
+	ErrTimeout = errors.New("timed out")
+)
+
+// Error represents an infrastructure error
+// for a resource, by name, of the build.
+type Error struct {
+	// Kind is the sentinel error describing the fault.
+	Kind error
+	// Name is the name of the resource with the fault.
+	Name string
+	// Err is the error that caused the fault.
+	Err error
+}
+
+// Wrap returns an infrastructure error of the kind provided,
+// for the resource by name, caused by the error provided or
+// nil when no error is provided.
+//
+// An error that already wraps an infrastructure error is
+// returned as is to keep the kind closest to the cause.
+func Wrap(kind error, name string, err error) error {
+	// check if an error was provided
+	if err == nil {
+		return nil
+	}
+
+	// check if the error is already an infrastructure error
+	var e *Error
+	if errors.As(err, &e) {
+		return err
+	}
+
+	return &Error{Kind: kind, Name: name, Err: err}
+}
+
+// Error returns the message for the infrastructure error.
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %v: %v", e.Name, e.Kind, e.Err)
+}
+
+// Is returns true when the target is
+// the kind of the infrastructure error.
+func (e *Error) Is(target error) bool {
+	return target == e.Kind
+}
+
+// Unwrap returns the error that caused the infrastructure error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Infrastructure returns true when the error provided,
+// or any error it wraps, is an infrastructure error.
+func Infrastructure(err error) bool {
+	var e *Error
+
+	return errors.As(err, &e)
+}
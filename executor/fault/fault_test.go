@@ -0,0 +1,72 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package fault
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestFault_Wrap(t *testing.T) {
+	// setup types
+	cause := errors.New("connection refused")
+
+	// setup tests
+	tests := []struct {
+		kind error
+		err  error
+		want string
+	}{
+		{
+			kind: ErrContainerStart,
+			err:  cause,
+			want: "echo: container start failed: connection refused",
+		},
+		{
+			kind: ErrTimeout,
+			err:  fmt.Errorf("unable to execute step: %w", Wrap(ErrContainerStart, "echo", cause)),
+			want: "unable to execute step: echo: container start failed: connection refused",
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got := Wrap(test.kind, "echo", test.err)
+
+		if got.Error() != test.want {
+			t.Errorf("Wrap is %s, want %s", got.Error(), test.want)
+		}
+
+		if !errors.Is(got, ErrContainerStart) {
+			t.Errorf("Wrap is not %v", ErrContainerStart)
+		}
+
+		if !errors.Is(got, cause) {
+			t.Errorf("Wrap does not wrap %v", cause)
+		}
+
+		var e *Error
+		if !errors.As(got, &e) || e.Name != "echo" {
+			t.Errorf("Wrap is not an Error for echo")
+		}
+
+		if !Infrastructure(got) {
+			t.Errorf("Infrastructure is false, want true")
+		}
+	}
+
+	if Wrap(ErrTimeout, "echo", nil) != nil {
+		t.Errorf("Wrap without an error should have returned nil")
+	}
+
+	if Infrastructure(cause) {
+		t.Errorf("Infrastructure is true, want false")
+	}
+
+	if errors.Is(Wrap(ErrImagePull, "echo", cause), ErrTimeout) {
+		t.Errorf("Wrap is %v, want only %v", ErrTimeout, ErrImagePull)
+	}
+}
@@ -5,6 +5,8 @@
 package executor
 
 import (
+	"time"
+
 	"github.com/go-vela/types/constants"
 
 	"github.com/urfave/cli/v2"
@@ -42,4 +44,136 @@ var Flags = []cli.Flag{
 		Usage:    "driver to be used for the executor",
 		Value:    constants.DriverLinux,
 	},
+	&cli.StringFlag{
+		EnvVars:  []string{"VELA_EXECUTOR_STOP_SIGNAL", "EXECUTOR_STOP_SIGNAL"},
+		FilePath: "/vela/executor/stop_signal",
+		Name:     "executor.stop.signal",
+		Usage:    "signal sent to containers when the build is canceled",
+		Value:    "SIGTERM",
+	},
+	&cli.DurationFlag{
+		EnvVars:  []string{"VELA_EXECUTOR_STOP_TIMEOUT", "EXECUTOR_STOP_TIMEOUT"},
+		FilePath: "/vela/executor/stop_timeout",
+		Name:     "executor.stop.timeout",
+		Usage:    "time to wait for containers to stop before they are killed",
+		Value:    10 * time.Second,
+	},
+	&cli.DurationFlag{
+		EnvVars:  []string{"VELA_EXECUTOR_APPROVAL_TIMEOUT", "EXECUTOR_APPROVAL_TIMEOUT"},
+		FilePath: "/vela/executor/approval_timeout",
+		Name:     "executor.approval.timeout",
+		Usage:    "time to wait for a manual approval before it is rejected",
+		Value:    60 * time.Minute,
+	},
+	&cli.DurationFlag{
+		EnvVars:  []string{"VELA_EXECUTOR_FINALIZER_TIMEOUT", "EXECUTOR_FINALIZER_TIMEOUT"},
+		FilePath: "/vela/executor/finalizer_timeout",
+		Name:     "executor.finalizer.timeout",
+		Usage:    "time the finalizer steps are given to run once the build has finished",
+		Value:    5 * time.Minute,
+	},
+	&cli.DurationFlag{
+		EnvVars:  []string{"VELA_EXECUTOR_READINESS_TIMEOUT", "EXECUTOR_READINESS_TIMEOUT"},
+		FilePath: "/vela/executor/readiness_timeout",
+		Name:     "executor.readiness.timeout",
+		Usage:    "time the services are given to pass their readiness probes before the steps run",
+		Value:    2 * time.Minute,
+	},
+	&cli.StringFlag{
+		EnvVars:  []string{"VELA_EXECUTOR_PROBE_IMAGE", "EXECUTOR_PROBE_IMAGE"},
+		FilePath: "/vela/executor/probe_image",
+		Name:     "executor.probe.image",
+		Usage:    "image running the readiness probes for services on the build network",
+		Value:    "alpine:latest",
+	},
+	&cli.StringFlag{
+		EnvVars:  []string{"VELA_EXECUTOR_STAGE_FAILURE", "EXECUTOR_STAGE_FAILURE"},
+		FilePath: "/vela/executor/stage_failure",
+		Name:     "executor.stage.failure",
+		Usage:    "policy for a failed stage (fail-fast or continue-independent)",
+		Value:    "fail-fast",
+	},
+	&cli.IntFlag{
+		EnvVars:  []string{"VELA_EXECUTOR_MAX_STAGES", "EXECUTOR_MAX_STAGES"},
+		FilePath: "/vela/executor/max_stages",
+		Name:     "executor.max.stages",
+		Usage:    "number of stages allowed to run at once (0 for no limit)",
+	},
+	&cli.IntFlag{
+		EnvVars:  []string{"VELA_EXECUTOR_MAX_PULLS", "EXECUTOR_MAX_PULLS"},
+		FilePath: "/vela/executor/max_pulls",
+		Name:     "executor.max.pulls",
+		Usage:    "number of images pulled, and services started, at once for a build",
+		Value:    4,
+	},
+	&cli.IntFlag{
+		EnvVars:  []string{"VELA_EXECUTOR_PULL_RETRIES", "EXECUTOR_PULL_RETRIES"},
+		FilePath: "/vela/executor/pull_retries",
+		Name:     "executor.pull.retries",
+		Usage:    "number of times a failed image pull is retried",
+		Value:    2,
+	},
+	&cli.DurationFlag{
+		EnvVars:  []string{"VELA_EXECUTOR_PULL_BACKOFF", "EXECUTOR_PULL_BACKOFF"},
+		FilePath: "/vela/executor/pull_backoff",
+		Name:     "executor.pull.backoff",
+		Usage:    "time waited before the first retry of an image pull, which doubles for each retry",
+		Value:    5 * time.Second,
+	},
+	&cli.DurationFlag{
+		EnvVars:  []string{"VELA_EXECUTOR_PULL_TIMEOUT", "EXECUTOR_PULL_TIMEOUT"},
+		FilePath: "/vela/executor/pull_timeout",
+		Name:     "executor.pull.timeout",
+		Usage:    "time each attempt to pull an image is given",
+		Value:    10 * time.Minute,
+	},
+	&cli.StringSliceFlag{
+		EnvVars:  []string{"VELA_EXECUTOR_MIRRORS", "EXECUTOR_MIRRORS"},
+		FilePath: "/vela/executor/mirrors",
+		Name:     "executor.mirrors",
+		Usage:    "rules rewriting images to registry mirrors (prefix=replacement with an optional ;fallback to the original image)",
+	},
+	&cli.StringSliceFlag{
+		EnvVars:  []string{"VELA_EXECUTOR_POLICY_ALLOWED_IMAGES", "EXECUTOR_POLICY_ALLOWED_IMAGES"},
+		FilePath: "/vela/executor/policy/allowed_images",
+		Name:     "executor.policy.allowed.images",
+		Usage:    "patterns for the images containers may run, where * matches anything (every image when unset)",
+	},
+	&cli.StringSliceFlag{
+		EnvVars:  []string{"VELA_EXECUTOR_POLICY_DENIED_IMAGES", "EXECUTOR_POLICY_DENIED_IMAGES"},
+		FilePath: "/vela/executor/policy/denied_images",
+		Name:     "executor.policy.denied.images",
+		Usage:    "patterns for the images containers may not run, where * matches anything",
+	},
+	&cli.StringFlag{
+		EnvVars:  []string{"VELA_EXECUTOR_POLICY_PRIVILEGED", "EXECUTOR_POLICY_PRIVILEGED"},
+		FilePath: "/vela/executor/policy/privileged",
+		Name:     "executor.policy.privileged",
+		Usage:    "repos allowed to run privileged containers (always, trusted or never)",
+		Value:    "always",
+	},
+	&cli.StringSliceFlag{
+		EnvVars:  []string{"VELA_EXECUTOR_POLICY_ALLOWED_VOLUMES", "EXECUTOR_POLICY_ALLOWED_VOLUMES"},
+		FilePath: "/vela/executor/policy/allowed_volumes",
+		Name:     "executor.policy.allowed.volumes",
+		Usage:    "patterns for the host paths containers may mount, where * matches anything (every path when unset)",
+	},
+	&cli.IntFlag{
+		EnvVars:  []string{"VELA_EXECUTOR_POLICY_MAX_SERVICES", "EXECUTOR_POLICY_MAX_SERVICES"},
+		FilePath: "/vela/executor/policy/max_services",
+		Name:     "executor.policy.max.services",
+		Usage:    "number of services a build may run (0 for no limit)",
+	},
+	&cli.IntFlag{
+		EnvVars:  []string{"VELA_EXECUTOR_POLICY_MAX_STEPS", "EXECUTOR_POLICY_MAX_STEPS"},
+		FilePath: "/vela/executor/policy/max_steps",
+		Name:     "executor.policy.max.steps",
+		Usage:    "number of steps, including the steps for stages, a build may run (0 for no limit)",
+	},
+	&cli.IntFlag{
+		EnvVars:  []string{"VELA_EXECUTOR_MAX_CONTAINERS", "EXECUTOR_MAX_CONTAINERS"},
+		FilePath: "/vela/executor/max_containers",
+		Name:     "executor.max.containers",
+		Usage:    "number of step containers allowed to run at once on the host, not counting services and detached steps (0 for no limit)",
+	},
 }
@@ -0,0 +1,11 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+// Package limit provides the ability for Vela to bound
+// the stages and containers that run at the same time.
+//
+// Usage:
+//
+// 	import "github.com/go-vela/pkg-executor/executor/limit"
+package limit
@@ -0,0 +1,95 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package limit
+
+import (
+	"context"
+)
+
+// Semaphore represents a fixed number of slots that is safe
+// to share between multiple engines in the same process.
+//
+// A nil semaphore, or one created without any slots,
+// does not limit anything.
+type Semaphore struct {
+	slots chan struct{}
+}
+
+// New creates a semaphore with the number of slots provided.
+//
+// A size of zero or less creates a semaphore without a limit.
+func New(size int) *Semaphore {
+	s := new(Semaphore)
+
+	// check if a limit is provided
+	if size > 0 {
+		s.slots = make(chan struct{}, size)
+	}
+
+	return s
+}
+
+// Acquire blocks until a slot is available and returns
+// an error once the context provided has been canceled.
+func (s *Semaphore) Acquire(ctx context.Context) error {
+	// check if the semaphore has a limit
+	if s == nil || s.slots == nil {
+		return nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case s.slots <- struct{}{}:
+		return nil
+	}
+}
+
+// TryAcquire takes a slot without blocking and
+// returns if a slot was available.
+func (s *Semaphore) TryAcquire() bool {
+	// check if the semaphore has a limit
+	if s == nil || s.slots == nil {
+		return true
+	}
+
+	select {
+	case s.slots <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// Release frees a slot taken from the semaphore.
+func (s *Semaphore) Release() {
+	// check if the semaphore has a limit
+	if s == nil || s.slots == nil {
+		return
+	}
+
+	<-s.slots
+}
+
+// Size returns the number of slots for the
+// semaphore or zero when it has no limit.
+func (s *Semaphore) Size() int {
+	// check if the semaphore has a limit
+	if s == nil {
+		return 0
+	}
+
+	return cap(s.slots)
+}
+
+// InUse returns the number of slots taken from the semaphore.
+func (s *Semaphore) InUse() int {
+	// check if the semaphore has a limit
+	if s == nil {
+		return 0
+	}
+
+	return len(s.slots)
+}
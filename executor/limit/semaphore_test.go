@@ -0,0 +1,102 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package limit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLimit_New(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		size int
+		want int
+	}{
+		{
+			size: 2,
+			want: 2,
+		},
+		{
+			size: 0,
+			want: 0,
+		},
+		{
+			size: -1,
+			want: 0,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got := New(test.size)
+
+		if got.Size() != test.want {
+			t.Errorf("New size is %d, want %d", got.Size(), test.want)
+		}
+	}
+}
+
+func TestLimit_Semaphore_Acquire(t *testing.T) {
+	// setup types
+	s := New(1)
+
+	// run test
+	err := s.Acquire(context.Background())
+	if err != nil {
+		t.Errorf("Acquire returned err: %v", err)
+	}
+
+	if s.TryAcquire() {
+		t.Errorf("TryAcquire is true, want false")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err = s.Acquire(ctx)
+	if err == nil {
+		t.Errorf("Acquire should have returned err")
+	}
+
+	if s.InUse() != 1 {
+		t.Errorf("InUse is %d, want 1", s.InUse())
+	}
+
+	s.Release()
+
+	if !s.TryAcquire() {
+		t.Errorf("TryAcquire is false, want true")
+	}
+}
+
+func TestLimit_Semaphore_Unlimited(t *testing.T) {
+	// setup tests
+	tests := []*Semaphore{
+		nil,
+		New(0),
+	}
+
+	// run tests
+	for _, s := range tests {
+		for i := 0; i < 10; i++ {
+			err := s.Acquire(context.Background())
+			if err != nil {
+				t.Errorf("Acquire returned err: %v", err)
+			}
+
+			if !s.TryAcquire() {
+				t.Errorf("TryAcquire is false, want true")
+			}
+		}
+
+		s.Release()
+
+		if s.InUse() != 0 {
+			t.Errorf("InUse is %d, want 0", s.InUse())
+		}
+	}
+}
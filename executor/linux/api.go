@@ -9,7 +9,9 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/go-vela/pkg-executor/executor/status"
 	"github.com/go-vela/pkg-executor/internal/service"
+	"github.com/go-vela/pkg-executor/internal/stage"
 	"github.com/go-vela/pkg-executor/internal/step"
 	"github.com/go-vela/types/constants"
 	"github.com/go-vela/types/library"
@@ -18,12 +20,18 @@ import (
 
 // GetBuild gets the current build in execution.
 func (c *client) GetBuild() (*library.Build, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	// check if the build resource is available
 	if c.build == nil {
 		return nil, fmt.Errorf("build resource not found")
 	}
 
-	return c.build, nil
+	// copy the build since it is updated during execution
+	copied := *c.build
+
+	return &copied, nil
 }
 
 // GetPipeline gets the current pipeline in execution.
@@ -46,110 +54,373 @@ func (c *client) GetRepo() (*library.Repo, error) {
 	return c.repo, nil
 }
 
-// CancelBuild cancels the current build in execution.
-// nolint: funlen // process of going through steps/services/stages is verbose and could be funcitonalized
-func (c *client) CancelBuild() (*library.Build, error) {
-	// get the current build from the client
-	b, err := c.GetBuild()
+// GetPhase gets the current phase of the build in execution.
+func (c *client) GetPhase() (string, error) {
+	// capture the phase currently in execution
+	phase, ok := c.phase.Load().(string)
+	if !ok {
+		return "", fmt.Errorf("build phase not found")
+	}
+
+	return phase, nil
+}
+
+// GetStep gets the step, by stage and name, from the build in execution.
+func (c *client) GetStep(stage, name string) (*library.Step, error) {
+	// get the current pipeline from the client
+	p, err := c.GetPipeline()
+	if err != nil {
+		return nil, err
+	}
+
+	// find the step from the pipeline
+	//
+	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/step#Find
+	ctn, err := step.Find(p, stage, name)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// load the step from the client
+	//
+	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/step#Load
+	s, err := step.Load(ctn, &c.steps)
+	if err != nil {
+		return nil, err
+	}
+
+	// copy the step since it is updated during execution
+	copied := *s
+
+	return &copied, nil
+}
+
+// GetService gets the service, by name, from the build in execution.
+func (c *client) GetService(name string) (*library.Service, error) {
+	// get the current pipeline from the client
+	p, err := c.GetPipeline()
+	if err != nil {
+		return nil, err
+	}
+
+	// find the service from the pipeline
+	//
+	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/service#Find
+	ctn, err := service.Find(p, name)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// load the service from the client
+	//
+	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/service#Load
+	s, err := service.Load(ctn, &c.services)
 	if err != nil {
 		return nil, err
 	}
 
-	// set the build status to canceled
-	b.SetStatus(constants.StatusCanceled)
+	// copy the service since it is updated during execution
+	copied := *s
+
+	return &copied, nil
+}
 
+// GetServices gets a copy of every service, in
+// pipeline order, tracked for the current build
+// in execution.
+func (c *client) GetServices() ([]*library.Service, error) {
 	// get the current pipeline from the client
-	pipeline, err := c.GetPipeline()
+	p, err := c.GetPipeline()
 	if err != nil {
 		return nil, err
 	}
 
-	// cancel non successful services
-	// nolint: dupl // false positive, steps/services are different
-	for _, _service := range pipeline.Services {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.copyServices(p), nil
+}
+
+// copyServices gets a copy of every service, in pipeline
+// order, tracked for the pipeline provided.
+//
+// The caller must hold the lock for the client.
+func (c *client) copyServices(p *pipeline.Build) []*library.Service {
+	services := []*library.Service{}
+
+	// iterate through all services in the pipeline
+	for _, _service := range p.Services {
 		// load the service from the client
 		//
 		// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/service#Load
 		s, err := service.Load(_service, &c.services)
 		if err != nil {
-			// create the library service object
-			s = new(library.Service)
-			s.SetName(_service.Name)
-			s.SetNumber(_service.Number)
-			s.SetImage(_service.Image)
-			s.SetStarted(time.Now().UTC().Unix())
-			s.SetHost(c.build.GetHost())
-			s.SetRuntime(c.build.GetRuntime())
-			s.SetDistribution(c.build.GetDistribution())
+			continue
 		}
 
-		// if service state was not terminal, set it as canceled
-		switch s.GetStatus() {
-		// service is in a error state
-		case constants.StatusError:
-			break
-		// service is in a failure state
-		case constants.StatusFailure:
-			break
-		// service is in a killed state
-		case constants.StatusKilled:
-			break
-		// service is in a success state
-		case constants.StatusSuccess:
-			break
-		default:
-			// update the service with a canceled state
-			s.SetStatus(constants.StatusCanceled)
-			// add a service to a map
-			c.services.Store(_service.ID, s)
-		}
+		// copy the service since it is updated during execution
+		copied := *s
+
+		services = append(services, &copied)
+	}
+
+	return services
+}
+
+// GetSteps gets a copy of every step, in pipeline
+// order, tracked for the current build in execution.
+func (c *client) GetSteps() ([]*library.Step, error) {
+	// get the current pipeline from the client
+	p, err := c.GetPipeline()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.copySteps(p), nil
+}
+
+// copySteps gets a copy of every step, in pipeline
+// order, tracked for the pipeline provided.
+//
+// The caller must hold the lock for the client.
+func (c *client) copySteps(p *pipeline.Build) []*library.Step {
+	// copy the steps to avoid modifying the pipeline
+	containers := append([]*pipeline.Container{}, p.Steps...)
+
+	// iterate through all stages in the pipeline
+	for _, _stage := range p.Stages {
+		containers = append(containers, _stage.Steps...)
 	}
 
-	// cancel non successful steps
-	// nolint: dupl // false positive, steps/services are different
-	for _, _step := range pipeline.Steps {
+	steps := []*library.Step{}
+
+	// iterate through all steps in the pipeline
+	for _, _step := range containers {
 		// load the step from the client
 		//
 		// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/step#Load
 		s, err := step.Load(_step, &c.steps)
 		if err != nil {
-			// create the library step object
-			s = new(library.Step)
-			s.SetName(_step.Name)
-			s.SetNumber(_step.Number)
-			s.SetImage(_step.Image)
-			s.SetStarted(time.Now().UTC().Unix())
-			s.SetHost(c.build.GetHost())
-			s.SetRuntime(c.build.GetRuntime())
-			s.SetDistribution(c.build.GetDistribution())
+			continue
 		}
 
-		// if step state was not terminal, set it as canceled
-		switch s.GetStatus() {
-		// step is in a error state
-		case constants.StatusError:
-			break
-		// step is in a failure state
-		case constants.StatusFailure:
-			break
-		// step is in a killed state
-		case constants.StatusKilled:
-			break
-		// step is in a success state
-		case constants.StatusSuccess:
-			break
-		default:
-			// update the step with a canceled state
-			s.SetStatus(constants.StatusCanceled)
-			// add a step to a map
-			c.steps.Store(_step.ID, s)
+		// copy the step since it is updated during execution
+		copied := *s
+
+		steps = append(steps, &copied)
+	}
+
+	return steps
+}
+
+// GetStatus gets a summary of the progress
+// for the current build in execution.
+func (c *client) GetStatus() (*status.Summary, error) {
+	// get the current pipeline from the client
+	p, err := c.GetPipeline()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &status.Summary{
+		Running:  []*status.Item{},
+		Queued:   []string{},
+		Services: []*status.Item{},
+		Steps:    []*status.Item{},
+		Stages:   map[string]string{},
+		Errors:   map[string]string{},
+	}
+
+	// capture the phase and paused state of the build
+	s.Phase, _ = c.GetPhase()
+	s.Paused, _ = c.GetPause()
+
+	// capture the progress of the build
+	// with a single acquisition of the lock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// check if the build resource is available
+	if c.build == nil {
+		return nil, fmt.Errorf("build resource not found")
+	}
+
+	s.Build = c.build.GetStatus()
+
+	// iterate through a copy of the services for the build
+	for _, _service := range c.copyServices(p) {
+		item := &status.Item{
+			Name:    _service.GetName(),
+			Status:  _service.GetStatus(),
+			Elapsed: status.Elapsed(_service.GetStarted(), _service.GetFinished()),
+		}
+
+		s.Services = append(s.Services, item)
+
+		// check if the service is running
+		if item.Status == constants.StatusRunning {
+			s.Running = append(s.Running, item)
 		}
 	}
 
-	// cancel non successful stages
-	for _, _stage := range pipeline.Stages {
-		// cancel non successful steps for that stage
+	// iterate through a copy of the steps for the build
+	for _, _step := range c.copySteps(p) {
+		item := &status.Item{
+			Name:    _step.GetName(),
+			Stage:   _step.GetStage(),
+			Status:  _step.GetStatus(),
+			Elapsed: status.Elapsed(_step.GetStarted(), _step.GetFinished()),
+		}
+
+		s.Steps = append(s.Steps, item)
+
+		// check if the step is running
+		if item.Status == constants.StatusRunning {
+			s.Running = append(s.Running, item)
+		}
+	}
+
+	// iterate through all stages in the pipeline
+	for _, _stage := range p.Stages {
+		// TODO: remove hardcoded reference
+		if _stage.Name == "init" {
+			continue
+		}
+
+		// load the tracker for the stage from the client
+		//
+		// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/stage#Load
+		t, err := stage.Load(_stage.Name, &c.stages)
+		if err == nil && t.Finished() {
+			s.Stages[_stage.Name] = t.Status()
+
+			// check if the stage finished with an error
+			if t.Err() != nil {
+				s.Errors[_stage.Name] = t.Err().Error()
+			}
+
+			continue
+		}
+
+		// check if the stage is waiting for a slot to run
+		if c.stageQueued(_stage) {
+			s.Stages[_stage.Name] = stage.StatusQueued
+			s.Queued = append(s.Queued, _stage.Name)
+
+			continue
+		}
+
+		queued := true
+
+		// check if any step for the stage has been tracked
 		for _, _step := range _stage.Steps {
+			// load the step from the client
+			//
+			// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/step#Load
+			_, err := step.Load(_step, &c.steps)
+			if err == nil {
+				queued = false
+
+				break
+			}
+		}
+
+		// check if the stage is queued
+		if queued {
+			s.Queued = append(s.Queued, _stage.Name)
+		}
+	}
+
+	return s, nil
+}
+
+// CancelBuild cancels the current build in execution.
+// nolint: funlen // process of going through steps/services/stages is verbose and could be funcitonalized
+func (c *client) CancelBuild() (*library.Build, error) {
+	// get the current build from the client
+	_, err := c.GetBuild()
+	if err != nil {
+		return nil, err
+	}
+
+	// track the containers that are running
+	containers := []*pipeline.Container{}
+
+	// get the current pipeline from the client
+	pipeline, err := c.GetPipeline()
+	if err != nil {
+		return nil, err
+	}
+
+	c.locked(func() {
+		// set the build status to canceled
+		c.build.SetStatus(constants.StatusCanceled)
+
+		// cancel non successful services
+		// nolint: dupl // false positive, steps/services are different
+		for _, _service := range pipeline.Services {
+			// load the service from the client
+			//
+			// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/service#Load
+			s, err := service.Load(_service, &c.services)
+			if err != nil {
+				// create the library service object
+				s = new(library.Service)
+				s.SetName(_service.Name)
+				s.SetNumber(_service.Number)
+				s.SetImage(_service.Image)
+				s.SetStarted(time.Now().UTC().Unix())
+				s.SetHost(c.build.GetHost())
+				s.SetRuntime(c.build.GetRuntime())
+				s.SetDistribution(c.build.GetDistribution())
+			}
+
+			// if service state was not terminal, set it as canceled
+			switch s.GetStatus() {
+			// service is in a error state
+			case constants.StatusError:
+				break
+			// service is in a failure state
+			case constants.StatusFailure:
+				break
+			// service is in a killed state
+			case constants.StatusKilled:
+				break
+			// service is in a success state
+			case constants.StatusSuccess:
+				break
+			default:
+				// check if the service is running
+				if s.GetStatus() == constants.StatusRunning {
+					containers = append(containers, _service)
+				}
+
+				// update the service with a canceled state
+				s.SetStatus(constants.StatusCanceled)
+				// add a service to a map
+				c.services.Store(_service.ID, s)
+			}
+		}
+
+		// cancel non successful steps
+		// nolint: dupl // false positive, steps/services are different
+		for _, _step := range pipeline.Steps {
+			// skip over finalizers, which still run once the build is canceled
+			//
+			// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/step#Finalizer
+			if step.Finalizer(_step) {
+				continue
+			}
+
 			// load the step from the client
 			//
 			// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/step#Load
@@ -160,34 +431,116 @@ func (c *client) CancelBuild() (*library.Build, error) {
 				s.SetName(_step.Name)
 				s.SetNumber(_step.Number)
 				s.SetImage(_step.Image)
-				s.SetStage(_stage.Name)
 				s.SetStarted(time.Now().UTC().Unix())
 				s.SetHost(c.build.GetHost())
 				s.SetRuntime(c.build.GetRuntime())
 				s.SetDistribution(c.build.GetDistribution())
 			}
 
-			// if stage state was not terminal, set it as canceled
+			// if step state was not terminal, set it as canceled
 			switch s.GetStatus() {
-			// stage is in a error state
+			// step is in a error state
 			case constants.StatusError:
 				break
-			// stage is in a failure state
+			// step is in a failure state
 			case constants.StatusFailure:
 				break
-			// stage is in a killed state
+			// step is in a killed state
 			case constants.StatusKilled:
 				break
-			// stage is in a success state
+			// step is in a success state
 			case constants.StatusSuccess:
 				break
 			default:
+				// check if the step is running
+				if s.GetStatus() == constants.StatusRunning && _step.Name != "init" {
+					containers = append(containers, _step)
+				}
+
 				// update the step with a canceled state
 				s.SetStatus(constants.StatusCanceled)
 				// add a step to a map
 				c.steps.Store(_step.ID, s)
 			}
 		}
+
+		// cancel non successful stages
+		for _, _stage := range pipeline.Stages {
+			// cancel non successful steps for that stage
+			for _, _step := range _stage.Steps {
+				// skip over finalizers, which still run once the build is canceled
+				//
+				// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/step#Finalizer
+				if step.Finalizer(_step) {
+					continue
+				}
+
+				// load the step from the client
+				//
+				// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/step#Load
+				s, err := step.Load(_step, &c.steps)
+				if err != nil {
+					// create the library step object
+					s = new(library.Step)
+					s.SetName(_step.Name)
+					s.SetNumber(_step.Number)
+					s.SetImage(_step.Image)
+					s.SetStage(_stage.Name)
+					s.SetStarted(time.Now().UTC().Unix())
+					s.SetHost(c.build.GetHost())
+					s.SetRuntime(c.build.GetRuntime())
+					s.SetDistribution(c.build.GetDistribution())
+				}
+
+				// if stage state was not terminal, set it as canceled
+				switch s.GetStatus() {
+				// stage is in a error state
+				case constants.StatusError:
+					break
+				// stage is in a failure state
+				case constants.StatusFailure:
+					break
+				// stage is in a killed state
+				case constants.StatusKilled:
+					break
+				// stage is in a success state
+				case constants.StatusSuccess:
+					break
+				default:
+					// check if the step is running
+					if s.GetStatus() == constants.StatusRunning && _step.Name != "init" {
+						containers = append(containers, _step)
+					}
+
+					// update the step with a canceled state
+					s.SetStatus(constants.StatusCanceled)
+					// add a step to a map
+					c.steps.Store(_step.ID, s)
+				}
+			}
+		}
+	})
+
+	// check if the build is paused
+	_, err = c.GetPause()
+	if err == nil {
+		// resume the build to thaw any frozen containers
+		err = c.ResumeBuild(c.user.GetName())
+		if err != nil {
+			c.logger.Errorf("unable to resume build: %v", err)
+		}
+	}
+
+	// cancel the contexts for the build in execution
+	c.cancelBuild()
+
+	// stop the running containers for the build
+	c.stopContainers(context.Background(), containers)
+
+	// wait for the output of the containers to flush
+	err = c.waitStreams()
+	if err != nil {
+		c.logger.Errorf("unable to flush container output: %v", err)
 	}
 
 	err = c.DestroyBuild(context.Background())
@@ -195,5 +548,5 @@ func (c *client) CancelBuild() (*library.Build, error) {
 		c.logger.Errorf("unable to destroy build: %v", err)
 	}
 
-	return b, nil
+	return c.GetBuild()
 }
@@ -7,23 +7,44 @@ package linux
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"golang.org/x/sync/errgroup"
 
+	"github.com/go-vela/pkg-executor/executor/event"
+	"github.com/go-vela/pkg-executor/executor/fault"
+	"github.com/go-vela/pkg-executor/executor/limit"
 	"github.com/go-vela/pkg-executor/internal/build"
+	"github.com/go-vela/pkg-executor/internal/stage"
 	"github.com/go-vela/pkg-executor/internal/step"
 	"github.com/go-vela/types/constants"
+	"github.com/go-vela/types/pipeline"
 )
 
 // CreateBuild configures the build for execution.
 func (c *client) CreateBuild(ctx context.Context) error {
+	// create a context that is canceled along with the build
+	ctx, cancel := c.buildContext(ctx)
+	defer cancel()
+
+	// publish an event for the start of the phase
+	c.publishPhase(event.BuildPhaseStarted, event.PhaseCreate, nil)
+
+	// defer publishing an event for the end of the phase
+	defer func() { c.publishPhase(event.BuildPhaseFinished, event.PhaseCreate, c.err) }()
+
 	// defer taking a snapshot of the build
 	//
 	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/build#Snapshot
-	defer func() { build.Snapshot(c.build, c.Vela, c.err, c.logger, c.repo) }()
+	defer c.syncBuild(func() { build.Snapshot(c.build, nil, c.err, c.logger, c.repo) })
+
+	// the executor starts every build from pending so the
+	// status is updated without validating the transition
+	c.mu.Lock()
 
 	// update the build fields
 	c.build.SetStatus(constants.StatusRunning)
@@ -32,13 +53,49 @@ func (c *client) CreateBuild(ctx context.Context) error {
 	c.build.SetDistribution(c.Driver())
 	c.build.SetRuntime(c.Runtime.Driver())
 
+	// copy the build since it is updated during execution
+	b := *c.build
+
+	c.mu.Unlock()
+
 	c.logger.Info("uploading build state")
 	// send API call to update the build
 	//
 	// https://pkg.go.dev/github.com/go-vela/sdk-go/vela?tab=doc#BuildService.Update
-	c.build, _, c.err = c.Vela.Build.Update(c.repo.GetOrg(), c.repo.GetName(), c.build)
+	_build, _, err := c.Vela.Build.Update(c.repo.GetOrg(), c.repo.GetName(), &b)
+	if err == nil {
+		c.mu.Lock()
+		c.build = _build
+		c.mu.Unlock()
+	}
+
+	c.err = err
+	if c.err != nil {
+		c.err = c.infraError(fault.ErrUpload, c.pipeline.ID, c.err)
+
+		return fmt.Errorf("unable to upload build state: %w", c.err)
+	}
+
+	// validate the stages needed by the stages in the pipeline
+	//
+	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/stage#Validate
+	c.err = stage.Validate(c.pipeline.Stages)
+	if c.err != nil {
+		return fmt.Errorf("unable to validate stages: %w", c.err)
+	}
+
+	// validate the steps the steps need
+	//
+	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/step#Dependencies
+	_, c.err = step.Dependencies(c.pipeline.Steps)
 	if c.err != nil {
-		return fmt.Errorf("unable to upload build state: %v", c.err)
+		return fmt.Errorf("unable to validate steps: %w", c.err)
+	}
+
+	// validate the lock groups shared by the pipeline
+	c.err = validateLocks(c.pipeline)
+	if c.err != nil {
+		return fmt.Errorf("unable to validate lock groups: %w", c.err)
 	}
 
 	// setup the runtime build
@@ -76,10 +133,20 @@ func (c *client) CreateBuild(ctx context.Context) error {
 //
 // nolint: funlen // ignore function length due to comments and logging messages
 func (c *client) PlanBuild(ctx context.Context) error {
+	// create a context that is canceled along with the build
+	ctx, cancel := c.buildContext(ctx)
+	defer cancel()
+
+	// publish an event for the start of the phase
+	c.publishPhase(event.BuildPhaseStarted, event.PhasePlan, nil)
+
+	// defer publishing an event for the end of the phase
+	defer func() { c.publishPhase(event.BuildPhaseFinished, event.PhasePlan, c.err) }()
+
 	// defer taking a snapshot of the build
 	//
 	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/build#Snapshot
-	defer func() { build.Snapshot(c.build, c.Vela, c.err, c.logger, c.repo) }()
+	defer c.syncBuild(func() { build.Snapshot(c.build, nil, c.err, c.logger, c.repo) })
 
 	// load the init step from the client
 	//
@@ -100,12 +167,25 @@ func (c *client) PlanBuild(ctx context.Context) error {
 	// defer taking a snapshot of the init step
 	//
 	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/step#SnapshotInit
-	defer func() { step.SnapshotInit(c.init, c.build, c.Vela, c.logger, c.repo, _init, _log) }()
+	defer func() {
+		c.syncStep(_init, c.logger, func() { step.SnapshotInit(c.init, c.build, nil, c.logger, c.repo, _init, _log) })
+
+		c.logger.Debug("uploading step logs")
+		// send API call to update the logs for the step
+		//
+		// https://pkg.go.dev/github.com/go-vela/sdk-go/vela?tab=doc#LogService.UpdateStep
+		_, _, err := c.Vela.Log.UpdateStep(c.repo.GetOrg(), c.repo.GetName(), c.number, c.init.Number, _log)
+		if err != nil {
+			c.logger.Errorf("unable to upload step logs: %v", err)
+		}
+	}()
 
 	c.logger.Info("creating network")
 	// create the runtime network for the pipeline
 	c.err = c.Runtime.CreateNetwork(ctx, c.pipeline)
 	if c.err != nil {
+		c.err = c.infraError(fault.ErrRuntimeUnavailable, c.pipeline.ID, c.err)
+
 		return fmt.Errorf("unable to create network: %w", c.err)
 	}
 
@@ -117,8 +197,8 @@ func (c *client) PlanBuild(ctx context.Context) error {
 	// inspect the runtime network for the pipeline
 	network, err := c.Runtime.InspectNetwork(ctx, c.pipeline)
 	if err != nil {
-		c.err = err
-		return fmt.Errorf("unable to inspect network: %w", err)
+		c.err = c.infraError(fault.ErrRuntimeUnavailable, c.pipeline.ID, err)
+		return fmt.Errorf("unable to inspect network: %w", c.err)
 	}
 
 	// update the init log with network information
@@ -130,6 +210,8 @@ func (c *client) PlanBuild(ctx context.Context) error {
 	// create the runtime volume for the pipeline
 	c.err = c.Runtime.CreateVolume(ctx, c.pipeline)
 	if c.err != nil {
+		c.err = c.infraError(fault.ErrRuntimeUnavailable, c.pipeline.ID, c.err)
+
 		return fmt.Errorf("unable to create volume: %w", c.err)
 	}
 
@@ -141,8 +223,8 @@ func (c *client) PlanBuild(ctx context.Context) error {
 	// inspect the runtime volume for the pipeline
 	volume, err := c.Runtime.InspectVolume(ctx, c.pipeline)
 	if err != nil {
-		c.err = err
-		return fmt.Errorf("unable to inspect volume: %w", err)
+		c.err = c.infraError(fault.ErrRuntimeUnavailable, c.pipeline.ID, err)
+		return fmt.Errorf("unable to inspect volume: %w", c.err)
 	}
 
 	// update the init log with volume information
@@ -166,8 +248,8 @@ func (c *client) PlanBuild(ctx context.Context) error {
 
 		s, err := c.secret.pull(secret)
 		if err != nil {
-			c.err = err
-			return fmt.Errorf("unable to pull secrets: %w", err)
+			c.err = c.infraError(fault.ErrSecretResolution, secret.Name, err)
+			return fmt.Errorf("unable to pull secrets: %w", c.err)
 		}
 
 		_log.AppendData([]byte(
@@ -184,6 +266,9 @@ func (c *client) PlanBuild(ctx context.Context) error {
 
 		// add secret to the map
 		c.Secrets[secret.Name] = s
+
+		// publish an event for the pulled secret
+		c.publish(&event.Event{Type: event.SecretResolved, Name: secret.Name})
 	}
 
 	return nil
@@ -193,10 +278,20 @@ func (c *client) PlanBuild(ctx context.Context) error {
 //
 // nolint: funlen // ignore function length due to comments and logging messages
 func (c *client) AssembleBuild(ctx context.Context) error {
+	// create a context that is canceled along with the build
+	ctx, cancel := c.buildContext(ctx)
+	defer cancel()
+
+	// publish an event for the start of the phase
+	c.publishPhase(event.BuildPhaseStarted, event.PhaseAssemble, nil)
+
+	// defer publishing an event for the end of the phase
+	defer func() { c.publishPhase(event.BuildPhaseFinished, event.PhaseAssemble, c.err) }()
+
 	// defer taking a snapshot of the build
 	//
 	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/build#Snapshot
-	defer func() { build.Snapshot(c.build, c.Vela, c.err, c.logger, c.repo) }()
+	defer c.syncBuild(func() { build.Snapshot(c.build, nil, c.err, c.logger, c.repo) })
 
 	// load the init step from the client
 	//
@@ -217,56 +312,43 @@ func (c *client) AssembleBuild(ctx context.Context) error {
 	// defer an upload of the init step
 	//
 	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/step#Upload
-	defer func() { step.Upload(c.init, c.build, c.Vela, c.logger, c.repo, _init) }()
+	defer c.syncStep(_init, c.logger, func() { step.Upload(c.init, c.build, nil, c.logger, c.repo, _init) })
 
 	defer func() {
 		c.logger.Infof("uploading %s step logs", c.init.Name)
 		// send API call to update the logs for the step
 		//
 		// https://pkg.go.dev/github.com/go-vela/sdk-go/vela?tab=doc#LogService.UpdateStep
-		_log, _, err = c.Vela.Log.UpdateStep(c.repo.GetOrg(), c.repo.GetName(), c.build.GetNumber(), c.init.Number, _log)
+		_log, _, err = c.Vela.Log.UpdateStep(c.repo.GetOrg(), c.repo.GetName(), c.number, c.init.Number, _log)
 		if err != nil {
 			c.logger.Errorf("unable to upload %s logs: %v", c.init.Name, err)
 		}
 	}()
 
-	// update the init log with progress
+	// check the containers for the pipeline against the policy
+	// and update the init log with every violation
 	//
 	// https://pkg.go.dev/github.com/go-vela/types/library?tab=doc#Log.AppendData
-	_log.AppendData([]byte("> Pulling service images...\n"))
+	c.err = c.enforcePolicy(_log.AppendData)
+	if c.err != nil {
+		return fmt.Errorf("unable to assemble build: %w", c.err)
+	}
+
+	// capture the containers for the pipeline, along with the
+	// progress for the init log, in the order they are written
+	pulls := []*pull{pullLine("> Pulling service images...\n")}
 
-	// create the services for the pipeline
+	// capture the services for the pipeline
 	for _, s := range c.pipeline.Services {
 		// TODO: remove this; but we need it for tests
 		s.Detach = true
 
-		c.logger.Infof("creating %s service", s.Name)
-		// create the service
-		c.err = c.CreateService(ctx, s)
-		if c.err != nil {
-			return fmt.Errorf("unable to create %s service: %w", s.Name, c.err)
-		}
-
-		c.logger.Infof("inspecting %s service", s.Name)
-		// inspect the service image
-		image, err := c.Runtime.InspectImage(ctx, s)
-		if err != nil {
-			c.err = err
-			return fmt.Errorf("unable to inspect %s service: %w", s.Name, err)
-		}
-
-		// update the init log with service image info
-		//
-		// https://pkg.go.dev/github.com/go-vela/types/library?tab=doc#Log.AppendData
-		_log.AppendData(image)
+		pulls = append(pulls, &pull{kind: "service", ctn: s, create: c.CreateService})
 	}
 
-	// update the init log with progress
-	//
-	// https://pkg.go.dev/github.com/go-vela/types/library?tab=doc#Log.AppendData
-	_log.AppendData([]byte("> Pulling stage images...\n"))
+	pulls = append(pulls, pullLine("> Pulling stage images...\n"))
 
-	// create the stages for the pipeline
+	// capture the stages for the pipeline
 	for _, s := range c.pipeline.Stages {
 		// TODO: remove hardcoded reference
 		//
@@ -275,85 +357,50 @@ func (c *client) AssembleBuild(ctx context.Context) error {
 			continue
 		}
 
-		c.logger.Infof("creating %s stage", s.Name)
-		// create the stage
-		c.err = c.CreateStage(ctx, s)
-		if c.err != nil {
-			return fmt.Errorf("unable to create %s stage: %w", s.Name, c.err)
-		}
+		pulls = append(pulls, c.stagePulls(s)...)
 	}
 
-	// update the init log with progress
-	//
-	// https://pkg.go.dev/github.com/go-vela/types/library?tab=doc#Log.AppendData
-	_log.AppendData([]byte("> Pulling step images...\n"))
+	pulls = append(pulls, pullLine("> Pulling step images...\n"))
 
-	// create the steps for the pipeline
+	// capture the steps for the pipeline
 	for _, s := range c.pipeline.Steps {
 		// TODO: remove hardcoded reference
 		if s.Name == "init" {
 			continue
 		}
 
-		c.logger.Infof("creating %s step", s.Name)
-		// create the step
-		c.err = c.CreateStep(ctx, s)
-		if c.err != nil {
-			return fmt.Errorf("unable to create %s step: %w", s.Name, c.err)
-		}
-
-		c.logger.Infof("inspecting %s step", s.Name)
-		// inspect the step image
-		image, err := c.Runtime.InspectImage(ctx, s)
-		if err != nil {
-			c.err = err
-			return fmt.Errorf("unable to inspect %s step: %w", s.Name, c.err)
-		}
-
-		// update the init log with step image info
-		//
-		// https://pkg.go.dev/github.com/go-vela/types/library?tab=doc#Log.AppendData
-		_log.AppendData(image)
+		pulls = append(pulls, &pull{kind: "step", ctn: s, create: c.CreateStep})
 	}
 
-	// update the init log with progress
-	//
-	// https://pkg.go.dev/github.com/go-vela/types/library?tab=doc#Log.AppendData
-	_log.AppendData([]byte("> Pulling secret images...\n"))
+	pulls = append(pulls, pullLine("> Pulling secret images...\n"))
 
-	// create the secrets for the pipeline
+	// capture the secrets for the pipeline
 	for _, s := range c.pipeline.Secrets {
 		// skip over non-plugin secrets
 		if s.Origin.Empty() {
 			continue
 		}
 
-		c.logger.Infof("creating %s secret", s.Origin.Name)
-		// create the service
-		c.err = c.secret.create(ctx, s.Origin)
-		if c.err != nil {
-			return fmt.Errorf("unable to create %s secret: %w", s.Origin.Name, c.err)
-		}
+		pulls = append(pulls, &pull{kind: "secret", ctn: s.Origin, create: c.secret.create})
+	}
 
-		c.logger.Infof("inspecting %s secret", s.Origin.Name)
-		// inspect the service image
-		image, err := c.Runtime.InspectImage(ctx, s.Origin)
-		if err != nil {
-			c.err = err
-			return fmt.Errorf("unable to inspect %s secret: %w", s.Origin.Name, err)
-		}
+	// create the containers for the pipeline, pulling their
+	// images in parallel, and update the init log with the
+	// image info for each container in pipeline order
+	//
+	// https://pkg.go.dev/github.com/go-vela/types/library?tab=doc#Log.AppendData
+	err = c.pullImages(ctx, pulls, _log.AppendData)
+	if err != nil {
+		c.err = errors.Unwrap(err)
 
-		// update the init log with secret image info
-		//
-		// https://pkg.go.dev/github.com/go-vela/types/library?tab=doc#Log.AppendData
-		_log.AppendData(image)
+		return err
 	}
 
 	// inspect the runtime build (eg a kubernetes pod) for the pipeline
 	buildOutput, err := c.Runtime.InspectBuild(ctx, c.pipeline)
 	if err != nil {
-		c.err = err
-		return fmt.Errorf("unable to inspect build: %w", err)
+		c.err = c.infraError(fault.ErrRuntimeUnavailable, c.pipeline.ID, err)
+		return fmt.Errorf("unable to inspect build: %w", c.err)
 	}
 
 	if len(buildOutput) > 0 {
@@ -367,6 +414,8 @@ func (c *client) AssembleBuild(ctx context.Context) error {
 	// assemble runtime build just before any containers execute
 	c.err = c.Runtime.AssembleBuild(ctx, c.pipeline)
 	if c.err != nil {
+		c.err = c.infraError(fault.ErrRuntimeUnavailable, c.pipeline.ID, c.err)
+
 		return fmt.Errorf("unable to assemble runtime build %s: %w", c.pipeline.ID, c.err)
 	}
 
@@ -382,71 +431,149 @@ func (c *client) AssembleBuild(ctx context.Context) error {
 		return fmt.Errorf("unable to execute secret: %w", c.err)
 	}
 
-	return c.err
+	// record the build was assembled so its finalizers run
+	atomic.StoreInt32(&c.assembled, 1)
+
+	return nil
 }
 
 // ExecBuild runs a pipeline for a build.
 //
 // nolint: funlen // ignore function length due to comments and log messages
-func (c *client) ExecBuild(ctx context.Context) error {
+func (c *client) ExecBuild(ctx context.Context) (err error) {
+	// create a context that is canceled along with the build
+	ctx, cancel := c.buildContext(ctx)
+	defer cancel()
+
+	// create a context that is canceled once the build exceeds
+	// its time limit, which does not run while it is paused
+	ctx, cancelLimit := c.timeLimit(ctx, time.Duration(c.repo.GetTimeout())*time.Minute)
+	defer cancelLimit()
+
+	// create a context that is canceled once a service
+	// with the fail crash policy exits unexpectedly
+	//
+	// https://pkg.go.dev/context?tab=doc#WithCancel
+	ctx, cancelCrash := context.WithCancel(ctx)
+	defer cancelCrash()
+
+	c.locked(func() { c.crashed = cancelCrash })
+
+	// publish an event for the start of the phase
+	c.publishPhase(event.BuildPhaseStarted, event.PhaseExec, nil)
+
+	// defer publishing an event for the end of the phase
+	defer func() { c.publishPhase(event.BuildPhaseFinished, event.PhaseExec, c.err) }()
+
 	// defer an upload of the build
 	//
 	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/build#Upload
-	defer func() { build.Upload(c.build, c.Vela, c.err, c.logger, c.repo) }()
+	defer c.syncBuild(func() { build.Upload(c.build, nil, c.err, c.logger, c.repo) })
 
-	// execute the services for the pipeline
-	for _, _service := range c.pipeline.Services {
-		c.logger.Infof("planning %s service", _service.Name)
-		// plan the service
-		c.err = c.PlanService(ctx, _service)
-		if c.err != nil {
-			return fmt.Errorf("unable to plan service: %w", c.err)
-		}
+	// defer stopping the detached steps, with a background context
+	// since the build may be canceled, so their final status is
+	// recorded before the build is uploaded
+	defer c.stopDetached(context.Background())
 
-		c.logger.Infof("executing %s service", _service.Name)
-		// execute the service
-		c.err = c.ExecService(ctx, _service)
-		if c.err != nil {
-			return fmt.Errorf("unable to execute service: %w", c.err)
+	// defer running the finalizers for the pipeline before the
+	// build is uploaded, unless the build is canceled, which runs
+	// them once the output for its containers has flushed
+	defer func() {
+		select {
+		case <-c.canceledBuild():
+		default:
+			c.finalize()
 		}
-	}
+	}()
 
-	// execute the steps for the pipeline
-	for _, _step := range c.pipeline.Steps {
-		// TODO: remove hardcoded reference
-		if _step.Name == "init" {
-			continue
+	// defer reporting the errors for a build that
+	// exceeded its time limit as a timeout
+	defer func() {
+		// check if the build exceeded its time limit
+		if c.timedOut() {
+			// https://pkg.go.dev/github.com/go-vela/pkg-executor/executor/fault#Wrap
+			c.err = fault.Wrap(fault.ErrTimeout, c.pipeline.ID, c.err)
+			err = fault.Wrap(fault.ErrTimeout, c.pipeline.ID, err)
 		}
+	}()
 
-		// check if the step should be skipped
-		//
-		// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/step#Skip
-		if step.Skip(_step, c.build, c.repo) {
-			continue
+	// defer reporting the error for a service
+	// that exited unexpectedly and failed the build
+	defer func() {
+		// check if a service failed the build
+		crash := c.crashError()
+		if crash != nil {
+			c.err = crash
+			err = fmt.Errorf("unable to execute build: %w", crash)
 		}
+	}()
 
-		c.logger.Infof("planning %s step", _step.Name)
-		// plan the step
-		c.err = c.PlanStep(ctx, _step)
-		if c.err != nil {
-			return fmt.Errorf("unable to plan step: %w", c.err)
-		}
+	// execute the services for the pipeline in parallel
+	err = c.startServices(ctx)
+	if err != nil {
+		c.err = errors.Unwrap(err)
+
+		return err
+	}
 
-		c.logger.Infof("executing %s step", _step.Name)
-		// execute the step
-		c.err = c.ExecStep(ctx, _step)
-		if c.err != nil {
-			return fmt.Errorf("unable to execute step: %w", c.err)
+	// wait for the services to pass their readiness probes
+	c.err = c.waitReady(ctx)
+	if c.err != nil {
+		return fmt.Errorf("unable to wait for services: %w", c.err)
+	}
+
+	// capture the steps each step needs to finish first
+	//
+	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/step#Dependencies
+	needs, err := step.Dependencies(c.pipeline.Steps)
+	if err != nil {
+		c.err = err
+
+		return fmt.Errorf("unable to validate steps: %w", c.err)
+	}
+
+	// check if any step declares the steps it needs
+	if len(needs) > 0 {
+		err = c.runSteps(ctx, needs)
+	} else {
+		// execute the steps for the pipeline in order
+		for _, _step := range c.pipeline.Steps {
+			err = c.runStep(ctx, _step)
+			if err != nil {
+				break
+			}
 		}
 	}
 
-	// create an error group with the context for each stage
+	if err != nil {
+		// capture the error without the context of the step
+		c.err = errors.Unwrap(err)
+
+		return err
+	}
+
+	// create a context for the stages that is canceled
+	// once a stage with the fail-fast policy fails
+	//
+	// https://pkg.go.dev/context?tab=doc#WithCancel
+	stageCtx, cancelStages := context.WithCancel(ctx)
+	defer cancelStages()
+
+	// create an error group for each stage
 	//
-	// https://pkg.go.dev/golang.org/x/sync/errgroup?tab=doc#WithContext
-	stages, stageCtx := errgroup.WithContext(ctx)
+	// https://pkg.go.dev/golang.org/x/sync/errgroup?tab=doc#Group
+	stages := new(errgroup.Group)
 
-	// create a map to track the progress of each stage
-	stageMap := new(sync.Map)
+	// capture the status of the build before any stage runs
+	c.stageBase = c.status()
+
+	// create a semaphore limiting the stages running at once
+	//
+	// https://pkg.go.dev/github.com/go-vela/pkg-executor/executor/limit#New
+	stageSlots := limit.New(c.maxStages)
+
+	// capture the map tracking the outcome of each stage
+	stageMap := &c.stages
 
 	// iterate through each stage in the pipeline
 	for _, _stage := range c.pipeline.Stages {
@@ -456,30 +583,27 @@ func (c *client) ExecBuild(ctx context.Context) error {
 		}
 
 		// https://golang.org/doc/faq#closures_and_goroutines
-		stage := _stage
+		s := _stage
 
-		// create a new channel for each stage in the map
-		stageMap.Store(stage.Name, make(chan error))
+		// create a new tracker for each stage in the map
+		//
+		// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/stage#NewTracker
+		stageMap.Store(s.Name, stage.NewTracker())
 
 		// spawn errgroup routine for the stage
 		//
 		// https://pkg.go.dev/golang.org/x/sync/errgroup?tab=doc#Group.Go
 		stages.Go(func() error {
-			c.logger.Infof("planning %s stage", stage.Name)
-			// plan the stage
-			c.err = c.PlanStage(stageCtx, stage, stageMap)
-			if c.err != nil {
-				return fmt.Errorf("unable to plan stage: %w", c.err)
-			}
+			err := c.runStage(stageCtx, s, stageMap, stageSlots)
 
-			c.logger.Infof("executing %s stage", stage.Name)
-			// execute the stage
-			c.err = c.ExecStage(stageCtx, stage, stageMap)
-			if c.err != nil {
-				return fmt.Errorf("unable to execute stage: %w", c.err)
+			// check if the failed stage stops every other stage
+			//
+			// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/stage#Policy
+			if err != nil && stage.Policy(s, c.failure) == stage.FailFast {
+				cancelStages()
 			}
 
-			return nil
+			return err
 		})
 	}
 
@@ -489,16 +613,240 @@ func (c *client) ExecBuild(ctx context.Context) error {
 	// https://pkg.go.dev/golang.org/x/sync/errgroup?tab=doc#Group.Wait
 	c.err = stages.Wait()
 	if c.err != nil {
-		return fmt.Errorf("unable to wait for stages: %v", c.err)
+		// capture the errors for every failed stage
+		c.err = c.stageErrors(stageMap, c.err)
+
+		return fmt.Errorf("unable to wait for stages: %w", c.err)
 	}
 
 	return c.err
 }
 
+// runStep plans and executes the step, from the steps for the
+// pipeline, once the build is not paused and the step has
+// been approved, if required, unless the step is skipped.
+func (c *client) runStep(ctx context.Context, ctn *pipeline.Container) error {
+	// TODO: remove hardcoded reference
+	if ctn.Name == "init" {
+		return nil
+	}
+
+	// check if the step is a finalizer, which
+	// runs once the build has finished
+	//
+	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/step#Finalizer
+	if step.Finalizer(ctn) {
+		return nil
+	}
+
+	// wait for the build to be resumed if it is paused
+	err := c.waitPaused(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to execute build: %w", err)
+	}
+
+	// check if the step should be skipped
+	//
+	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/step#Skip
+	if c.skip(ctn) {
+		// publish an event for the skipped step
+		c.publish(&event.Event{Type: event.StepSkipped, Name: ctn.Name})
+
+		return nil
+	}
+
+	// check if the step requires approval
+	if stepGated(ctn) {
+		// wait for the step to be approved
+		err = c.waitApproval(ctx, "", ctn.Name)
+		if err != nil {
+			return fmt.Errorf("unable to execute build: %w", err)
+		}
+	}
+
+	c.logger.Infof("planning %s step", ctn.Name)
+	// plan the step
+	err = c.PlanStep(ctx, ctn)
+	if err != nil {
+		return fmt.Errorf("unable to plan step: %w", err)
+	}
+
+	c.logger.Infof("executing %s step", ctn.Name)
+	// execute the step
+	err = c.ExecStep(ctx, ctn)
+	if err != nil {
+		return fmt.Errorf("unable to execute step: %w", err)
+	}
+
+	return nil
+}
+
+// runSteps executes the steps for the pipeline as soon as
+// the steps they need have finished, running the steps
+// that do not need each other at the same time.
+//
+// A step that returns an error only stops the steps that
+// need it, directly or through other steps, from running.
+func (c *client) runSteps(ctx context.Context, needs map[string][]string) error {
+	// result represents the outcome of a step
+	// once the channel for the step is closed
+	type result struct {
+		done chan struct{}
+		err  error
+	}
+
+	// create an error group for the steps, without a shared
+	// context, so a failed step does not cancel the others
+	//
+	// https://pkg.go.dev/golang.org/x/sync/errgroup?tab=doc#Group
+	steps := new(errgroup.Group)
+
+	results := make(map[string]*result, len(c.pipeline.Steps))
+
+	// iterate through all steps in the pipeline
+	for _, _step := range c.pipeline.Steps {
+		results[_step.Name] = &result{done: make(chan struct{})}
+	}
+
+	// iterate through all steps in the pipeline
+	for _, _step := range c.pipeline.Steps {
+		// https://golang.org/doc/faq#closures_and_goroutines
+		ctn := _step
+
+		// spawn errgroup routine for the step
+		//
+		// https://pkg.go.dev/golang.org/x/sync/errgroup?tab=doc#Group.Go
+		steps.Go(func() error {
+			r := results[ctn.Name]
+			defer close(r.done)
+
+			// wait for the steps the step needs to finish
+			for _, name := range needs[ctn.Name] {
+				need := results[name]
+
+				select {
+				case <-ctx.Done():
+					r.err = fmt.Errorf("unable to execute build: %w", ctx.Err())
+
+					return r.err
+				case <-need.done:
+				}
+
+				// check if the step needed returned an error, in which
+				// case the step is not run and the error is returned
+				// by the step needed
+				if need.err != nil {
+					r.err = need.err
+
+					return nil
+				}
+			}
+
+			r.err = c.runStep(ctx, ctn)
+
+			return r.err
+		})
+	}
+
+	// wait for the steps to complete or return an error
+	//
+	// https://pkg.go.dev/golang.org/x/sync/errgroup?tab=doc#Group.Wait
+	return steps.Wait()
+}
+
+// runStage plans and executes the stage once the build is
+// not paused, the stage has been approved, if required,
+// its lock group, if any, is free and a slot is
+// available for the stage to run.
+func (c *client) runStage(ctx context.Context, s *pipeline.Stage, m *sync.Map, slots *limit.Semaphore) error {
+	var err error
+
+	// defer recording the outcome of the stage, with the last
+	// error captured, in case the stage is never executed
+	defer func() { c.finishStage(s, m, err) }()
+
+	// wait for the build to be resumed if it is paused
+	err = c.waitPaused(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to plan stage: %w", err)
+	}
+
+	// check if the stage requires approval
+	if stageGated(s) {
+		// wait for the stage to be approved
+		err = c.waitApproval(ctx, s.Name, "")
+		if err != nil {
+			return fmt.Errorf("unable to plan stage: %w", err)
+		}
+	}
+
+	c.logger.Infof("planning %s stage", s.Name)
+	// plan the stage
+	err = c.PlanStage(ctx, s, m)
+	if err != nil {
+		// check if the stage is not run since a stage it needs did not
+		// succeed, which is recorded with the outcome of the stage
+		//
+		// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/stage#ErrNotRun
+		if errors.Is(err, stage.ErrNotRun) {
+			return nil
+		}
+
+		return fmt.Errorf("unable to plan stage: %w", err)
+	}
+
+	// wait for a slot for the stage to run
+	err = c.queueStage(ctx, s, slots)
+	if err != nil {
+		return fmt.Errorf("unable to queue stage: %w", err)
+	}
+
+	// release the slot once the stage is executed
+	defer slots.Release()
+
+	// wait for the lock group for the stage to be free, once the
+	// stage holds a slot, so a stage waiting for a slot never holds
+	// the lock group other stages with a slot are waiting for
+	unlock, err := c.lockStage(ctx, s)
+	if err != nil {
+		return fmt.Errorf("unable to lock stage: %w", err)
+	}
+
+	// release the lock group once the stage is executed
+	defer unlock()
+
+	c.logger.Infof("executing %s stage", s.Name)
+	// execute the stage
+	err = c.ExecStage(ctx, s, m)
+	if err != nil {
+		return fmt.Errorf("unable to execute stage: %w", err)
+	}
+
+	return nil
+}
+
 // DestroyBuild cleans up the build after execution.
 func (c *client) DestroyBuild(ctx context.Context) error {
 	var err error
 
+	// run the finalizers for the pipeline, if they have not
+	// already run, before the runtime build is removed
+	c.finalize()
+
+	// publish an event for the start of the phase
+	c.publishPhase(event.BuildPhaseStarted, event.PhaseDestroy, nil)
+
+	// defer publishing an event for the end of the phase
+	defer func() {
+		c.publishPhase(event.BuildPhaseFinished, event.PhaseDestroy, err)
+
+		// no further events are published once the build is destroyed
+		c.events.Close()
+
+		// no further output is produced once the build is destroyed
+		c.closeTails()
+	}()
+
 	defer func() {
 		c.logger.Info("deleting runtime build")
 		// remove the runtime build for the pipeline
@@ -538,6 +886,11 @@ func (c *client) DestroyBuild(ctx context.Context) error {
 		}
 	}
 
+	c.logger.Info("flushing container logs")
+	// wait for the output of every step to flush
+	// before the services they rely on are removed
+	c.flushStreams()
+
 	// destroy the services for the pipeline
 	for _, _service := range c.pipeline.Services {
 		c.logger.Infof("destroying %s service", _service.Name)
@@ -7,17 +7,27 @@ package linux
 import (
 	"context"
 	"flag"
+	"fmt"
 	"net/http/httptest"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-vela/compiler/compiler/native"
 	"github.com/go-vela/mock/server"
 	"github.com/urfave/cli/v2"
 
+	"github.com/go-vela/pkg-executor/executor/event"
+	"github.com/go-vela/pkg-executor/executor/limit"
+	"github.com/go-vela/pkg-executor/internal/stage"
+	"github.com/go-vela/pkg-executor/internal/step"
+
 	"github.com/go-vela/pkg-runtime/runtime/docker"
 
 	"github.com/go-vela/sdk-go/vela"
 
+	"github.com/go-vela/types/constants"
 	"github.com/go-vela/types/library"
 
 	"github.com/gin-gonic/gin"
@@ -118,6 +128,159 @@ func TestLinux_CreateBuild(t *testing.T) {
 	}
 }
 
+func TestLinux_CreateBuild_Needs(t *testing.T) {
+	// setup types
+	gin.SetMode(gin.TestMode)
+
+	s := httptest.NewServer(server.FakeHandler())
+
+	_client, err := vela.NewClient(s.URL, "", nil)
+	if err != nil {
+		t.Errorf("unable to create Vela API client: %v", err)
+	}
+
+	_runtime, err := docker.NewMock()
+	if err != nil {
+		t.Errorf("unable to create runtime engine: %v", err)
+	}
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		needs   map[string][]string
+	}{
+		{ // stages with needs
+			failure: false,
+			needs: map[string][]string{
+				"stage1": {"stage0"},
+				"stage2": {"stage0", "stage1"},
+			},
+		},
+		{ // stage needs unknown stage
+			failure: true,
+			needs: map[string][]string{
+				"stage1": {"foo"},
+			},
+		},
+		{ // stages need each other
+			failure: true,
+			needs: map[string][]string{
+				"stage0": {"stage2"},
+				"stage1": {"stage0"},
+				"stage2": {"stage1"},
+			},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		_pipeline := testStages(3, 1)
+
+		// update the stages with the stages they need
+		for _, s := range _pipeline.Stages {
+			s.Needs = test.needs[s.Name]
+		}
+
+		_engine, err := New(
+			WithBuild(testBuild()),
+			WithPipeline(_pipeline),
+			WithRepo(testRepo()),
+			WithRuntime(_runtime),
+			WithUser(testUser()),
+			WithVelaClient(_client),
+		)
+		if err != nil {
+			t.Errorf("unable to create executor engine: %v", err)
+		}
+
+		err = _engine.CreateBuild(context.Background())
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("CreateBuild should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("CreateBuild returned err: %v", err)
+		}
+	}
+}
+
+func TestLinux_CreateBuild_StepNeeds(t *testing.T) {
+	// setup types
+	gin.SetMode(gin.TestMode)
+
+	s := httptest.NewServer(server.FakeHandler())
+
+	_client, err := vela.NewClient(s.URL, "", nil)
+	if err != nil {
+		t.Errorf("unable to create Vela API client: %v", err)
+	}
+
+	_runtime, err := docker.NewMock()
+	if err != nil {
+		t.Errorf("unable to create runtime engine: %v", err)
+	}
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		needs   map[string]string
+	}{
+		{ // steps with needs
+			failure: false,
+			needs:   map[string]string{"echo": "init"},
+		},
+		{ // step needs unknown step
+			failure: true,
+			needs:   map[string]string{"echo": "foo"},
+		},
+		{ // steps need each other
+			failure: true,
+			needs:   map[string]string{"clone": "echo"},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		_pipeline := testSteps()
+
+		// update the steps with the steps they need
+		for _, _step := range _pipeline.Steps {
+			_step.Environment[step.NeedsKey] = test.needs[_step.Name]
+		}
+
+		_engine, err := New(
+			WithBuild(testBuild()),
+			WithPipeline(_pipeline),
+			WithRepo(testRepo()),
+			WithRuntime(_runtime),
+			WithUser(testUser()),
+			WithVelaClient(_client),
+		)
+		if err != nil {
+			t.Errorf("unable to create executor engine: %v", err)
+		}
+
+		err = _engine.CreateBuild(context.Background())
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("CreateBuild should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("CreateBuild returned err: %v", err)
+		}
+	}
+}
+
 func TestLinux_PlanBuild(t *testing.T) {
 	// setup types
 	compiler, _ := native.New(cli.NewContext(nil, flag.NewFlagSet("test", 0), nil))
@@ -465,6 +628,658 @@ func TestLinux_ExecBuild(t *testing.T) {
 	}
 }
 
+func TestLinux_ExecBuild_Needs(t *testing.T) {
+	// setup types
+	_pipeline := testStages(4, 1)
+
+	// fail the first stage with an image that is not found
+	_pipeline.Stages[1].Steps[0].Image = "alpine:notfound"
+
+	// update the stages with the stages they need
+	_pipeline.Stages[2].Needs = []string{"stage0"}
+	_pipeline.Stages[3].Needs = []string{"stage1"}
+	_pipeline.Stages[4].Needs = []string{"stage2"}
+
+	gin.SetMode(gin.TestMode)
+
+	s := httptest.NewServer(server.FakeHandler())
+
+	_client, err := vela.NewClient(s.URL, "", nil)
+	if err != nil {
+		t.Errorf("unable to create Vela API client: %v", err)
+	}
+
+	_runtime, err := docker.NewMock()
+	if err != nil {
+		t.Errorf("unable to create runtime engine: %v", err)
+	}
+
+	_engine, err := New(
+		WithBuild(testBuild()),
+		WithPipeline(_pipeline),
+		WithRepo(testRepo()),
+		WithRuntime(_runtime),
+		WithUser(testUser()),
+		WithVelaClient(_client),
+	)
+	if err != nil {
+		t.Errorf("unable to create executor engine: %v", err)
+	}
+
+	// run create to init steps to be created properly
+	err = _engine.CreateBuild(context.Background())
+	if err != nil {
+		t.Errorf("unable to create build: %v", err)
+	}
+
+	// the mock server responds with a build that is not running
+	_engine.build.SetStatus("running")
+
+	// run test
+	err = _engine.ExecBuild(context.Background())
+	if err == nil {
+		t.Errorf("ExecBuild should have returned err")
+	}
+
+	got, err := _engine.GetStatus()
+	if err != nil {
+		t.Errorf("GetStatus returned err: %v", err)
+	}
+
+	want := map[string]string{
+		"stage0": constants.StatusFailure,
+		"stage1": stage.StatusSkipped,
+		"stage2": stage.StatusSkipped,
+	}
+
+	// check the outcome of each stage that needs another stage
+	for name, status := range want {
+		if got.Stages[name] != status {
+			t.Errorf("ExecBuild %s stage is %s, want %s", name, got.Stages[name], status)
+		}
+	}
+
+	if len(got.Queued) > 0 {
+		t.Errorf("ExecBuild queued stages is %v, want none", got.Queued)
+	}
+}
+
+func TestLinux_ExecBuild_StageFailure(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		policy string
+		want   map[string]string
+	}{
+		{ // independent stage stopped
+			policy: stage.FailFast,
+			want: map[string]string{
+				"stage0": constants.StatusFailure,
+				"stage1": stage.StatusSkipped,
+				"stage2": constants.StatusCanceled,
+			},
+		},
+		{ // independent stage finished
+			policy: stage.ContinueIndependent,
+			want: map[string]string{
+				"stage0": constants.StatusFailure,
+				"stage1": stage.StatusSkipped,
+				"stage2": constants.StatusSuccess,
+			},
+		},
+	}
+
+	gin.SetMode(gin.TestMode)
+
+	s := httptest.NewServer(server.FakeHandler())
+
+	_client, err := vela.NewClient(s.URL, "", nil)
+	if err != nil {
+		t.Errorf("unable to create Vela API client: %v", err)
+	}
+
+	// run tests
+	for _, test := range tests {
+		_pipeline := testStages(3, 1)
+
+		// fail the first stage with an image that is not found
+		_pipeline.Stages[1].Steps[0].Image = "alpine:notfound"
+
+		// update the second stage to need the first stage
+		_pipeline.Stages[2].Needs = []string{"stage0"}
+
+		// hold the independent stage until it is approved
+		_pipeline.Stages[3].Steps[0].Environment[approvalKey] = approvalStage
+
+		_runtime, err := docker.NewMock()
+		if err != nil {
+			t.Errorf("unable to create runtime engine: %v", err)
+		}
+
+		_engine, err := New(
+			WithApprovalTimeout(time.Minute),
+			WithBuild(testBuild()),
+			WithPipeline(_pipeline),
+			WithRepo(testRepo()),
+			WithRuntime(_runtime),
+			WithStageFailure(test.policy),
+			WithUser(testUser()),
+			WithVelaClient(_client),
+		)
+		if err != nil {
+			t.Errorf("unable to create executor engine: %v", err)
+		}
+
+		// run create to init steps to be created properly
+		err = _engine.CreateBuild(context.Background())
+		if err != nil {
+			t.Errorf("unable to create build: %v", err)
+		}
+
+		// approve the independent stage when it keeps running
+		if test.policy == stage.ContinueIndependent {
+			go func() {
+				for _engine.ApproveGate("stage2", "", "octocat") != nil {
+					time.Sleep(time.Millisecond)
+				}
+			}()
+		}
+
+		err = _engine.ExecBuild(context.Background())
+		if err == nil || !strings.Contains(err.Error(), "stage0 stage") {
+			t.Errorf("ExecBuild returned err %v, want stage0 stage err", err)
+		}
+
+		got, err := _engine.GetStatus()
+		if err != nil {
+			t.Errorf("GetStatus returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(got.Stages, test.want) {
+			t.Errorf("ExecBuild stages is %v, want %v", got.Stages, test.want)
+		}
+
+		if len(got.Errors["stage0"]) == 0 {
+			t.Errorf("ExecBuild stage0 error is empty, want it recorded")
+		}
+	}
+}
+
+func TestLinux_ExecBuild_MaxStages(t *testing.T) {
+	// setup types
+	_containers := limit.New(1)
+
+	_runtime, err := docker.NewMock()
+	if err != nil {
+		t.Errorf("unable to create runtime engine: %v", err)
+	}
+
+	gin.SetMode(gin.TestMode)
+
+	s := httptest.NewServer(server.FakeHandler())
+
+	_client, err := vela.NewClient(s.URL, "", nil)
+	if err != nil {
+		t.Errorf("unable to create Vela API client: %v", err)
+	}
+
+	engines := []*client{}
+
+	// create the engines sharing the container limit
+	for i := 0; i < 2; i++ {
+		_pipeline := testStages(10, 2)
+
+		// hold each stage, once it runs, until it is approved
+		for _, _stage := range _pipeline.Stages[1:] {
+			_stage.Steps[0].Environment[approvalKey] = approvalStep
+		}
+
+		_engine, err := New(
+			WithBuild(testBuild()),
+			WithContainerLimit(_containers),
+			WithMaxStages(2),
+			WithPipeline(_pipeline),
+			WithRepo(testRepo()),
+			WithRuntime(_runtime),
+			WithUser(testUser()),
+			WithVelaClient(_client),
+		)
+		if err != nil {
+			t.Errorf("unable to create executor engine: %v", err)
+		}
+
+		// run create to init steps to be created properly
+		err = _engine.CreateBuild(context.Background())
+		if err != nil {
+			t.Errorf("unable to create build: %v", err)
+		}
+
+		engines = append(engines, _engine)
+	}
+
+	events, cancel := engines[0].Subscribe(1000)
+	defer cancel()
+
+	// run test
+	errs := make(chan error, len(engines))
+
+	for _, _engine := range engines {
+		go func(e *client) {
+			errs <- e.ExecBuild(context.Background())
+		}(_engine)
+	}
+
+	queued := func() []string {
+		got, _ := engines[0].GetStatus()
+
+		stages := []string{}
+
+		// capture the stages waiting for a slot
+		for _, name := range got.Queued {
+			if got.Stages[name] == stage.StatusQueued {
+				stages = append(stages, name)
+			}
+		}
+
+		return stages
+	}
+
+	// wait for the stages without a slot to be queued
+	for i := 0; i < 1000 && len(queued()) < 8; i++ {
+		time.Sleep(time.Millisecond)
+	}
+
+	if len(queued()) != 8 {
+		t.Errorf("ExecBuild queued stages is %v, want 8 stages", queued())
+	}
+
+	// approve every stage until the builds finish
+	for done := 0; done < len(engines); {
+		select {
+		case err := <-errs:
+			if err != nil {
+				t.Errorf("ExecBuild returned err: %v", err)
+			}
+
+			done++
+		case <-time.After(time.Millisecond):
+			for _, _engine := range engines {
+				for i := 0; i < 10; i++ {
+					_ = _engine.ApproveGate(fmt.Sprintf("stage%d", i), "echo0", "octocat")
+				}
+			}
+		}
+	}
+
+	running, max := 0, 0
+
+	// track the stages running at the same time
+	for len(events) > 0 {
+		e := <-events
+
+		switch e.Type {
+		case event.StageStarted:
+			running++
+		case event.StageFinished:
+			running--
+		}
+
+		if running > max {
+			max = running
+		}
+	}
+
+	if max != 2 {
+		t.Errorf("ExecBuild ran %d stages at once, want 2", max)
+	}
+
+	if _containers.InUse() != 0 {
+		t.Errorf("ExecBuild container slots in use is %d, want 0", _containers.InUse())
+	}
+}
+
+func TestLinux_ExecBuild_Locks(t *testing.T) {
+	// setup types
+	_pipeline := testStages(2, 1)
+
+	// hold each stage, once it runs, until it is approved
+	for _, _stage := range _pipeline.Stages[1:] {
+		_stage.Steps[0].Environment[stageLockKey] = "db"
+		_stage.Steps[0].Environment[approvalKey] = approvalStep
+	}
+
+	gin.SetMode(gin.TestMode)
+
+	s := httptest.NewServer(server.FakeHandler())
+
+	_client, err := vela.NewClient(s.URL, "", nil)
+	if err != nil {
+		t.Errorf("unable to create Vela API client: %v", err)
+	}
+
+	_runtime, err := docker.NewMock()
+	if err != nil {
+		t.Errorf("unable to create runtime engine: %v", err)
+	}
+
+	_engine, err := New(
+		WithBuild(testBuild()),
+		WithPipeline(_pipeline),
+		WithRepo(testRepo()),
+		WithRuntime(_runtime),
+		WithUser(testUser()),
+		WithVelaClient(_client),
+	)
+	if err != nil {
+		t.Errorf("unable to create executor engine: %v", err)
+	}
+
+	// run create to init steps to be created properly
+	err = _engine.CreateBuild(context.Background())
+	if err != nil {
+		t.Errorf("unable to create build: %v", err)
+	}
+
+	// logged returns the stages with a step log containing the text provided
+	logged := func(text string) []string {
+		stages := []string{}
+
+		for _, _stage := range _pipeline.Stages[1:] {
+			lines, stop := _engine.tail(_stage.Steps[0]).Follow(0)
+
+			for len(lines) > 0 {
+				line := <-lines
+				if strings.Contains(line.Data, text) {
+					stages = append(stages, _stage.Name)
+				}
+			}
+
+			stop()
+		}
+
+		return stages
+	}
+
+	// run test
+	done := make(chan error)
+
+	go func() {
+		done <- _engine.ExecBuild(context.Background())
+	}()
+
+	// wait for one stage to wait for the lock group
+	for i := 0; i < 1000 && len(logged("Waiting for lock group db")) == 0; i++ {
+		time.Sleep(time.Millisecond)
+	}
+
+	waiting := logged("Waiting for lock group db")
+	if len(waiting) != 1 {
+		t.Errorf("ExecBuild stages waiting for lock group is %v, want 1 stage", waiting)
+	}
+
+	// approve every stage until the build finishes
+	for finished := false; !finished; {
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Errorf("ExecBuild returned err: %v", err)
+			}
+
+			finished = true
+		case <-time.After(time.Millisecond):
+			_ = _engine.ApproveGate("stage0", "echo0", "octocat")
+			_ = _engine.ApproveGate("stage1", "echo0", "octocat")
+		}
+	}
+
+	locked := logged("Locked group db after waiting")
+	if len(locked) != 2 {
+		t.Errorf("ExecBuild stages locked is %v, want 2 stages", locked)
+	}
+}
+
+func TestLinux_ExecBuild_StepNeeds(t *testing.T) {
+	// setup types
+	_pipeline := testSteps()
+
+	// copy the echo step for a step running next to it
+	_test := *_pipeline.Steps[2]
+	_test.ID = "step_github_octocat_1_test"
+	_test.Name = "test"
+	_test.Number = 4
+	_test.Environment = map[string]string{"FOO": "bar"}
+
+	_pipeline.Steps = append(_pipeline.Steps, &_test)
+
+	// hold both steps needing the clone step until they are approved
+	for _, _step := range _pipeline.Steps[2:] {
+		_step.Environment[step.NeedsKey] = "clone"
+		_step.Environment[approvalKey] = approvalStep
+	}
+
+	gin.SetMode(gin.TestMode)
+
+	s := httptest.NewServer(server.FakeHandler())
+
+	_client, err := vela.NewClient(s.URL, "", nil)
+	if err != nil {
+		t.Errorf("unable to create Vela API client: %v", err)
+	}
+
+	_runtime, err := docker.NewMock()
+	if err != nil {
+		t.Errorf("unable to create runtime engine: %v", err)
+	}
+
+	_engine, err := New(
+		WithBuild(testBuild()),
+		WithPipeline(_pipeline),
+		WithRepo(testRepo()),
+		WithRuntime(_runtime),
+		WithUser(testUser()),
+		WithVelaClient(_client),
+	)
+	if err != nil {
+		t.Errorf("unable to create executor engine: %v", err)
+	}
+
+	// run create to init steps to be created properly
+	err = _engine.CreateBuild(context.Background())
+	if err != nil {
+		t.Errorf("unable to create build: %v", err)
+	}
+
+	events, cancel := _engine.Subscribe(100)
+	defer cancel()
+
+	// run test
+	done := make(chan error)
+
+	go func() {
+		done <- _engine.ExecBuild(context.Background())
+	}()
+
+	waiting := map[string]bool{}
+
+	// wait for both steps to wait for approval at the same time
+	for len(waiting) < 2 {
+		select {
+		case e := <-events:
+			if e.Type == event.GateWaiting {
+				waiting[e.Name] = true
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("ExecBuild steps waiting at once is %v, want echo and test", waiting)
+		}
+	}
+
+	for name := range waiting {
+		err = _engine.ApproveGate("", name, "octocat")
+		if err != nil {
+			t.Errorf("unable to approve %s step: %v", name, err)
+		}
+	}
+
+	err = <-done
+	if err != nil {
+		t.Errorf("ExecBuild returned err: %v", err)
+	}
+}
+
+func TestLinux_ExecBuild_StepNeedsFailure(t *testing.T) {
+	// setup types
+	_pipeline := testSteps()
+
+	// run the echo step next to the clone step
+	_pipeline.Steps[2].Environment[step.NeedsKey] = "init"
+
+	// fail the clone step with an image that is not found
+	_pipeline.Steps[1].Image = "target/vela-git:notfound"
+
+	gin.SetMode(gin.TestMode)
+
+	s := httptest.NewServer(server.FakeHandler())
+
+	_client, err := vela.NewClient(s.URL, "", nil)
+	if err != nil {
+		t.Errorf("unable to create Vela API client: %v", err)
+	}
+
+	_runtime, err := docker.NewMock()
+	if err != nil {
+		t.Errorf("unable to create runtime engine: %v", err)
+	}
+
+	_engine, err := New(
+		WithBuild(testBuild()),
+		WithPipeline(_pipeline),
+		WithRepo(testRepo()),
+		WithRuntime(_runtime),
+		WithUser(testUser()),
+		WithVelaClient(_client),
+	)
+	if err != nil {
+		t.Errorf("unable to create executor engine: %v", err)
+	}
+
+	// run create to init steps to be created properly
+	err = _engine.CreateBuild(context.Background())
+	if err != nil {
+		t.Errorf("unable to create build: %v", err)
+	}
+
+	// run test
+	err = _engine.ExecBuild(context.Background())
+	if err == nil {
+		t.Errorf("ExecBuild should have returned err")
+	}
+
+	// the echo step does not need the clone step so it still runs
+	got, err := _engine.GetStep("", "echo")
+	if err != nil {
+		t.Errorf("unable to get echo step: %v", err)
+	}
+
+	if got.GetStatus() != constants.StatusSuccess {
+		t.Errorf("ExecBuild echo step status is %s, want %s", got.GetStatus(), constants.StatusSuccess)
+	}
+}
+
+func TestLinux_ExecBuild_Parallel(t *testing.T) {
+	// setup types
+	_build := testBuild()
+	_repo := testRepo()
+	_user := testUser()
+	_pipeline := testStages(20, 3)
+
+	gin.SetMode(gin.TestMode)
+
+	s := httptest.NewServer(server.FakeHandler())
+
+	_client, err := vela.NewClient(s.URL, "", nil)
+	if err != nil {
+		t.Errorf("unable to create Vela API client: %v", err)
+	}
+
+	_runtime, err := docker.NewMock()
+	if err != nil {
+		t.Errorf("unable to create runtime engine: %v", err)
+	}
+
+	_engine, err := New(
+		WithBuild(_build),
+		WithPipeline(_pipeline),
+		WithRepo(_repo),
+		WithRuntime(_runtime),
+		WithUser(_user),
+		WithVelaClient(_client),
+	)
+	if err != nil {
+		t.Errorf("unable to create executor engine: %v", err)
+	}
+
+	// run create, plan and assemble to prepare the build
+	err = _engine.CreateBuild(context.Background())
+	if err != nil {
+		t.Errorf("unable to create build: %v", err)
+	}
+
+	err = _engine.PlanBuild(context.Background())
+	if err != nil {
+		t.Errorf("unable to plan build: %v", err)
+	}
+
+	err = _engine.AssembleBuild(context.Background())
+	if err != nil {
+		t.Errorf("unable to assemble build: %v", err)
+	}
+
+	// the mock server responds with a build that is not running
+	_engine.build.SetStatus("running")
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+
+	// read the state of the build while the stages execute
+	go func() {
+		defer close(done)
+
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+
+			_, _ = _engine.GetBuild()
+			_, _ = _engine.GetSteps()
+			_, _ = _engine.GetStatus()
+		}
+	}()
+
+	// run test
+	err = _engine.ExecBuild(context.Background())
+	if err != nil {
+		t.Errorf("ExecBuild returned err: %v", err)
+	}
+
+	close(stop)
+	<-done
+
+	got, err := _engine.GetStatus()
+	if err != nil {
+		t.Errorf("GetStatus returned err: %v", err)
+	}
+
+	if len(got.Steps) != 61 {
+		t.Errorf("GetStatus returned %d steps, want 61", len(got.Steps))
+	}
+
+	err = _engine.DestroyBuild(context.Background())
+	if err != nil {
+		t.Errorf("DestroyBuild returned err: %v", err)
+	}
+}
+
 func TestLinux_DestroyBuild(t *testing.T) {
 	// setup types
 	compiler, _ := native.New(cli.NewContext(nil, flag.NewFlagSet("test", 0), nil))
@@ -571,3 +1386,149 @@ func TestLinux_DestroyBuild(t *testing.T) {
 		}
 	}
 }
+
+func TestLinux_ExecBuild_Finalizers(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		name    string
+		failure bool
+		cancel  bool
+	}{
+		{
+			name: "success",
+		},
+		{
+			name:    "failure",
+			failure: true,
+		},
+		{
+			name:   "cancel",
+			cancel: true,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		// setup types
+		_pipeline := testSteps()
+
+		// copy the echo step for a finalizer
+		_notify := *_pipeline.Steps[2]
+		_notify.ID = "step_github_octocat_1_notify"
+		_notify.Name = "notify"
+		_notify.Number = 4
+		_notify.Environment = map[string]string{step.FinallyKey: "true"}
+
+		// add the finalizer before the echo step
+		_pipeline.Steps = append(_pipeline.Steps[:2], &_notify, _pipeline.Steps[2])
+
+		// check if the build should fail
+		if test.failure {
+			// fail the clone step with an image that is not found
+			_pipeline.Steps[1].Image = "target/vela-git:notfound"
+		}
+
+		// check if the build should be canceled
+		if test.cancel {
+			// hold the echo step until the build is canceled
+			_pipeline.Steps[3].Environment[approvalKey] = approvalStep
+		}
+
+		gin.SetMode(gin.TestMode)
+
+		s := httptest.NewServer(server.FakeHandler())
+
+		_client, err := vela.NewClient(s.URL, "", nil)
+		if err != nil {
+			t.Errorf("unable to create Vela API client: %v", err)
+		}
+
+		_runtime, err := docker.NewMock()
+		if err != nil {
+			t.Errorf("unable to create runtime engine: %v", err)
+		}
+
+		_engine, err := New(
+			WithBuild(testBuild()),
+			WithPipeline(_pipeline),
+			WithRepo(testRepo()),
+			WithRuntime(_runtime),
+			WithUser(testUser()),
+			WithVelaClient(_client),
+		)
+		if err != nil {
+			t.Errorf("unable to create executor engine: %v", err)
+		}
+
+		// run create to init steps to be created properly
+		err = _engine.CreateBuild(context.Background())
+		if err != nil {
+			t.Errorf("unable to create build: %v", err)
+		}
+
+		events, cancel := _engine.Subscribe(100)
+
+		// run test
+		done := make(chan error)
+
+		go func() {
+			done <- _engine.ExecBuild(context.Background())
+		}()
+
+		// check if the build should be canceled
+		if test.cancel {
+			// wait for the echo step to wait for approval
+			for e := range events {
+				if e.Type == event.GateWaiting {
+					break
+				}
+			}
+
+			_, err = _engine.CancelBuild()
+			if err != nil {
+				t.Errorf("%s CancelBuild returned err: %v", test.name, err)
+			}
+		}
+
+		err = <-done
+
+		if test.failure || test.cancel {
+			if err == nil {
+				t.Errorf("%s ExecBuild should have returned err", test.name)
+			}
+		} else if err != nil {
+			t.Errorf("%s ExecBuild returned err: %v", test.name, err)
+		}
+
+		// capture the finalizer once the build has finished
+		got, err := _engine.GetStep("", "notify")
+		if err != nil {
+			t.Errorf("%s unable to get finalizer: %v", test.name, err)
+
+			continue
+		}
+
+		if got.GetStatus() != constants.StatusSuccess {
+			t.Errorf("%s finalizer status is %s, want %s", test.name, got.GetStatus(), constants.StatusSuccess)
+		}
+
+		planned := []string{}
+
+		// capture the order the steps were planned in
+		for len(events) > 0 {
+			e := <-events
+
+			if e.Type == event.StepPlanned {
+				planned = append(planned, e.Name)
+			}
+		}
+
+		cancel()
+
+		// check if the finalizer was planned once after every other step
+		if len(planned) == 0 || planned[len(planned)-1] != "notify" ||
+			strings.Count(strings.Join(planned, ","), "notify") != 1 {
+			t.Errorf("%s steps planned in order %v, want notify once and last", test.name, planned)
+		}
+	}
+}
@@ -0,0 +1,461 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package linux
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-vela/pkg-runtime/runtime"
+	"github.com/go-vela/pkg-runtime/runtime/docker"
+
+	"github.com/go-vela/types/constants"
+	"github.com/go-vela/types/library"
+	"github.com/go-vela/types/pipeline"
+)
+
+func TestLinux_CancelStep(t *testing.T) {
+	// setup types
+	_steps := testSteps()
+
+	_runtime, err := docker.NewMock()
+	if err != nil {
+		t.Errorf("unable to create runtime engine: %v", err)
+	}
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		name    string
+		want    string
+	}{
+		{ // step in pipeline
+			failure: false,
+			name:    "echo",
+			want:    constants.StatusCanceled,
+		},
+		{ // init step
+			failure: true,
+			name:    "init",
+		},
+		{ // step not in pipeline
+			failure: true,
+			name:    "foo",
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		// the build is running while the step is canceled
+		_build := testBuild()
+		_build.SetStatus(constants.StatusRunning)
+
+		_engine, err := New(
+			WithBuild(_build),
+			WithPipeline(_steps),
+			WithRepo(testRepo()),
+			WithRuntime(_runtime),
+			WithUser(testUser()),
+		)
+		if err != nil {
+			t.Errorf("unable to create executor engine: %v", err)
+		}
+
+		got, err := _engine.CancelStep("", test.name)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("CancelStep should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("CancelStep returned err: %v", err)
+		}
+
+		if got.GetStatus() != test.want {
+			t.Errorf("CancelStep is %s, want %s", got.GetStatus(), test.want)
+		}
+
+		// executing the canceled step should not run the container
+		err = _engine.ExecStep(context.Background(), _steps.Steps[2])
+		if err != nil {
+			t.Errorf("ExecStep returned err: %v", err)
+		}
+
+		if _engine.build.GetStatus() != constants.StatusFailure {
+			t.Errorf("CancelStep build status is %s, want %s", _engine.build.GetStatus(), constants.StatusFailure)
+		}
+	}
+}
+
+func TestLinux_CancelStep_Running(t *testing.T) {
+	// setup types
+	_steps := testSteps()
+
+	_runtime, err := docker.NewMock()
+	if err != nil {
+		t.Errorf("unable to create runtime engine: %v", err)
+	}
+
+	_engine, err := New(
+		WithBuild(testBuild()),
+		WithPipeline(_steps),
+		WithRepo(testRepo()),
+		WithRuntime(_runtime),
+		WithUser(testUser()),
+	)
+	if err != nil {
+		t.Errorf("unable to create executor engine: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// track the step as running
+	_engine.running.Store(_steps.Steps[2].ID, cancel)
+
+	// run test
+	_, err = _engine.CancelStep("", "echo")
+	if err != nil {
+		t.Errorf("CancelStep returned err: %v", err)
+	}
+
+	if ctx.Err() == nil {
+		t.Errorf("CancelStep should have canceled the running step")
+	}
+}
+
+func TestLinux_CancelStage(t *testing.T) {
+	// setup types
+	_stages := &pipeline.Build{
+		Version: "1",
+		ID:      "github_octocat_1",
+		Stages: pipeline.StageSlice{
+			{
+				Name: "init",
+				Steps: pipeline.ContainerSlice{
+					{
+						ID:     "github_octocat_1_init_init",
+						Image:  "#init",
+						Name:   "init",
+						Number: 1,
+					},
+				},
+			},
+			{
+				Name: "test",
+				Steps: pipeline.ContainerSlice{
+					{
+						ID:     "github_octocat_1_test_echo",
+						Image:  "alpine:latest",
+						Name:   "echo",
+						Number: 2,
+					},
+					{
+						ID:     "github_octocat_1_test_lint",
+						Image:  "alpine:latest",
+						Name:   "lint",
+						Number: 3,
+					},
+				},
+			},
+		},
+	}
+
+	_runtime, err := docker.NewMock()
+	if err != nil {
+		t.Errorf("unable to create runtime engine: %v", err)
+	}
+
+	_engine, err := New(
+		WithBuild(testBuild()),
+		WithPipeline(_stages),
+		WithRepo(testRepo()),
+		WithRuntime(_runtime),
+		WithUser(testUser()),
+	)
+	if err != nil {
+		t.Errorf("unable to create executor engine: %v", err)
+	}
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		name    string
+		want    int
+	}{
+		{ // stage in pipeline
+			failure: false,
+			name:    "test",
+			want:    2,
+		},
+		{ // init stage
+			failure: true,
+			name:    "init",
+		},
+		{ // stage not in pipeline
+			failure: true,
+			name:    "foo",
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got, err := _engine.CancelStage(test.name)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("CancelStage should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("CancelStage returned err: %v", err)
+		}
+
+		if len(got) != test.want {
+			t.Errorf("CancelStage returned %d steps, want %d", len(got), test.want)
+		}
+
+		for _, s := range got {
+			if s.GetStatus() != constants.StatusCanceled {
+				t.Errorf("CancelStage step %s is %s, want %s", s.GetName(), s.GetStatus(), constants.StatusCanceled)
+			}
+
+			if s.GetStage() != test.name {
+				t.Errorf("CancelStage step %s stage is %s, want %s", s.GetName(), s.GetStage(), test.name)
+			}
+		}
+	}
+}
+
+func TestLinux_buildContext(t *testing.T) {
+	// setup types
+	_engine, err := New()
+	if err != nil {
+		t.Errorf("unable to create executor engine: %v", err)
+	}
+
+	ctx, cancel := _engine.buildContext(context.Background())
+	defer cancel()
+
+	// run test
+	_engine.cancelBuild()
+
+	// canceling more than once should not panic
+	_engine.cancelBuild()
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Errorf("buildContext should have been canceled with the build")
+	}
+
+	// contexts created after the cancel should already be done
+	late, lateCancel := _engine.buildContext(context.Background())
+	defer lateCancel()
+
+	if late.Err() == nil {
+		t.Errorf("buildContext should return a canceled context after cancelBuild")
+	}
+}
+
+func TestLinux_buildCanceled(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		canceled bool
+		expired  bool
+		want     bool
+	}{
+		{
+			canceled: false,
+			expired:  false,
+			want:     false,
+		},
+		{
+			canceled: true,
+			expired:  false,
+			want:     true,
+		},
+		{
+			canceled: true,
+			expired:  true,
+			want:     false,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		_engine, err := New(
+			WithBuild(testBuild()),
+		)
+		if err != nil {
+			t.Errorf("unable to create executor engine: %v", err)
+		}
+
+		// check if the build exceeded its time limit
+		if test.expired {
+			_engine.expired = 1
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		// check if the build was canceled
+		if test.canceled {
+			cancel()
+		}
+
+		_step := new(library.Step)
+		_step.SetStatus(constants.StatusRunning)
+
+		got := _engine.buildCanceled(ctx, _step)
+
+		cancel()
+
+		if got != test.want {
+			t.Errorf("buildCanceled is %v, want %v", got, test.want)
+		}
+
+		// check if the step was recorded as canceled
+		if test.want && _step.GetStatus() != constants.StatusCanceled {
+			t.Errorf("buildCanceled step status is %s, want %s", _step.GetStatus(), constants.StatusCanceled)
+		}
+
+		if !test.want && _step.GetStatus() != constants.StatusRunning {
+			t.Errorf("buildCanceled step status is %s, want %s", _step.GetStatus(), constants.StatusRunning)
+		}
+	}
+}
+
+func TestLinux_stopContainer(t *testing.T) {
+	// setup types
+	_mock, err := docker.NewMock()
+	if err != nil {
+		t.Errorf("unable to create runtime engine: %v", err)
+	}
+
+	// setup tests
+	tests := []struct {
+		killErr error
+		want    []string
+	}{
+		{ // container ignores the stop signal
+			killErr: nil,
+			want:    []string{"kill SIGINT", "wait", "remove"},
+		},
+		{ // runtime unable to signal the container
+			killErr: errors.New("kill failed"),
+			want:    []string{"kill SIGINT", "remove"},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		_runtime := &signalRuntime{Engine: _mock, killErr: test.killErr}
+
+		_engine, err := New(
+			WithPipeline(testSteps()),
+			WithRuntime(_runtime),
+			WithStopSignal("SIGINT"),
+			WithStopTimeout(10*time.Millisecond),
+		)
+		if err != nil {
+			t.Errorf("unable to create executor engine: %v", err)
+		}
+
+		err = _engine.stopContainer(context.Background(), testSteps().Steps[0])
+		if err != nil {
+			t.Errorf("stopContainer returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(_runtime.calls, test.want) {
+			t.Errorf("stopContainer calls are %v, want %v", _runtime.calls, test.want)
+		}
+	}
+}
+
+func TestLinux_stopContainers(t *testing.T) {
+	// setup types
+	_steps := testSteps()
+
+	_runtime, err := docker.NewMock()
+	if err != nil {
+		t.Errorf("unable to create runtime engine: %v", err)
+	}
+
+	_engine, err := New(
+		WithPipeline(_steps),
+		WithRuntime(_runtime),
+		WithStopTimeout(time.Second),
+	)
+	if err != nil {
+		t.Errorf("unable to create executor engine: %v", err)
+	}
+
+	// run test
+	_engine.stopContainers(context.Background(), _steps.Steps)
+}
+
+func TestLinux_waitStreams(t *testing.T) {
+	// setup types
+	_engine, err := New(
+		WithStopTimeout(10 * time.Millisecond),
+	)
+	if err != nil {
+		t.Errorf("unable to create executor engine: %v", err)
+	}
+
+	// run test
+	err = _engine.waitStreams()
+	if err != nil {
+		t.Errorf("waitStreams returned err: %v", err)
+	}
+
+	_engine.streams.Add(1)
+
+	err = _engine.waitStreams()
+	if err == nil {
+		t.Errorf("waitStreams should have returned err")
+	}
+
+	_engine.streams.Done()
+}
+
+// signalRuntime records the calls made to stop a container
+// whose process never exits on its own.
+type signalRuntime struct {
+	runtime.Engine
+
+	killErr error
+	calls   []string
+}
+
+func (r *signalRuntime) KillContainer(ctx context.Context, ctn *pipeline.Container, signal string) error {
+	r.calls = append(r.calls, "kill "+signal)
+
+	return r.killErr
+}
+
+func (r *signalRuntime) WaitContainer(ctx context.Context, ctn *pipeline.Container) error {
+	r.calls = append(r.calls, "wait")
+
+	<-ctx.Done()
+
+	return ctx.Err()
+}
+
+func (r *signalRuntime) RemoveContainer(ctx context.Context, ctn *pipeline.Container) error {
+	r.calls = append(r.calls, "remove")
+
+	return nil
+}
@@ -0,0 +1,241 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package linux
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-vela/pkg-executor/executor/event"
+	"github.com/go-vela/pkg-executor/internal/step"
+	"github.com/go-vela/types/constants"
+	"github.com/go-vela/types/library"
+	"github.com/go-vela/types/pipeline"
+)
+
+// detached represents the watch on a running detached step.
+type detached struct {
+	monitor
+
+	ctn  *pipeline.Container
+	step *library.Step
+}
+
+// watchDetached watches the container for the detached step in
+// the background and records the final status for the step
+// once the container exits on its own.
+func (c *client) watchDetached(ctn *pipeline.Container, s *library.Step) {
+	// create a context with a background context so the
+	// step is still watched after a build is canceled
+	//
+	// https://pkg.go.dev/context?tab=doc#WithCancel
+	ctx, cancel := context.WithCancel(context.Background())
+
+	d := &detached{
+		monitor: monitor{cancel: cancel, done: make(chan struct{})},
+		ctn:     ctn,
+		step:    s,
+	}
+
+	// add the detached step to the map
+	c.detached.Store(ctn.ID, d)
+
+	go func() {
+		defer close(d.done)
+
+		// wait for the runtime container to exit
+		err := c.Runtime.WaitContainer(ctx, ctn)
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err != nil {
+			c.logger.WithField("step", ctn.Name).Errorf("unable to wait for detached container: %v", err)
+
+			return
+		}
+
+		c.exitDetached(ctx, ctn, s, false)
+	}()
+}
+
+// exitDetached records the final status for the detached
+// step from the exit code for the container and applies
+// the detach policy for the step.
+//
+// A container that is killed, since it did not exit before it
+// is removed with the build, or that is unable to be inspected
+// is recorded as killed without applying the detach policy.
+func (c *client) exitDetached(ctx context.Context, ctn *pipeline.Container, s *library.Step, killed bool) {
+	// update engine logger with step metadata
+	//
+	// https://pkg.go.dev/github.com/sirupsen/logrus?tab=doc#Entry.WithField
+	logger := c.logger.WithField("step", ctn.Name)
+
+	// check if the container was not killed
+	if !killed {
+		logger.Debug("inspecting detached container")
+		// inspect the runtime container
+		err := c.Runtime.InspectContainer(ctx, ctn)
+		if err != nil {
+			logger.Errorf("unable to inspect detached container: %v", err)
+
+			// record the step as killed since the
+			// exit code for the container is unknown
+			killed = true
+		}
+	}
+
+	// check if the container was killed
+	if killed {
+		// update the container with the exit code for a killed container
+		//
+		// nolint: gomnd // ignore magic number 137
+		ctn.ExitCode = 137
+	}
+
+	// capture the detach policy for the step
+	//
+	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/step#DetachPolicy
+	policy, err := step.DetachPolicy(ctn)
+	if err != nil {
+		logger.Error(err)
+	}
+
+	var (
+		finished bool
+		number   int
+		st       library.Step
+	)
+
+	c.locked(func() {
+		// check if the step already reached a final status
+		if s.GetStatus() != constants.StatusRunning {
+			return
+		}
+
+		finished = true
+
+		// update the step with the exit code and finished timestamp
+		s.SetExitCode(ctn.ExitCode)
+		s.SetFinished(time.Now().UTC().Unix())
+
+		// handle the step based off the outcome for the exit code
+		//
+		// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/step#ExitOutcome
+		switch outcome := step.ExitOutcome(ctn); {
+		case killed:
+			// update the step fields to indicate the container
+			// was killed without applying the detach policy
+			s.SetStatus(constants.StatusKilled)
+		case outcome == step.ExitFailure:
+			// check if the failed step fails the build
+			if policy == step.DetachFail && !ctn.Ruleset.Continue {
+				// set build status to failure
+				c.build.SetStatus(constants.StatusFailure)
+			}
+
+			// update the step fields to indicate a failure
+			s.SetStatus(constants.StatusFailure)
+		case outcome == step.ExitSoftFailure:
+			// update the step fields to indicate a failure
+			// without updating the build status
+			s.SetStatus(constants.StatusFailure)
+		default:
+			// update the step fields to indicate a success
+			s.SetStatus(constants.StatusSuccess)
+		}
+
+		// copy the step since it is updated during execution
+		number, st = c.number, *s
+	})
+
+	// check if the step reached its final status
+	if !finished {
+		return
+	}
+
+	c.uploadStep(number, &st, logger)
+
+	logger.Infof("detached container exited with exit code %d", ctn.ExitCode)
+
+	// publish an event for the exited step
+	c.publish(&event.Event{
+		Type:     event.StepExited,
+		Stage:    s.GetStage(),
+		Name:     ctn.Name,
+		ExitCode: ctn.ExitCode,
+	})
+}
+
+// stopDetached stops the containers for every detached step
+// still running, records their final status and waits for
+// their output to flush.
+func (c *client) stopDetached(ctx context.Context) {
+	wg := new(sync.WaitGroup)
+
+	// iterate through all detached steps
+	c.detached.Range(func(key, value interface{}) bool {
+		// remove the detached step from the map
+		c.detached.Delete(key)
+
+		wg.Add(1)
+
+		go func(d *detached) {
+			defer wg.Done()
+
+			c.stopDetachedStep(ctx, d)
+		}(value.(*detached))
+
+		return true
+	})
+
+	wg.Wait()
+}
+
+// stopDetachedStep waits for the container for the detached step,
+// if it is still running, to exit and records its final status
+// before waiting for its output to flush.
+func (c *client) stopDetachedStep(ctx context.Context, d *detached) {
+	// update engine logger with step metadata
+	//
+	// https://pkg.go.dev/github.com/sirupsen/logrus?tab=doc#Entry.WithField
+	logger := c.logger.WithField("step", d.ctn.Name)
+
+	// stop watching the container so it is not
+	// recorded as exiting on its own once stopped
+	d.cancel()
+	<-d.done
+
+	var running bool
+
+	c.locked(func() { running = d.step.GetStatus() == constants.StatusRunning })
+
+	// check if the container is still running
+	if running {
+		logger.Debug("waiting for detached container")
+		// wait, up to the stop timeout, for the container to exit
+		exited := c.waitContainer(ctx, d.ctn)
+
+		// record the final status for the step, which is killed if
+		// the container did not exit since it is forcefully removed
+		// along with the build
+		c.exitDetached(ctx, d.ctn, d.step, !exited)
+
+		// check if the container did not exit, in which case
+		// its output finishes streaming once it is removed
+		if !exited {
+			return
+		}
+	}
+
+	logger.Debug("flushing logs for detached container")
+	// wait for the output for the step to flush
+	err := c.flushStream(d.ctn)
+	if err != nil {
+		logger.Errorf("unable to flush detached container output: %v", err)
+	}
+}
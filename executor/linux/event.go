@@ -0,0 +1,177 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package linux
+
+import (
+	"io"
+	"time"
+
+	"github.com/go-vela/pkg-executor/executor/event"
+	"github.com/go-vela/pkg-executor/internal/service"
+	"github.com/go-vela/pkg-executor/internal/step"
+	"github.com/go-vela/types/pipeline"
+)
+
+// Subscribe creates a channel receiving the events
+// published for the build from this point forward.
+func (c *client) Subscribe(size int) (<-chan *event.Event, func()) {
+	return c.events.Subscribe(size)
+}
+
+// publish stamps the event with the build metadata
+// and sends it to all subscribers of the client.
+func (c *client) publish(e *event.Event) {
+	// set the build number for the event
+	e.Build = c.number
+
+	// set the timestamp for the event
+	e.Timestamp = time.Now().UTC().Unix()
+
+	c.events.Publish(e)
+}
+
+// publishPhase sends a build phase event with the
+// error, if any, that the phase finished with.
+func (c *client) publishPhase(t event.Type, phase string, err error) {
+	e := &event.Event{
+		Type:   t,
+		Phase:  phase,
+		Status: c.status(),
+	}
+
+	// check if the phase is starting
+	if t == event.BuildPhaseStarted {
+		// track the phase currently in execution
+		c.phase.Store(phase)
+	}
+
+	// check if the phase returned an error
+	if err != nil {
+		e.Error = err.Error()
+	}
+
+	c.publish(e)
+}
+
+// logReader records the output read from a
+// container while it is streamed to the server.
+type logReader struct {
+	io.Reader
+
+	rc  io.Closer
+	log *event.Log
+}
+
+// Close closes the buffered log for the
+// container and the underlying output.
+func (r *logReader) Close() error {
+	// close the log to end all followers
+	_ = r.log.Close()
+
+	return r.rc.Close()
+}
+
+// tailLogs wraps the container output so every chunk read
+// from it is recorded in the buffered log for the container,
+// which publishes every masked line as a log event.
+func (c *client) tailLogs(rc io.ReadCloser, ctn *pipeline.Container) io.ReadCloser {
+	// capture the buffered log for the container
+	l := c.tail(ctn)
+
+	secrets := []string{}
+
+	// collect the secret values to mask in the log
+	for _, secret := range c.Secrets {
+		secrets = append(secrets, secret.GetValue())
+	}
+
+	l.Mask(secrets...)
+
+	return &logReader{
+		Reader: io.TeeReader(rc, l),
+		rc:     rc,
+		log:    l,
+	}
+}
+
+// tail captures the buffered log for the container,
+// creating it when the container has no output yet.
+func (c *client) tail(ctn *pipeline.Container) *event.Log {
+	// check if the container already has a buffered log
+	l, ok := c.tails.Load(ctn.ID)
+	if ok {
+		return l.(*event.Log)
+	}
+
+	buffer := event.NewLog(event.HistorySize)
+
+	// publish every masked line of output as a log event
+	buffer.Notify(func(line *event.Line) {
+		c.publish(&event.Event{
+			Type:  event.LogChunk,
+			Stage: ctn.Environment["VELA_STEP_STAGE"],
+			Name:  ctn.Name,
+			Data:  []byte(line.Data + "\n"),
+		})
+	})
+
+	l, _ = c.tails.LoadOrStore(ctn.ID, buffer)
+
+	return l.(*event.Log)
+}
+
+// FollowStep tails the masked output for the step,
+// by stage and name, starting from the offset provided.
+func (c *client) FollowStep(stage, name string, offset int) (<-chan *event.Line, func(), error) {
+	// get the current pipeline from the client
+	p, err := c.GetPipeline()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// find the step from the pipeline
+	//
+	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/step#Find
+	ctn, err := step.Find(p, stage, name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	lines, cancel := c.tail(ctn).Follow(offset)
+
+	return lines, cancel, nil
+}
+
+// FollowService tails the masked output for the
+// service, by name, starting from the offset provided.
+func (c *client) FollowService(name string, offset int) (<-chan *event.Line, func(), error) {
+	// get the current pipeline from the client
+	p, err := c.GetPipeline()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// find the service from the pipeline
+	//
+	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/service#Find
+	ctn, err := service.Find(p, name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	lines, cancel := c.tail(ctn).Follow(offset)
+
+	return lines, cancel, nil
+}
+
+// closeTails closes the buffered logs for all
+// containers to end any remaining followers.
+func (c *client) closeTails() {
+	c.tails.Range(func(_, l interface{}) bool {
+		_ = l.(*event.Log).Close()
+
+		return true
+	})
+}
@@ -0,0 +1,303 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package linux
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/go-vela/mock/server"
+
+	"github.com/go-vela/pkg-executor/executor/event"
+
+	"github.com/go-vela/pkg-runtime/runtime/docker"
+
+	"github.com/go-vela/sdk-go/vela"
+
+	"github.com/go-vela/types/library"
+)
+
+func TestLinux_Subscribe(t *testing.T) {
+	// setup types
+	_build := testBuild()
+
+	gin.SetMode(gin.TestMode)
+
+	s := httptest.NewServer(server.FakeHandler())
+
+	_client, err := vela.NewClient(s.URL, "", nil)
+	if err != nil {
+		t.Errorf("unable to create Vela API client: %v", err)
+	}
+
+	_runtime, err := docker.NewMock()
+	if err != nil {
+		t.Errorf("unable to create runtime engine: %v", err)
+	}
+
+	// setup tests
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{ // phase without error
+			err:  nil,
+			want: "",
+		},
+		{ // phase with error
+			err:  errors.New("foo"),
+			want: "foo",
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		_engine, err := New(
+			WithBuild(_build),
+			WithPipeline(testSteps()),
+			WithRepo(testRepo()),
+			WithRuntime(_runtime),
+			WithUser(testUser()),
+			WithVelaClient(_client),
+		)
+		if err != nil {
+			t.Errorf("unable to create executor engine: %v", err)
+		}
+
+		events, cancel := _engine.Subscribe(1)
+
+		_engine.publishPhase(event.BuildPhaseFinished, event.PhaseExec, test.err)
+
+		got := <-events
+
+		if got.Build != _build.GetNumber() {
+			t.Errorf("Subscribe build is %d, want %d", got.Build, _build.GetNumber())
+		}
+
+		if got.Phase != event.PhaseExec {
+			t.Errorf("Subscribe phase is %s, want %s", got.Phase, event.PhaseExec)
+		}
+
+		if got.Error != test.want {
+			t.Errorf("Subscribe error is %s, want %s", got.Error, test.want)
+		}
+
+		if got.Timestamp == 0 {
+			t.Errorf("Subscribe timestamp should be set")
+		}
+
+		cancel()
+	}
+}
+
+func TestLinux_tailLogs(t *testing.T) {
+	// setup types
+	gin.SetMode(gin.TestMode)
+
+	s := httptest.NewServer(server.FakeHandler())
+
+	_client, err := vela.NewClient(s.URL, "", nil)
+	if err != nil {
+		t.Errorf("unable to create Vela API client: %v", err)
+	}
+
+	_runtime, err := docker.NewMock()
+	if err != nil {
+		t.Errorf("unable to create runtime engine: %v", err)
+	}
+
+	_engine, err := New(
+		WithBuild(testBuild()),
+		WithPipeline(testSteps()),
+		WithRepo(testRepo()),
+		WithRuntime(_runtime),
+		WithUser(testUser()),
+		WithVelaClient(_client),
+	)
+	if err != nil {
+		t.Errorf("unable to create executor engine: %v", err)
+	}
+
+	_engine.Secrets["foo"] = &library.Secret{Value: vela.String("world")}
+
+	events, cancel := _engine.Subscribe(10)
+	defer cancel()
+
+	want := "hello\nworld\n"
+
+	// run test
+	rc := _engine.tailLogs(ioutil.NopCloser(strings.NewReader(want)), _engine.pipeline.Steps[2])
+
+	data, err := ioutil.ReadAll(rc)
+	if err != nil {
+		t.Errorf("tailLogs returned err: %v", err)
+	}
+
+	if string(data) != want {
+		t.Errorf("tailLogs read %s, want %s", data, want)
+	}
+
+	got := ""
+
+	for len(events) > 0 {
+		e := <-events
+
+		if e.Type != event.LogChunk || e.Name != "echo" {
+			t.Errorf("tailLogs published %s for %s, want %s for echo", e.Type, e.Name, event.LogChunk)
+		}
+
+		got += string(e.Data)
+	}
+
+	if got != "hello\n***\n" {
+		t.Errorf("tailLogs published %s, want %s", got, "hello\n***\n")
+	}
+
+	err = rc.Close()
+	if err != nil {
+		t.Errorf("tailLogs close returned err: %v", err)
+	}
+
+	lines, _, err := _engine.FollowStep("", "echo", 0)
+	if err != nil {
+		t.Errorf("FollowStep returned err: %v", err)
+	}
+
+	masked := []string{}
+	for line := range lines {
+		masked = append(masked, line.Data)
+	}
+
+	if !reflect.DeepEqual(masked, []string{"hello", "***"}) {
+		t.Errorf("tailLogs recorded %v, want %v", masked, []string{"hello", "***"})
+	}
+}
+
+func TestLinux_FollowStep(t *testing.T) {
+	// setup types
+	_engine, err := New(
+		WithBuild(testBuild()),
+		WithPipeline(testSteps()),
+	)
+	if err != nil {
+		t.Errorf("unable to create executor engine: %v", err)
+	}
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		engine  *client
+		name    string
+	}{
+		{ // step in pipeline
+			failure: false,
+			engine:  _engine,
+			name:    "echo",
+		},
+		{ // step not in pipeline
+			failure: true,
+			engine:  _engine,
+			name:    "foo",
+		},
+		{ // empty client
+			failure: true,
+			engine:  new(client),
+			name:    "echo",
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		lines, cancel, err := test.engine.FollowStep("", test.name, 0)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("FollowStep should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("FollowStep returned err: %v", err)
+		}
+
+		_, _ = test.engine.tail(test.engine.pipeline.Steps[2]).Write([]byte("hello\n"))
+
+		got := <-lines
+		if got.Data != "hello" {
+			t.Errorf("FollowStep received %s, want hello", got.Data)
+		}
+
+		cancel()
+	}
+}
+
+func TestLinux_FollowService(t *testing.T) {
+	// setup types
+	_engine, err := New(
+		WithBuild(testBuild()),
+		WithPipeline(testSteps()),
+	)
+	if err != nil {
+		t.Errorf("unable to create executor engine: %v", err)
+	}
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		engine  *client
+		name    string
+	}{
+		{ // service in pipeline
+			failure: false,
+			engine:  _engine,
+			name:    "postgres",
+		},
+		{ // service not in pipeline
+			failure: true,
+			engine:  _engine,
+			name:    "redis",
+		},
+		{ // empty client
+			failure: true,
+			engine:  new(client),
+			name:    "postgres",
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		lines, cancel, err := test.engine.FollowService(test.name, 0)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("FollowService should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("FollowService returned err: %v", err)
+		}
+
+		// closing the logs should end the followers
+		test.engine.closeTails()
+
+		_, ok := <-lines
+		if ok {
+			t.Errorf("FollowService channel should be closed")
+		}
+
+		cancel()
+	}
+}
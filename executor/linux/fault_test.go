@@ -0,0 +1,128 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package linux
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/go-vela/mock/server"
+
+	"github.com/go-vela/pkg-executor/executor/fault"
+
+	"github.com/go-vela/pkg-runtime/runtime/docker"
+
+	"github.com/go-vela/sdk-go/vela"
+
+	"github.com/go-vela/types/constants"
+	"github.com/go-vela/types/library"
+	"github.com/go-vela/types/pipeline"
+)
+
+func TestLinux_infraError(t *testing.T) {
+	// setup types
+	cause := errors.New("connection refused")
+
+	// setup tests
+	tests := []struct {
+		expired bool
+		want    error
+	}{
+		{
+			expired: false,
+			want:    fault.ErrContainerStart,
+		},
+		{
+			expired: true,
+			want:    fault.ErrTimeout,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		_engine, err := New()
+		if err != nil {
+			t.Errorf("unable to create executor engine: %v", err)
+		}
+
+		// check if the build exceeded its time limit
+		if test.expired {
+			_engine.expired = 1
+		}
+
+		got := _engine.infraError(fault.ErrContainerStart, "echo", cause)
+
+		if !errors.Is(got, test.want) {
+			t.Errorf("infraError is %v, want %v", got, test.want)
+		}
+
+		if !errors.Is(got, cause) {
+			t.Errorf("infraError does not wrap %v", cause)
+		}
+	}
+}
+
+func TestLinux_ExecStep_Fault(t *testing.T) {
+	// setup types
+	_step := &pipeline.Container{
+		ID:          "step_github_octocat_1_echo",
+		Directory:   "/vela/src/github.com/github/octocat",
+		Environment: map[string]string{"FOO": "bar"},
+		Image:       "alpine:notfound",
+		Name:        "echo",
+		Number:      1,
+		Pull:        "not_present",
+	}
+
+	gin.SetMode(gin.TestMode)
+
+	s := httptest.NewServer(server.FakeHandler())
+
+	_client, err := vela.NewClient(s.URL, "", nil)
+	if err != nil {
+		t.Errorf("unable to create Vela API client: %v", err)
+	}
+
+	_runtime, err := docker.NewMock()
+	if err != nil {
+		t.Errorf("unable to create runtime engine: %v", err)
+	}
+
+	_engine, err := New(
+		WithBuild(testBuild()),
+		WithPipeline(new(pipeline.Build)),
+		WithRepo(testRepo()),
+		WithRuntime(_runtime),
+		WithUser(testUser()),
+		WithVelaClient(_client),
+	)
+	if err != nil {
+		t.Errorf("unable to create executor engine: %v", err)
+	}
+
+	got := new(library.Step)
+
+	_engine.steps.Store(_step.ID, got)
+	_engine.stepLogs.Store(_step.ID, new(library.Log))
+
+	// run test
+	err = _engine.ExecStep(context.Background(), _step)
+
+	if !errors.Is(err, fault.ErrContainerStart) {
+		t.Errorf("ExecStep is %v, want %v", err, fault.ErrContainerStart)
+	}
+
+	if got.GetStatus() != constants.StatusError {
+		t.Errorf("ExecStep status is %s, want %s", got.GetStatus(), constants.StatusError)
+	}
+
+	if len(got.GetError()) == 0 {
+		t.Errorf("ExecStep error is empty, want the cause")
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package linux
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/go-vela/pkg-executor/internal/step"
+)
+
+// finalize runs the finalizers for the build once, after the
+// build succeeds, fails, exceeds its time limit or is canceled,
+// and before the build is destroyed.
+//
+// The finalizers run in the order they appear in the pipeline,
+// without regard to their ruleset or the build being paused,
+// and with their own time limit since the context for the
+// build may already be canceled.
+func (c *client) finalize() {
+	c.finally.Do(func() {
+		// capture the finalizers for the pipeline
+		//
+		// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/step#Finalizers
+		finalizers := step.Finalizers(c.pipeline)
+
+		// check if the pipeline has any finalizers
+		if len(finalizers) == 0 {
+			return
+		}
+
+		// check if the build was assembled since the finalizers
+		// would run without their images, volumes or secrets
+		if atomic.LoadInt32(&c.assembled) == 0 {
+			c.logger.Info("skipping finalizers since the build was not assembled")
+
+			return
+		}
+
+		// create a context that is canceled once the
+		// finalizers exceed their time limit
+		//
+		// https://pkg.go.dev/context?tab=doc#WithTimeout
+		ctx, cancel := context.WithTimeout(context.Background(), c.finalizer)
+		defer cancel()
+
+		// iterate through all finalizers for the pipeline
+		for _, _step := range finalizers {
+			c.logger.Infof("planning %s finalizer", _step.Name)
+			// plan the finalizer
+			err := c.PlanStep(ctx, _step)
+			if err != nil {
+				c.logger.Errorf("unable to plan %s finalizer: %v", _step.Name, err)
+
+				continue
+			}
+
+			c.logger.Infof("executing %s finalizer", _step.Name)
+			// execute the finalizer
+			err = c.ExecStep(ctx, _step)
+			if err != nil {
+				c.logger.Errorf("unable to execute %s finalizer: %v", _step.Name, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,194 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package linux
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-vela/pkg-executor/executor/event"
+	"github.com/go-vela/types/pipeline"
+)
+
+func TestLinux_waitApproval(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		failure bool
+		approve bool
+		timeout time.Duration
+		want    event.Type
+	}{
+		{ // gate approved
+			failure: false,
+			approve: true,
+			timeout: time.Minute,
+			want:    event.GateApproved,
+		},
+		{ // gate rejected
+			failure: true,
+			approve: false,
+			timeout: time.Minute,
+			want:    event.GateRejected,
+		},
+		{ // gate timed out
+			failure: true,
+			timeout: 10 * time.Millisecond,
+			want:    event.GateRejected,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		_engine, err := New(
+			WithApprovalTimeout(test.timeout),
+		)
+		if err != nil {
+			t.Errorf("unable to create executor engine: %v", err)
+		}
+
+		events, cancel := _engine.Subscribe(2)
+
+		done := make(chan error)
+
+		go func() {
+			done <- _engine.waitApproval(context.Background(), "test", "")
+		}()
+
+		waiting := <-events
+		if waiting.Type != event.GateWaiting {
+			t.Errorf("waitApproval published %s, want %s", waiting.Type, event.GateWaiting)
+		}
+
+		// decide the gate unless it should time out
+		if test.timeout == time.Minute {
+			if test.approve {
+				err = _engine.ApproveGate("test", "", "octocat")
+			} else {
+				err = _engine.RejectGate("test", "", "octocat")
+			}
+
+			if err != nil {
+				t.Errorf("unable to decide gate: %v", err)
+			}
+		}
+
+		err = <-done
+
+		cancel()
+
+		if test.failure && err == nil {
+			t.Errorf("waitApproval should have returned err")
+		}
+
+		if !test.failure && err != nil {
+			t.Errorf("waitApproval returned err: %v", err)
+		}
+
+		got := <-events
+		if got.Type != test.want {
+			t.Errorf("waitApproval published %s, want %s", got.Type, test.want)
+		}
+
+		// deciding a gate that is no longer waiting should fail
+		err = _engine.ApproveGate("test", "", "octocat")
+		if err == nil {
+			t.Errorf("ApproveGate should have returned err")
+		}
+	}
+}
+
+func TestLinux_gate_decide(t *testing.T) {
+	// setup types
+	g := &gate{done: make(chan struct{})}
+
+	// run test
+	err := g.decide("octocat", true)
+	if err != nil {
+		t.Errorf("decide returned err: %v", err)
+	}
+
+	err = g.decide("octokitty", false)
+	if err == nil {
+		t.Errorf("decide should have returned err")
+	}
+
+	if !g.approved || g.user != "octocat" {
+		t.Errorf("decide is %v by %s, want %v by %s", g.approved, g.user, true, "octocat")
+	}
+}
+
+func TestLinux_stageGated(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		stage *pipeline.Stage
+		want  bool
+	}{
+		{
+			stage: &pipeline.Stage{
+				Name: "deploy",
+				Steps: pipeline.ContainerSlice{
+					{Name: "deploy", Environment: map[string]string{"VELA_APPROVAL": "stage"}},
+				},
+			},
+			want: true,
+		},
+		{
+			stage: &pipeline.Stage{
+				Name: "deploy",
+				Steps: pipeline.ContainerSlice{
+					{Name: "deploy", Environment: map[string]string{"VELA_APPROVAL": "step"}},
+				},
+			},
+			want: false,
+		},
+		{
+			stage: &pipeline.Stage{
+				Name:  "test",
+				Steps: pipeline.ContainerSlice{{Name: "test"}},
+			},
+			want: false,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got := stageGated(test.stage)
+
+		if got != test.want {
+			t.Errorf("stageGated is %v, want %v", got, test.want)
+		}
+	}
+}
+
+func TestLinux_stepGated(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		step *pipeline.Container
+		want bool
+	}{
+		{
+			step: &pipeline.Container{Name: "deploy", Environment: map[string]string{"VELA_APPROVAL": "step"}},
+			want: true,
+		},
+		{
+			step: &pipeline.Container{Name: "deploy", Environment: map[string]string{"VELA_APPROVAL": "stage"}},
+			want: false,
+		},
+		{
+			step: &pipeline.Container{Name: "test"},
+			want: false,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got := stepGated(test.step)
+
+		if got != test.want {
+			t.Errorf("stepGated is %v, want %v", got, test.want)
+		}
+	}
+}
@@ -5,7 +5,17 @@
 package linux
 
 import (
+	"context"
 	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-vela/pkg-executor/executor/event"
+	"github.com/go-vela/pkg-executor/executor/limit"
+	"github.com/go-vela/pkg-executor/executor/lock"
+	"github.com/go-vela/pkg-executor/executor/mirror"
+	"github.com/go-vela/pkg-executor/executor/policy"
+	"github.com/go-vela/pkg-executor/internal/stage"
 
 	"github.com/go-vela/pkg-runtime/runtime"
 
@@ -31,17 +41,61 @@ type (
 
 		// private fields
 		init     *pipeline.Container
+		done     chan struct{}
+		doneMu   sync.Mutex
+		events   *event.Bus
+		tails    sync.Map
+		phase    atomic.Value
+		mu       sync.Mutex
+		pause    *pause
+		pauseCh  chan struct{}
+		pauseMu  sync.Mutex
 		logger   *logrus.Entry
 		build    *library.Build
+		number   int
 		pipeline *pipeline.Build
 		repo     *library.Repo
 		// nolint: structcheck,unused // ignore false positives
+		canceled    sync.Map
+		gates       sync.Map
+		held        sync.Map
+		running     sync.Map
 		secrets     sync.Map
 		services    sync.Map
+		stages      sync.Map
+		queued      sync.Map
+		slots       sync.Map
 		serviceLogs sync.Map
 		steps       sync.Map
 		stepLogs    sync.Map
+		stepNotes   sync.Map
+		streams     sync.WaitGroup
+		streamers   sync.Map
 		user        *library.User
+		stopSignal  string
+		stopTimeout time.Duration
+		approval    time.Duration
+		expired     int32
+		assembled   int32
+		finalizer   time.Duration
+		finally     sync.Once
+		readiness   time.Duration
+		probeImage  string
+		failure     string
+		stageBase   string
+		maxStages   int
+		maxPulls    int
+		pullBackoff time.Duration
+		pullRetries int
+		pullTimeout time.Duration
+		mirrors     mirror.Rules
+		policy      *policy.Policy
+		containers  *limit.Semaphore
+		locks       *lock.Registry
+		monitors    sync.Map
+		detached    sync.Map
+		crash       error
+		crashed     context.CancelFunc
 		err         error
 	}
 
@@ -68,6 +122,20 @@ func New(opts ...Opt) (*client, error) {
 	// https://pkg.go.dev/github.com/sirupsen/logrus?tab=doc#NewEntry
 	c.logger = logrus.NewEntry(logger)
 
+	// set the default configuration for stopping containers
+	c.stopSignal = defaultStopSignal
+	c.stopTimeout = defaultStopTimeout
+	c.approval = defaultApprovalTimeout
+	c.finalizer = defaultFinalizerTimeout
+	c.readiness = defaultReadinessTimeout
+	c.probeImage = defaultProbeImage
+	c.maxPulls = defaultMaxPulls
+	c.pullBackoff = defaultPullBackoff
+	c.pullRetries = defaultPullRetries
+	c.pullTimeout = defaultPullTimeout
+	c.failure = stage.FailFast
+	c.locks = lock.NewRegistry()
+
 	// apply all provided configuration options
 	for _, opt := range opts {
 		err := opt(c)
@@ -82,5 +150,8 @@ func New(opts ...Opt) (*client, error) {
 	// instantiate all client services
 	c.secret = &secretSvc{client: c}
 
+	// instantiate the bus for build events
+	c.events = new(event.Bus)
+
 	return c, nil
 }
@@ -0,0 +1,275 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package linux
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-vela/pkg-executor/executor/lock"
+	"github.com/go-vela/types/library"
+	"github.com/go-vela/types/pipeline"
+)
+
+func TestLinux_lockGroup(t *testing.T) {
+	// setup types
+	_locks := lock.NewRegistry()
+
+	_engine, err := New(
+		WithLockRegistry(_locks),
+	)
+	if err != nil {
+		t.Errorf("unable to create executor engine: %v", err)
+	}
+
+	// take the lock group from another build
+	other, _ := _locks.TryLock("db")
+
+	notes := make(chan string, 2)
+
+	done := make(chan error)
+
+	// run test
+	go func() {
+		unlock, err := _engine.lockGroup(context.Background(), "db", func(line string) { notes <- line })
+		if err == nil {
+			unlock()
+		}
+
+		done <- err
+	}()
+
+	got := <-notes
+	if !strings.Contains(got, "Waiting for lock group db") {
+		t.Errorf("lockGroup noted %q, want the wait for the lock group", got)
+	}
+
+	other()
+
+	err = <-done
+	if err != nil {
+		t.Errorf("lockGroup returned err: %v", err)
+	}
+
+	got = <-notes
+	if !strings.Contains(got, "Locked group db after waiting") {
+		t.Errorf("lockGroup noted %q, want the time spent waiting", got)
+	}
+
+	// take the lock group from another build
+	other, _ = _locks.TryLock("db")
+	defer other()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = _engine.lockGroup(ctx, "db", func(string) {})
+	if err == nil {
+		t.Errorf("lockGroup should have returned err")
+	}
+}
+
+func TestLinux_lockContainer(t *testing.T) {
+	// setup types
+	_pipeline := testStages(1, 2)
+
+	ctn := _pipeline.Stages[1].Steps[1]
+	ctn.Environment[lockKey] = "db"
+
+	_locks := lock.NewRegistry()
+
+	_engine, err := New(
+		WithLockRegistry(_locks),
+		WithPipeline(_pipeline),
+	)
+	if err != nil {
+		t.Errorf("unable to create executor engine: %v", err)
+	}
+
+	lines, stop := _engine.tail(ctn).Follow(0)
+	defer stop()
+
+	// run test
+	err = _engine.lockContainer(context.Background(), ctn, _engine.noteStep)
+	if err != nil {
+		t.Errorf("lockContainer returned err: %v", err)
+	}
+
+	if !_locks.Locked("db") {
+		t.Errorf("lockContainer did not lock group db")
+	}
+
+	got := <-lines
+	if !strings.Contains(got.Data, "Locked group db") {
+		t.Errorf("lockContainer logged %q, want the lock group", got.Data)
+	}
+
+	// unlocking the container more than once only releases the lock group once
+	_engine.unlockContainer(ctn)
+	_engine.unlockContainer(ctn)
+
+	if _locks.Locked("db") {
+		t.Errorf("unlockContainer did not unlock group db")
+	}
+}
+
+func TestLinux_containerLock(t *testing.T) {
+	// setup types
+	_pipeline := testStages(2, 2)
+
+	// lock the first stage as a whole
+	_pipeline.Stages[1].Steps[0].Environment[stageLockKey] = "db"
+
+	_engine, err := New(
+		WithPipeline(_pipeline),
+	)
+	if err != nil {
+		t.Errorf("unable to create executor engine: %v", err)
+	}
+
+	// setup tests
+	tests := []struct {
+		ctn   *pipeline.Container
+		group string
+		want  string
+	}{
+		{ // step in a stage holding the lock group
+			ctn:   _pipeline.Stages[1].Steps[1],
+			group: "db",
+			want:  "",
+		},
+		{ // step in a stage holding another lock group
+			ctn:   _pipeline.Stages[1].Steps[1],
+			group: "cache",
+			want:  "cache",
+		},
+		{ // step in a stage without a lock group
+			ctn:   _pipeline.Stages[2].Steps[1],
+			group: "db",
+			want:  "db",
+		},
+		{ // step without a lock group
+			ctn:   _pipeline.Stages[2].Steps[0],
+			group: "",
+			want:  "",
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		test.ctn.Environment[lockKey] = test.group
+
+		got := _engine.containerLock(test.ctn)
+
+		if got != test.want {
+			t.Errorf("containerLock is %q, want %q", got, test.want)
+		}
+	}
+}
+
+func TestLinux_validateLocks(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		failure  bool
+		pipeline *pipeline.Build
+	}{
+		{ // lock groups shared by steps
+			failure: false,
+			pipeline: &pipeline.Build{
+				Steps: pipeline.ContainerSlice{
+					{Name: "migrate", Environment: map[string]string{lockKey: "db"}},
+					{Name: "seed", Environment: map[string]string{lockKey: "db"}},
+				},
+			},
+		},
+		{ // lock group held by a service alone
+			failure: false,
+			pipeline: &pipeline.Build{
+				Services: pipeline.ContainerSlice{
+					{Name: "postgres", Environment: map[string]string{lockKey: "db"}},
+				},
+				Steps: pipeline.ContainerSlice{
+					{Name: "test", Environment: map[string]string{lockKey: "cache"}},
+				},
+			},
+		},
+		{ // lock group held by a service shared with a step
+			failure: true,
+			pipeline: &pipeline.Build{
+				Services: pipeline.ContainerSlice{
+					{Name: "postgres", Environment: map[string]string{lockKey: "db"}},
+				},
+				Steps: pipeline.ContainerSlice{
+					{Name: "migrate", Environment: map[string]string{lockKey: "db"}},
+				},
+			},
+		},
+		{ // lock group held by a detached step shared with a stage
+			failure: true,
+			pipeline: &pipeline.Build{
+				Stages: pipeline.StageSlice{
+					{
+						Name: "deploy",
+						Steps: pipeline.ContainerSlice{
+							{Name: "server", Detach: true, Environment: map[string]string{lockKey: "db"}},
+						},
+					},
+					{
+						Name: "test",
+						Steps: pipeline.ContainerSlice{
+							{Name: "migrate", Environment: map[string]string{stageLockKey: "db"}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := validateLocks(test.pipeline)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("validateLocks should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("validateLocks returned err: %v", err)
+		}
+	}
+}
+
+func TestLinux_noteStep(t *testing.T) {
+	// setup types
+	ctn := testSteps().Steps[0]
+
+	_log := new(library.Log)
+
+	_engine, err := New(
+		WithBuild(testBuild()),
+	)
+	if err != nil {
+		t.Errorf("unable to create executor engine: %v", err)
+	}
+
+	// run test
+	_engine.noteStep(ctn, "waiting\n")
+
+	_engine.flushNotes(ctn, _log)
+	_engine.stepLogs.Store(ctn.ID, _log)
+
+	_engine.noteStep(ctn, "locked\n")
+
+	got := string(_log.GetData())
+	if got != "waiting\nlocked\n" {
+		t.Errorf("noteStep logged %q, want the lines noted before and after the step was planned", got)
+	}
+}
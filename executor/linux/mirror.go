@@ -0,0 +1,74 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package linux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-vela/pkg-executor/executor/fault"
+	"github.com/go-vela/types/constants"
+)
+
+// mirrorImage rewrites the image for the container to the
+// registry mirror from the rule matching the image, if any,
+// and records the original image with the pull and in the log.
+func (c *client) mirrorImage(p *pull) {
+	// https://pkg.go.dev/github.com/go-vela/pkg-executor/executor/mirror#Rules.Rewrite
+	image, rule := c.mirrors.Rewrite(p.ctn.Image)
+	if rule == nil {
+		return
+	}
+
+	c.logger.Infof("rewriting image %s to %s for %s %s", p.ctn.Image, image, p.ctn.Name, p.kind)
+
+	p.notes = append(p.notes, []byte(fmt.Sprintf("> Rewrote image %s to %s\n", p.ctn.Image, image)))
+	p.original, p.rule, p.ctn.Image = p.ctn.Image, rule, image
+}
+
+// fallbackImage restores the original image for the container
+// when the image on the mirror could not be pulled and the mirror
+// rule falls back to the original, returning true if it did.
+func (c *client) fallbackImage(ctx context.Context, p *pull) bool {
+	// check if the image is from a mirror that falls back to the original
+	if p.rule == nil || !p.rule.Fallback {
+		return false
+	}
+
+	// check if the image on the mirror could not be pulled
+	if !errors.Is(p.err, fault.ErrImagePull) {
+		return false
+	}
+
+	// check if the build was canceled or exceeded its time limit
+	if ctx.Err() != nil || c.timedOut() {
+		return false
+	}
+
+	// check if the runtime configures the container, rather than
+	// pulling its image, in which case creating the container
+	// again would add it to the runtime build twice
+	if p.created && c.Runtime.Driver() == constants.DriverKubernetes {
+		return false
+	}
+
+	c.logger.Warnf("falling back to image %s for %s %s: %v", p.original, p.ctn.Name, p.kind, p.err)
+
+	p.notes = append(p.notes, []byte(fmt.Sprintf(
+		"> Unable to pull image %s after %d attempts, falling back to %s: %v\n",
+		p.ctn.Image, p.attempts, p.original, p.err,
+	)))
+
+	// restore the original image for the container
+	p.ctn.Image, p.rule = p.original, nil
+	// keep the attempts made on the mirror for the summary,
+	// while the original image is given every attempt allowed
+	p.mirrored += p.attempts
+
+	p.created, p.attempts, p.err = false, 0, nil
+
+	return true
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package linux
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/go-vela/pkg-executor/executor/fault"
+	"github.com/go-vela/pkg-executor/executor/mirror"
+
+	"github.com/go-vela/pkg-runtime/runtime/docker"
+
+	"github.com/go-vela/types/pipeline"
+)
+
+func TestLinux_mirrorImage(t *testing.T) {
+	// setup types
+	_engine, err := New(
+		WithMirrors(mirror.Rules{
+			{Prefix: "docker.io/", Replacement: "mirror.example.com/docker/"},
+		}),
+	)
+	if err != nil {
+		t.Errorf("unable to create executor engine: %v", err)
+	}
+
+	// setup tests
+	tests := []struct {
+		image    string
+		want     string
+		original string
+	}{
+		{
+			image:    "alpine:latest",
+			want:     "mirror.example.com/docker/library/alpine:latest",
+			original: "alpine:latest",
+		},
+		{
+			image:    "ghcr.io/go-vela/server:latest",
+			want:     "ghcr.io/go-vela/server:latest",
+			original: "",
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		p := &pull{
+			kind: "step",
+			ctn:  &pipeline.Container{ID: "step_github_octocat_1_test", Image: test.image, Name: "test"},
+		}
+
+		_engine.mirrorImage(p)
+
+		if p.ctn.Image != test.want {
+			t.Errorf("mirrorImage is %s, want %s", p.ctn.Image, test.want)
+		}
+
+		if p.original != test.original {
+			t.Errorf("mirrorImage original is %s, want %s", p.original, test.original)
+		}
+
+		if len(p.ctn.Environment) > 0 {
+			t.Errorf("mirrorImage environment is %v, want empty", p.ctn.Environment)
+		}
+	}
+}
+
+func TestLinux_pullImage_Mirror(t *testing.T) {
+	// setup types
+	_runtime, err := docker.NewMock()
+	if err != nil {
+		t.Errorf("unable to create runtime engine: %v", err)
+	}
+
+	// create the containers unless the image is on the mirror
+	create := func(ctx context.Context, ctn *pipeline.Container) error {
+		if strings.HasPrefix(ctn.Image, "mirror.example.com/") {
+			return fault.Wrap(fault.ErrImagePull, ctn.Name, errors.New("mirror unavailable"))
+		}
+
+		return nil
+	}
+
+	// setup tests
+	tests := []struct {
+		failure  bool
+		fallback bool
+		want     string
+		notes    int
+		attempts string
+	}{
+		{ // mirror falling back to the original image
+			failure:  false,
+			fallback: true,
+			want:     "alpine:latest",
+			notes:    2,
+			attempts: "attempts: 2)",
+		},
+		{ // mirror without a fallback
+			failure:  true,
+			fallback: false,
+			want:     "mirror.example.com/docker/library/alpine:latest",
+			notes:    1,
+			attempts: "attempts: 1)",
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		_engine, err := New(
+			WithMirrors(mirror.Rules{
+				{Prefix: "docker.io/", Replacement: "mirror.example.com/docker/", Fallback: test.fallback},
+			}),
+			WithPullRetries(0),
+			WithRuntime(_runtime),
+		)
+		if err != nil {
+			t.Errorf("unable to create executor engine: %v", err)
+		}
+
+		p := &pull{
+			kind:   "step",
+			ctn:    &pipeline.Container{ID: "step_github_octocat_1_test", Image: "alpine:latest", Name: "test"},
+			create: create,
+		}
+
+		_engine.pullImage(context.Background(), p)
+
+		if test.failure {
+			if !errors.Is(p.err, fault.ErrImagePull) {
+				t.Errorf("pullImage returned err %v, want %v", p.err, fault.ErrImagePull)
+			}
+		}
+
+		if !test.failure && p.err != nil {
+			t.Errorf("pullImage returned err: %v", p.err)
+		}
+
+		if p.ctn.Image != test.want {
+			t.Errorf("pullImage image is %s, want %s", p.ctn.Image, test.want)
+		}
+
+		if len(p.notes) != test.notes {
+			t.Errorf("pullImage recorded %d notes, want %d", len(p.notes), test.notes)
+		}
+
+		if !strings.Contains(string(p.summary()), test.attempts) {
+			t.Errorf("pullImage summary is %q, want %q", p.summary(), test.attempts)
+		}
+	}
+}
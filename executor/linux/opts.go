@@ -6,6 +6,13 @@ package linux
 
 import (
 	"fmt"
+	"time"
+
+	"github.com/go-vela/pkg-executor/executor/limit"
+	"github.com/go-vela/pkg-executor/executor/lock"
+	"github.com/go-vela/pkg-executor/executor/mirror"
+	"github.com/go-vela/pkg-executor/executor/policy"
+	"github.com/go-vela/pkg-executor/internal/stage"
 
 	"github.com/go-vela/pkg-runtime/runtime"
 
@@ -17,9 +24,76 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+const (
+	// defaultApprovalTimeout defines the time a gate waits
+	// for approval when no approval timeout is configured.
+	defaultApprovalTimeout = 60 * time.Minute
+
+	// defaultFinalizerTimeout defines the time the finalizers
+	// are given to run when no finalizer timeout is configured.
+	defaultFinalizerTimeout = 5 * time.Minute
+
+	// defaultMaxPulls defines the number of images pulled at
+	// the same time when no max pulls is configured.
+	defaultMaxPulls = 4
+
+	// defaultProbeImage defines the image running the readiness
+	// probes for services when no probe image is configured.
+	defaultProbeImage = "alpine:latest"
+
+	// defaultPullBackoff defines the time waited before the first
+	// retry of an image pull when no pull backoff is configured.
+	defaultPullBackoff = 5 * time.Second
+
+	// defaultPullRetries defines the number of times an image
+	// pull is retried when no pull retries are configured.
+	defaultPullRetries = 2
+
+	// defaultPullTimeout defines the time each attempt to pull
+	// an image is given when no pull timeout is configured.
+	defaultPullTimeout = 10 * time.Minute
+
+	// defaultReadinessTimeout defines the time the services are
+	// given to report they are ready when no readiness timeout
+	// is configured.
+	defaultReadinessTimeout = 2 * time.Minute
+
+	// defaultStopSignal defines the signal sent to
+	// stop containers when none is configured.
+	defaultStopSignal = "SIGTERM"
+
+	// defaultStopTimeout defines the time containers are
+	// given to exit when no stop timeout is configured.
+	defaultStopTimeout = 10 * time.Second
+)
+
 // Opt represents a configuration option to initialize the client.
 type Opt func(*client) error
 
+// WithApprovalTimeout sets the time a stage or step waits
+// for a manual approval in the client before it is rejected.
+func WithApprovalTimeout(timeout time.Duration) Opt {
+	logrus.Trace("configuring approval timeout in linux client")
+
+	return func(c *client) error {
+		// check if the approval timeout provided is invalid
+		if timeout < 0 {
+			return fmt.Errorf("invalid approval timeout provided: %v", timeout)
+		}
+
+		// check if an approval timeout is provided
+		if timeout == 0 {
+			// default the approval timeout to 60 minutes
+			timeout = defaultApprovalTimeout
+		}
+
+		// set the approval timeout in the client
+		c.approval = timeout
+
+		return nil
+	}
+}
+
 // WithBuild sets the library build in the client.
 func WithBuild(b *library.Build) Opt {
 	logrus.Trace("configuring build in linux client")
@@ -38,6 +112,56 @@ func WithBuild(b *library.Build) Opt {
 		// set the build in the client
 		c.build = b
 
+		// capture the build number, which never changes,
+		// so it is read without holding the lock
+		c.number = b.GetNumber()
+
+		return nil
+	}
+}
+
+// WithContainerLimit sets the semaphore in the client
+// limiting the containers running on the host.
+//
+// The same semaphore can be provided to multiple
+// clients to share the limit between their builds.
+func WithContainerLimit(l *limit.Semaphore) Opt {
+	logrus.Trace("configuring container limit in linux client")
+
+	return func(c *client) error {
+		// check if a container limit is provided
+		if l == nil {
+			// default the container limit to no limit
+			l = limit.New(0)
+		}
+
+		// set the container semaphore in the client
+		c.containers = l
+
+		return nil
+	}
+}
+
+// WithFinalizerTimeout sets the time the finalizers are given
+// to run in the client once the build has finished.
+func WithFinalizerTimeout(timeout time.Duration) Opt {
+	logrus.Trace("configuring finalizer timeout in linux client")
+
+	return func(c *client) error {
+		// check if the finalizer timeout provided is invalid
+		if timeout < 0 {
+			return fmt.Errorf("invalid finalizer timeout provided: %v", timeout)
+		}
+
+		// check if a finalizer timeout is provided
+		if timeout == 0 {
+			// default the finalizer timeout to 5 minutes
+			timeout = defaultFinalizerTimeout
+		}
+
+		// set the finalizer timeout in the client
+		c.finalizer = timeout
+
 		return nil
 	}
 }
@@ -65,6 +189,92 @@ func WithHostname(hostname string) Opt {
 	}
 }
 
+// WithLockRegistry sets the registry of lock groups in the client.
+//
+// The same registry can be provided to multiple clients
+// to share the lock groups between their builds.
+func WithLockRegistry(r *lock.Registry) Opt {
+	logrus.Trace("configuring lock registry in linux client")
+
+	return func(c *client) error {
+		// check if a lock registry is provided
+		if r == nil {
+			// default the lock registry to one for the build
+			r = lock.NewRegistry()
+		}
+
+		// set the lock registry in the client
+		c.locks = r
+
+		return nil
+	}
+}
+
+// WithMaxPulls sets the number of images pulled, and services
+// started, in the client at the same time for a build.
+func WithMaxPulls(max int) Opt {
+	logrus.Trace("configuring max pulls in linux client")
+
+	return func(c *client) error {
+		// check if the max pulls provided is invalid
+		if max < 0 {
+			return fmt.Errorf("invalid max pulls provided: %d", max)
+		}
+
+		// check if a max pulls is provided
+		if max == 0 {
+			// default the max pulls to 4
+			max = defaultMaxPulls
+		}
+
+		// set the max pulls in the client
+		c.maxPulls = max
+
+		return nil
+	}
+}
+
+// WithMaxStages sets the number of stages in the
+// client that are allowed to run at the same time.
+func WithMaxStages(max int) Opt {
+	logrus.Trace("configuring max stages in linux client")
+
+	return func(c *client) error {
+		// check if the max stages provided is invalid
+		if max < 0 {
+			return fmt.Errorf("invalid max stages provided: %d", max)
+		}
+
+		// set the max stages in the client
+		//
+		// A value of zero allows every stage to run at the same time.
+		c.maxStages = max
+
+		return nil
+	}
+}
+
+// WithMirrors sets the rules rewriting the images
+// for containers to registry mirrors in the client.
+func WithMirrors(rules mirror.Rules) Opt {
+	logrus.Trace("configuring mirrors in linux client")
+
+	return func(c *client) error {
+		// iterate through all mirror rules provided
+		for _, rule := range rules {
+			// check if the mirror rule provided is invalid
+			if rule == nil || len(rule.Prefix) == 0 || len(rule.Replacement) == 0 {
+				return fmt.Errorf("invalid mirror rule provided: %v", rule)
+			}
+		}
+
+		// set the mirror rules in the client
+		c.mirrors = rules
+
+		return nil
+	}
+}
+
 // WithPipeline sets the pipeline build in the client.
 func WithPipeline(p *pipeline.Build) Opt {
 	logrus.Trace("configuring pipeline in linux client")
@@ -82,6 +292,138 @@ func WithPipeline(p *pipeline.Build) Opt {
 	}
 }
 
+// WithPolicy sets the policy restricting the
+// containers the build may run in the client.
+func WithPolicy(p *policy.Policy) Opt {
+	logrus.Trace("configuring policy in linux client")
+
+	return func(c *client) error {
+		// check if the policy provided is invalid
+		//
+		// https://pkg.go.dev/github.com/go-vela/pkg-executor/executor/policy#Policy.Validate
+		err := p.Validate()
+		if err != nil {
+			return fmt.Errorf("invalid policy provided: %w", err)
+		}
+
+		// set the policy in the client
+		c.policy = p
+
+		return nil
+	}
+}
+
+// WithProbeImage sets the image running the
+// readiness probes for services in the client.
+func WithProbeImage(image string) Opt {
+	logrus.Trace("configuring probe image in linux client")
+
+	return func(c *client) error {
+		// check if a probe image is provided
+		if len(image) == 0 {
+			// default the probe image to alpine
+			image = defaultProbeImage
+		}
+
+		// set the probe image in the client
+		c.probeImage = image
+
+		return nil
+	}
+}
+
+// WithPullBackoff sets the time waited in the client before the
+// first retry of an image pull, which doubles for each retry.
+func WithPullBackoff(backoff time.Duration) Opt {
+	logrus.Trace("configuring pull backoff in linux client")
+
+	return func(c *client) error {
+		// check if the pull backoff provided is invalid
+		if backoff < 0 {
+			return fmt.Errorf("invalid pull backoff provided: %v", backoff)
+		}
+
+		// check if a pull backoff is provided
+		if backoff == 0 {
+			// default the pull backoff to 5 seconds
+			backoff = defaultPullBackoff
+		}
+
+		// set the pull backoff in the client
+		c.pullBackoff = backoff
+
+		return nil
+	}
+}
+
+// WithPullRetries sets the number of times an
+// image pull is retried in the client after it fails.
+func WithPullRetries(retries int) Opt {
+	logrus.Trace("configuring pull retries in linux client")
+
+	return func(c *client) error {
+		// check if the pull retries provided is invalid
+		if retries < 0 {
+			return fmt.Errorf("invalid pull retries provided: %d", retries)
+		}
+
+		// set the pull retries in the client
+		//
+		// A value of zero pulls each image once.
+		c.pullRetries = retries
+
+		return nil
+	}
+}
+
+// WithPullTimeout sets the time each attempt
+// to pull an image is given in the client.
+func WithPullTimeout(timeout time.Duration) Opt {
+	logrus.Trace("configuring pull timeout in linux client")
+
+	return func(c *client) error {
+		// check if the pull timeout provided is invalid
+		if timeout < 0 {
+			return fmt.Errorf("invalid pull timeout provided: %v", timeout)
+		}
+
+		// check if a pull timeout is provided
+		if timeout == 0 {
+			// default the pull timeout to 10 minutes
+			timeout = defaultPullTimeout
+		}
+
+		// set the pull timeout in the client
+		c.pullTimeout = timeout
+
+		return nil
+	}
+}
+
+// WithReadinessTimeout sets the time the services are given
+// to report they are ready in the client before the steps run.
+func WithReadinessTimeout(timeout time.Duration) Opt {
+	logrus.Trace("configuring readiness timeout in linux client")
+
+	return func(c *client) error {
+		// check if the readiness timeout provided is invalid
+		if timeout < 0 {
+			return fmt.Errorf("invalid readiness timeout provided: %v", timeout)
+		}
+
+		// check if a readiness timeout is provided
+		if timeout == 0 {
+			// default the readiness timeout to 2 minutes
+			timeout = defaultReadinessTimeout
+		}
+
+		// set the readiness timeout in the client
+		c.readiness = timeout
+
+		return nil
+	}
+}
+
 // WithRepo sets the library repo in the client.
 func WithRepo(r *library.Repo) Opt {
 	logrus.Trace("configuring repo in linux client")
@@ -121,6 +463,76 @@ func WithRuntime(r runtime.Engine) Opt {
 	}
 }
 
+// WithStageFailure sets the failure policy in the client
+// deciding if a failed stage stops every other stage.
+func WithStageFailure(policy string) Opt {
+	logrus.Trace("configuring stage failure in linux client")
+
+	return func(c *client) error {
+		// check if a stage failure policy is provided
+		if len(policy) == 0 {
+			// default the stage failure policy to fail-fast
+			policy = stage.FailFast
+		}
+
+		// check if the stage failure policy provided is invalid
+		//
+		// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/stage#ValidatePolicy
+		err := stage.ValidatePolicy(policy)
+		if err != nil {
+			return fmt.Errorf("invalid stage failure policy provided: %s", policy)
+		}
+
+		// set the stage failure policy in the client
+		c.failure = policy
+
+		return nil
+	}
+}
+
+// WithStopSignal sets the signal sent to stop containers in the client.
+func WithStopSignal(signal string) Opt {
+	logrus.Trace("configuring stop signal in linux client")
+
+	return func(c *client) error {
+		// check if a stop signal is provided
+		if len(signal) == 0 {
+			// default the stop signal to SIGTERM
+			signal = defaultStopSignal
+		}
+
+		// set the stop signal in the client
+		c.stopSignal = signal
+
+		return nil
+	}
+}
+
+// WithStopTimeout sets the time a container is given to
+// exit after the stop signal in the client before it is
+// forcefully removed.
+func WithStopTimeout(timeout time.Duration) Opt {
+	logrus.Trace("configuring stop timeout in linux client")
+
+	return func(c *client) error {
+		// check if the stop timeout provided is invalid
+		if timeout < 0 {
+			return fmt.Errorf("invalid stop timeout provided: %v", timeout)
+		}
+
+		// check if a stop timeout is provided
+		if timeout == 0 {
+			// default the stop timeout to 10 seconds
+			timeout = defaultStopTimeout
+		}
+
+		// set the stop timeout in the client
+		c.stopTimeout = timeout
+
+		return nil
+	}
+}
+
 // WithUser sets the library user in the client.
 func WithUser(u *library.User) Opt {
 	logrus.Trace("configuring user in linux client")
@@ -0,0 +1,95 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package linux
+
+import (
+	"testing"
+
+	"github.com/go-vela/pkg-executor/executor/policy"
+	"github.com/go-vela/pkg-executor/internal/service"
+
+	"github.com/go-vela/types/pipeline"
+)
+
+func TestLinux_enforcePolicy(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		failure bool
+		probe   bool
+		policy  *policy.Policy
+		lines   int
+	}{
+		{ // empty policy
+			failure: false,
+			policy:  nil,
+			lines:   0,
+		},
+		{ // policy the pipeline complies with
+			failure: false,
+			policy:  &policy.Policy{MaxSteps: 10},
+			lines:   0,
+		},
+		{ // policy the pipeline violates
+			failure: true,
+			policy:  &policy.Policy{MaxSteps: 1, DeniedImages: []string{"target/vela-git:*"}},
+			lines:   3,
+		},
+		{ // policy the readiness probe for a service violates
+			failure: true,
+			probe:   true,
+			policy:  &policy.Policy{DeniedImages: []string{"probe.example.com/*"}},
+			lines:   2,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		_pipeline := testSteps()
+
+		// check if a service declares a readiness probe
+		if test.probe {
+			_pipeline.Services = pipeline.ContainerSlice{
+				{
+					ID:          "service_github_octocat_1_postgres",
+					Environment: map[string]string{service.ReadyKey: "tcp:5432"},
+					Image:       "postgres:12-alpine",
+					Name:        "postgres",
+					Number:      1,
+				},
+			}
+		}
+
+		_engine, err := New(
+			WithBuild(testBuild()),
+			WithPipeline(_pipeline),
+			WithPolicy(test.policy),
+			WithProbeImage("probe.example.com/alpine:latest"),
+			WithRepo(testRepo()),
+		)
+		if err != nil {
+			t.Errorf("unable to create executor engine: %v", err)
+		}
+
+		got := [][]byte{}
+
+		err = _engine.enforcePolicy(func(data []byte) {
+			got = append(got, data)
+		})
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("enforcePolicy should have returned err")
+			}
+		}
+
+		if !test.failure && err != nil {
+			t.Errorf("enforcePolicy returned err: %v", err)
+		}
+
+		if len(got) != test.lines {
+			t.Errorf("enforcePolicy wrote %d lines, want %d", len(got), test.lines)
+		}
+	}
+}
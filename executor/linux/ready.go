@@ -0,0 +1,197 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package linux
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-vela/pkg-executor/executor/fault"
+	"github.com/go-vela/pkg-executor/executor/policy"
+	"github.com/go-vela/pkg-executor/internal/service"
+	"github.com/go-vela/types/pipeline"
+	"golang.org/x/sync/errgroup"
+)
+
+// probeInterval defines the time waited between
+// the attempts of a readiness probe for a service.
+const probeInterval = time.Second
+
+// waitReady blocks until every service declaring a readiness
+// probe is ready and returns an error if a service is not
+// ready before the readiness timeout expires.
+func (c *client) waitReady(ctx context.Context) error {
+	// create a context that is canceled once the readiness timeout expires
+	//
+	// https://pkg.go.dev/context?tab=doc#WithTimeout
+	ctx, cancel := context.WithTimeout(ctx, c.readiness)
+	defer cancel()
+
+	// create an error group for the probes of the services
+	//
+	// https://pkg.go.dev/golang.org/x/sync/errgroup?tab=doc#Group
+	probes := new(errgroup.Group)
+
+	// iterate through all services in the pipeline
+	for _, _service := range c.pipeline.Services {
+		// capture the readiness probe for the service
+		//
+		// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/service#Readiness
+		p, err := service.Readiness(_service)
+		if err != nil {
+			return err
+		}
+
+		// check if the service declares a readiness probe
+		if p == nil {
+			continue
+		}
+
+		// https://golang.org/doc/faq#closures_and_goroutines
+		ctn := _service
+
+		probes.Go(func() error {
+			return c.probeService(ctx, ctn, p)
+		})
+	}
+
+	return probes.Wait()
+}
+
+// probeViolations returns the violations of the policy for the
+// containers running the readiness probes for the services.
+func (c *client) probeViolations() []*policy.Violation {
+	violations := []*policy.Violation{}
+
+	// iterate through all services in the pipeline
+	for _, _service := range c.pipeline.Services {
+		// capture the readiness probe for the service
+		//
+		// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/service#Readiness
+		p, err := service.Readiness(_service)
+		if err != nil || p == nil {
+			continue
+		}
+
+		// check the container running the probe against the policy
+		//
+		// https://pkg.go.dev/github.com/go-vela/pkg-executor/executor/policy#Policy.CheckContainer
+		violations = append(violations, c.policy.CheckContainer("probe", p.Container(_service, c.probeImage), c.repo)...)
+	}
+
+	return violations
+}
+
+// probeService runs the readiness probe for the service until
+// it reports the service is ready or the context is canceled.
+func (c *client) probeService(ctx context.Context, ctn *pipeline.Container, p *service.Probe) error {
+	// update engine logger with service metadata
+	//
+	// https://pkg.go.dev/github.com/sirupsen/logrus?tab=doc#Entry.WithField
+	logger := c.logger.WithField("service", ctn.Name)
+
+	// create the container running the probe on the build network
+	probe := p.Container(ctn, c.probeImage)
+
+	// rewrite the image for the probe to a registry mirror
+	// the same way as the images for the pipeline
+	//
+	// https://pkg.go.dev/github.com/go-vela/pkg-executor/executor/mirror#Rules.Rewrite
+	if image, rule := c.mirrors.Rewrite(probe.Image); rule != nil {
+		logger.Infof("rewriting probe image %s to %s", probe.Image, image)
+
+		c.noteService(ctn, fmt.Sprintf("> Rewrote probe image %s to %s\n", probe.Image, image))
+
+		probe.Image = image
+	}
+
+	c.noteService(ctn, fmt.Sprintf("> Waiting for readiness probe %s...\n", p))
+
+	logger.Debug("setting up probe container")
+	// setup the runtime container
+	err := c.Runtime.SetupContainer(ctx, probe)
+	if err != nil {
+		return c.infraError(fault.ErrImagePull, probe.Name, err)
+	}
+
+	start := time.Now()
+
+	for attempt := 1; ; attempt++ {
+		// run the probe for the service
+		code, err := c.runProbe(ctx, probe)
+
+		switch {
+		case err == nil && code == 0:
+			logger.Infof("service ready after %d attempts", attempt)
+
+			c.noteService(ctn, fmt.Sprintf("> Ready after %d probe attempts in %v\n", attempt, time.Since(start).Round(time.Millisecond)))
+
+			return nil
+		case err == nil:
+			c.noteService(ctn, fmt.Sprintf("> Probe attempt %d not ready with exit code %d\n", attempt, code))
+		case ctx.Err() == nil:
+			logger.Errorf("unable to run probe container: %v", err)
+
+			c.noteService(ctn, fmt.Sprintf("> Probe attempt %d unable to run: %v\n", attempt, err))
+		}
+
+		// https://pkg.go.dev/time?tab=doc#NewTimer
+		timer := time.NewTimer(probeInterval)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			c.noteService(ctn, fmt.Sprintf("> Not ready after %d probe attempts in %v\n", attempt, time.Since(start).Round(time.Millisecond)))
+
+			err = fmt.Errorf("%s service not ready after %d attempts: %w", ctn.Name, attempt, ctx.Err())
+
+			// check if the readiness timeout expired
+			if ctx.Err() == context.DeadlineExceeded {
+				// update the service with the error
+				if _service, lerr := service.Load(ctn, &c.services); lerr == nil {
+					c.serviceError(_service, err)
+				}
+
+				// https://pkg.go.dev/github.com/go-vela/pkg-executor/executor/fault#Wrap
+				return fault.Wrap(fault.ErrTimeout, ctn.Name, err)
+			}
+
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// runProbe runs the probe container once and
+// returns the exit code for the probe.
+func (c *client) runProbe(ctx context.Context, probe *pipeline.Container) (int, error) {
+	// reset the exit code from the previous attempt
+	probe.ExitCode = 0
+
+	// defer removing the probe container
+	defer func() { _ = c.Runtime.RemoveContainer(context.Background(), probe) }()
+
+	// run the runtime container
+	err := c.Runtime.RunContainer(ctx, probe, c.pipeline)
+	if err != nil {
+		return 0, err
+	}
+
+	// wait for the runtime container
+	err = c.Runtime.WaitContainer(ctx, probe)
+	if err != nil {
+		return 0, err
+	}
+
+	// inspect the runtime container
+	err = c.Runtime.InspectContainer(ctx, probe)
+	if err != nil {
+		return 0, err
+	}
+
+	return probe.ExitCode, nil
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package linux
+
+import (
+	"github.com/go-vela/pkg-executor/internal/service"
+	"github.com/go-vela/pkg-executor/internal/stage"
+	"github.com/go-vela/pkg-executor/internal/step"
+	"github.com/go-vela/types/pipeline"
+)
+
+// reservedKeys defines the environment variables reserved
+// for configuring the executor, which are never passed to
+// the runtime with the container.
+var reservedKeys = map[string]bool{
+	approvalKey:       true,
+	lockKey:           true,
+	stageLockKey:      true,
+	service.CrashKey:  true,
+	service.ReadyKey:  true,
+	stage.PolicyKey:   true,
+	step.DetachKey:    true,
+	step.ExitCodesKey: true,
+	step.FinallyKey:   true,
+	step.NeedsKey:     true,
+}
+
+// runtimeContainer returns a copy of the container, passed to
+// the runtime, without the environment variables reserved for
+// configuring the executor.
+func runtimeContainer(ctn *pipeline.Container) *pipeline.Container {
+	env := make(map[string]string, len(ctn.Environment))
+
+	// iterate through all environment variables for the container
+	for key, value := range ctn.Environment {
+		// check if the environment variable is reserved for the executor
+		if reservedKeys[key] {
+			continue
+		}
+
+		env[key] = value
+	}
+
+	_ctn := *ctn
+	_ctn.Environment = env
+
+	return &_ctn
+}
@@ -13,6 +13,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/go-vela/pkg-executor/executor/event"
+	"github.com/go-vela/pkg-executor/executor/fault"
 	"github.com/go-vela/pkg-executor/internal/step"
 	"github.com/go-vela/types/constants"
 	"github.com/go-vela/types/library"
@@ -51,12 +53,12 @@ func (s *secretSvc) create(ctx context.Context, ctn *pipeline.Container) error {
 	// setup the runtime container
 	err := s.client.Runtime.SetupContainer(ctx, ctn)
 	if err != nil {
-		return err
+		return s.client.infraError(fault.ErrImagePull, ctn.Name, err)
 	}
 
 	logger.Debug("injecting secrets")
 	// inject secrets for container
-	err = injectSecrets(ctn, s.client.Secrets)
+	s.client.locked(func() { err = injectSecrets(ctn, s.client.Secrets) })
 	if err != nil {
 		return err
 	}
@@ -104,7 +106,7 @@ func (s *secretSvc) exec(ctx context.Context, p *pipeline.SecretSlice) error {
 	}
 
 	defer func() {
-		_init.SetFinished(time.Now().UTC().Unix())
+		s.client.locked(func() { _init.SetFinished(time.Now().UTC().Unix()) })
 
 		s.client.logger.Infof("uploading %s step state", _init.GetName())
 		// send API call to update the build
@@ -132,13 +134,13 @@ func (s *secretSvc) exec(ctx context.Context, p *pipeline.SecretSlice) error {
 		// run the runtime container
 		err := s.client.Runtime.RunContainer(ctx, _secret.Origin, s.client.pipeline)
 		if err != nil {
-			return err
+			return s.client.infraError(fault.ErrSecretResolution, _secret.Origin.Name, err)
 		}
 
 		go func() {
 			logger.Debug("stream logs for container")
 			// stream logs from container
-			err = s.client.secret.stream(ctx, _secret.Origin)
+			err := s.client.secret.stream(ctx, _secret.Origin)
 			if err != nil {
 				logger.Error(err)
 			}
@@ -148,31 +150,38 @@ func (s *secretSvc) exec(ctx context.Context, p *pipeline.SecretSlice) error {
 		// wait for the runtime container
 		err = s.client.Runtime.WaitContainer(ctx, _secret.Origin)
 		if err != nil {
-			return err
+			return s.client.infraError(fault.ErrSecretResolution, _secret.Origin.Name, err)
 		}
 
 		logger.Debug("inspecting container")
 		// inspect the runtime container
 		err = s.client.Runtime.InspectContainer(ctx, _secret.Origin)
 		if err != nil {
-			return err
+			return s.client.infraError(fault.ErrSecretResolution, _secret.Origin.Name, err)
 		}
 
 		// check the step exit code
 		if _secret.Origin.ExitCode != 0 {
-			// check if we ignore step failures
-			if !_secret.Origin.Ruleset.Continue {
-				// set build status to failure
-				s.client.build.SetStatus(constants.StatusFailure)
-			}
+			s.client.locked(func() {
+				// check if we ignore step failures
+				if !_secret.Origin.Ruleset.Continue {
+					// set build status to failure
+					s.client.build.SetStatus(constants.StatusFailure)
+				}
 
-			// update the step fields
-			_init.SetExitCode(_secret.Origin.ExitCode)
-			_init.SetStatus(constants.StatusFailure)
+				// update the step fields
+				_init.SetExitCode(_secret.Origin.ExitCode)
+				_init.SetStatus(constants.StatusFailure)
+			})
 
-			return fmt.Errorf("%s container exited with non-zero code", _secret.Origin.Name)
+			err = fmt.Errorf("%s container exited with non-zero code", _secret.Origin.Name)
+
+			return s.client.infraError(fault.ErrSecretResolution, _secret.Origin.Name, err)
 		}
 
+		// publish an event for the secrets produced by the plugin
+		s.client.publish(&event.Event{Type: event.SecretResolved, Name: _secret.Name})
+
 		// send API call to update the build
 		//
 		// https://pkg.go.dev/github.com/go-vela/sdk-go/vela?tab=doc#StepService.Update
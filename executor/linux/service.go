@@ -5,16 +5,19 @@
 package linux
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"time"
 
+	"github.com/go-vela/pkg-executor/executor/event"
+	"github.com/go-vela/pkg-executor/executor/fault"
 	"github.com/go-vela/pkg-executor/internal/service"
 	"github.com/go-vela/types/constants"
 	"github.com/go-vela/types/library"
 	"github.com/go-vela/types/pipeline"
-	"golang.org/x/sync/errgroup"
 )
 
 // CreateService configures the service for execution.
@@ -24,24 +27,43 @@ func (c *client) CreateService(ctx context.Context, ctn *pipeline.Container) err
 	// https://pkg.go.dev/github.com/sirupsen/logrus?tab=doc#Entry.WithField
 	logger := c.logger.WithField("service", ctn.Name)
 
-	logger.Debug("setting up container")
-	// setup the runtime container
-	err := c.Runtime.SetupContainer(ctx, ctn)
+	logger.Debug("validating readiness probe")
+	// validate the readiness probe declared for the service
+	//
+	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/service#Readiness
+	_, err := service.Readiness(ctn)
+	if err != nil {
+		return err
+	}
+
+	logger.Debug("validating crash policy")
+	// validate the crash policy declared for the service
+	//
+	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/service#CrashPolicy
+	_, err = service.CrashPolicy(ctn)
 	if err != nil {
 		return err
 	}
 
+	logger.Debug("setting up container")
+	// setup the runtime container without the
+	// environment reserved for the executor
+	err = c.Runtime.SetupContainer(ctx, runtimeContainer(ctn))
+	if err != nil {
+		return c.infraError(fault.ErrImagePull, ctn.Name, err)
+	}
+
 	// update the service container environment
 	//
 	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/service#Environment
-	err = service.Environment(ctn, c.build, c.repo, nil, c.Version)
+	c.locked(func() { err = service.Environment(ctn, c.build, c.repo, nil, c.Version) })
 	if err != nil {
 		return err
 	}
 
 	logger.Debug("injecting secrets")
 	// inject secrets for container
-	err = injectSecrets(ctn, c.Secrets)
+	c.locked(func() { err = injectSecrets(ctn, c.Secrets) })
 	if err != nil {
 		return err
 	}
@@ -74,23 +96,27 @@ func (c *client) PlanService(ctx context.Context, ctn *pipeline.Container) error
 	_service.SetImage(ctn.Image)
 	_service.SetStatus(constants.StatusRunning)
 	_service.SetStarted(time.Now().UTC().Unix())
-	_service.SetHost(c.build.GetHost())
-	_service.SetRuntime(c.build.GetRuntime())
-	_service.SetDistribution(c.build.GetDistribution())
+
+	// capture the build fields updated once the build is created
+	c.locked(func() {
+		_service.SetHost(c.build.GetHost())
+		_service.SetRuntime(c.build.GetRuntime())
+		_service.SetDistribution(c.build.GetDistribution())
+	})
 
 	logger.Debug("uploading service state")
 	// send API call to update the service
 	//
 	// https://pkg.go.dev/github.com/go-vela/sdk-go/vela?tab=doc#SvcService.Update
-	_service, _, err = c.Vela.Svc.Update(c.repo.GetOrg(), c.repo.GetName(), c.build.GetNumber(), _service)
+	_service, _, err = c.Vela.Svc.Update(c.repo.GetOrg(), c.repo.GetName(), c.number, _service)
 	if err != nil {
-		return err
+		return c.infraError(fault.ErrUpload, ctn.Name, err)
 	}
 
 	// update the service container environment
 	//
 	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/service#Environment
-	err = service.Environment(ctn, c.build, c.repo, _service, c.Version)
+	c.locked(func() { err = service.Environment(ctn, c.build, c.repo, _service, c.Version) })
 	if err != nil {
 		return err
 	}
@@ -103,7 +129,7 @@ func (c *client) PlanService(ctx context.Context, ctn *pipeline.Container) error
 	// send API call to capture the service log
 	//
 	// https://pkg.go.dev/github.com/go-vela/sdk-go/vela?tab=doc#LogService.GetService
-	_log, _, err := c.Vela.Log.GetService(c.repo.GetOrg(), c.repo.GetName(), c.build.GetNumber(), _service.GetNumber())
+	_log, _, err := c.Vela.Log.GetService(c.repo.GetOrg(), c.repo.GetName(), c.number, _service.GetNumber())
 	if err != nil {
 		return err
 	}
@@ -132,36 +158,43 @@ func (c *client) ExecService(ctx context.Context, ctn *pipeline.Container) error
 	// defer taking a snapshot of the service
 	//
 	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/service#Snapshot
-	defer func() { service.Snapshot(ctn, c.build, c.Vela, c.logger, c.repo, _service) }()
+	defer c.syncService(_service, c.logger, func() { service.Snapshot(ctn, c.build, nil, c.logger, c.repo, _service) })
+
+	// wait for the lock group for the container to be free
+	err = c.lockContainer(ctx, ctn, c.noteService)
+	if err != nil {
+		return err
+	}
 
 	logger.Debug("running container")
-	// run the runtime container
-	err = c.Runtime.RunContainer(ctx, ctn, c.pipeline)
+	// run the runtime container without the
+	// environment reserved for the executor
+	err = c.Runtime.RunContainer(ctx, runtimeContainer(ctn), c.pipeline)
 	if err != nil {
+		err = c.infraError(fault.ErrContainerStart, ctn.Name, err)
+
+		// update the service with the error
+		c.serviceError(_service, err)
+
 		return err
 	}
 
-	// create an error group with the parent context
-	//
-	// https://pkg.go.dev/golang.org/x/sync/errgroup?tab=doc#WithContext
-	logs, logCtx := errgroup.WithContext(ctx)
-
-	logs.Go(func() error {
-		logger.Debug("streaming logs for container")
-		// stream logs from container
-		err := c.StreamService(logCtx, ctn)
-		if err != nil {
-			logger.Error(err)
-		}
+	// publish an event for the started service
+	c.publish(&event.Event{Type: event.ServiceStarted, Name: ctn.Name})
 
-		return nil
-	})
+	logger.Debug("monitoring container")
+	// watch the container for the service exiting unexpectedly
+	c.monitorService(ctn, _service)
+
+	logger.Debug("streaming logs for container")
+	// stream logs from container
+	c.stream(ctn, c.StreamService)
 
 	return nil
 }
 
 // StreamService tails the output for a service.
-func (c *client) StreamService(ctx context.Context, ctn *pipeline.Container) error {
+func (c *client) StreamService(ctx context.Context, ctn *pipeline.Container) (err error) {
 	// update engine logger with service metadata
 	//
 	// https://pkg.go.dev/github.com/sirupsen/logrus?tab=doc#Entry.WithField
@@ -175,61 +208,69 @@ func (c *client) StreamService(ctx context.Context, ctn *pipeline.Container) err
 		return err
 	}
 
-	// nolint: dupl // ignore similar code
-	defer func() {
-		// tail the runtime container
-		rc, err := c.Runtime.TailContainer(ctx, ctn)
-		if err != nil {
-			logger.Errorf("unable to tail container output for upload: %v", err)
-
-			return
-		}
-		defer rc.Close()
-
-		// read all output from the runtime container
-		data, err := ioutil.ReadAll(rc)
-		if err != nil {
-			logger.Errorf("unable to read container output for upload: %v", err)
+	logger.Debug("tailing container")
+	// tail the runtime container
+	rc, err := c.Runtime.TailContainer(ctx, ctn)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
 
-			return
-		}
+	// capture the output streamed for the service so it
+	// is uploaded even once the container is removed
+	output := new(bytes.Buffer)
 
-		// overwrite the existing log with all bytes
-		//
-		// https://pkg.go.dev/github.com/go-vela/types/library?tab=doc#Log.SetData
-		_log.SetData(data)
+	defer func() {
+		// capture a copy of the log to upload since lines, such
+		// as the results of its readiness probe, are recorded
+		// for the service while its output is streamed
+		var upload library.Log
+
+		c.locked(func() {
+			// overwrite the existing log with all bytes following the
+			// lines recorded for the service, such as the wait for its
+			// lock group and the results of its readiness probe
+			//
+			// https://pkg.go.dev/github.com/go-vela/types/library?tab=doc#Log.SetData
+			_log.SetData(append(append([]byte(nil), _log.GetData()...), output.Bytes()...))
+
+			upload = *_log
+		})
 
 		logger.Debug("uploading logs")
 		// send API call to update the logs for the service
 		//
 		// https://pkg.go.dev/github.com/go-vela/sdk-go/vela?tab=doc#LogService.UpdateService
-		_, _, err = c.Vela.Log.UpdateService(c.repo.GetOrg(), c.repo.GetName(), c.build.GetNumber(), ctn.Number, _log)
-		if err != nil {
-			logger.Errorf("unable to upload container logs: %v", err)
+		_, _, uerr := c.Vela.Log.UpdateService(c.repo.GetOrg(), c.repo.GetName(), c.number, ctn.Number, &upload)
+		if uerr != nil && err == nil {
+			err = fmt.Errorf("unable to upload container logs: %w", uerr)
 		}
 	}()
 
-	logger.Debug("tailing container")
-	// tail the runtime container
-	rc, err := c.Runtime.TailContainer(ctx, ctn)
-	if err != nil {
-		return err
-	}
-	defer rc.Close()
-
 	// set the timeout to the repo timeout
 	// to ensure the stream is not cut off
 	c.Vela.SetTimeout(time.Minute * time.Duration(c.repo.GetTimeout()))
 
+	// publish the output as log events while streaming it
+	logs := &captureReader{
+		ReadCloser: c.tailLogs(rc, ctn),
+		buf:        output,
+	}
+
 	// https://pkg.go.dev/github.com/go-vela/sdk-go/vela?tab=doc#SvcService.Stream
-	_, err = c.Vela.Svc.Stream(c.repo.GetOrg(), c.repo.GetName(), c.build.GetNumber(), ctn.Number, rc)
+	_, err = c.Vela.Svc.Stream(c.repo.GetOrg(), c.repo.GetName(), c.number, ctn.Number, logs)
 	if err != nil {
 		logger.Errorf("unable to stream logs: %v", err)
+
+		err = fmt.Errorf("unable to stream logs: %w", err)
 	}
 
+	// read the output left behind by a stream that ended early
+	_, _ = io.Copy(ioutil.Discard, logs)
+
 	logger.Info("finished streaming logs")
 
-	return nil
+	return err
 }
 
 // DestroyService cleans up services after execution.
@@ -252,8 +293,15 @@ func (c *client) DestroyService(ctx context.Context, ctn *pipeline.Container) er
 
 	// defer an upload of the service
 	//
-	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/service#LoaUploadd
-	defer func() { service.Upload(ctn, c.build, c.Vela, logger, c.repo, _service) }()
+	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/service#Upload
+	defer c.syncService(_service, logger, func() { service.Upload(ctn, c.build, nil, logger, c.repo, _service) })
+
+	// release the lock group held by the container
+	defer c.unlockContainer(ctn)
+
+	logger.Debug("stopping container monitor")
+	// stop watching the container before it is removed
+	c.unmonitorService(ctn)
 
 	logger.Debug("inspecting container")
 	// inspect the runtime container
@@ -262,12 +310,10 @@ func (c *client) DestroyService(ctx context.Context, ctn *pipeline.Container) er
 		return err
 	}
 
-	logger.Debug("removing container")
-	// remove the runtime container
-	err = c.Runtime.RemoveContainer(ctx, ctn)
-	if err != nil {
-		return err
-	}
+	// publish an event for the stopped service
+	c.publish(&event.Event{Type: event.ServiceDied, Name: ctn.Name, ExitCode: ctn.ExitCode})
 
-	return nil
+	// remove the runtime container before its output is flushed,
+	// since a service runs until its container is removed
+	return c.removeContainer(ctx, ctn, false)
 }
@@ -13,6 +13,8 @@ import (
 
 	"github.com/go-vela/mock/server"
 
+	"github.com/go-vela/pkg-executor/executor/limit"
+
 	"github.com/go-vela/pkg-runtime/runtime/docker"
 
 	"github.com/go-vela/sdk-go/vela"
@@ -260,8 +262,13 @@ func TestLinux_ExecService(t *testing.T) {
 
 	// run tests
 	for _, test := range tests {
+		// services run until the build is destroyed
+		// so they do not take a slot on the host
+		_containers := limit.New(1)
+
 		_engine, err := New(
 			WithBuild(_build),
+			WithContainerLimit(_containers),
 			WithPipeline(new(pipeline.Build)),
 			WithRepo(_repo),
 			WithRuntime(_runtime),
@@ -290,6 +297,10 @@ func TestLinux_ExecService(t *testing.T) {
 		if err != nil {
 			t.Errorf("ExecService returned err: %v", err)
 		}
+
+		if _containers.InUse() != 0 {
+			t.Errorf("ExecService slots in use is %d, want 0", _containers.InUse())
+		}
 	}
 }
 
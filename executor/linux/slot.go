@@ -0,0 +1,82 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package linux
+
+import (
+	"context"
+
+	"github.com/go-vela/pkg-executor/executor/event"
+	"github.com/go-vela/pkg-executor/executor/limit"
+	"github.com/go-vela/types/pipeline"
+)
+
+// queueStage blocks until a slot is available for the stage
+// and returns an error once the context provided is canceled.
+//
+// The stage is reported as queued while it waits for a slot.
+func (c *client) queueStage(ctx context.Context, s *pipeline.Stage, slots *limit.Semaphore) error {
+	// check if a slot is available without waiting
+	if slots.TryAcquire() {
+		return nil
+	}
+
+	c.logger.Infof("queuing %s stage until a slot is available", s.Name)
+
+	// track the stage as queued until a slot is available
+	c.queued.Store(s.Name, true)
+	defer c.queued.Delete(s.Name)
+
+	// publish an event for the queued stage
+	c.publish(&event.Event{Type: event.StageQueued, Stage: s.Name})
+
+	// https://pkg.go.dev/github.com/go-vela/pkg-executor/executor/limit#Semaphore.Acquire
+	return slots.Acquire(ctx)
+}
+
+// stageQueued returns if the stage is waiting for a slot.
+func (c *client) stageQueued(s *pipeline.Stage) bool {
+	_, ok := c.queued.Load(s.Name)
+
+	return ok
+}
+
+// acquireContainer blocks until a slot is available on the host
+// for the container and returns an error once the context
+// provided is canceled.
+//
+// The slot is held until the container is released. Services
+// and detached steps do not take a slot since they run until
+// the build is destroyed, so holding a slot could leave the
+// steps they run alongside waiting for a slot forever.
+func (c *client) acquireContainer(ctx context.Context, ctn *pipeline.Container) error {
+	// check if a slot is available without waiting
+	//
+	// https://pkg.go.dev/github.com/go-vela/pkg-executor/executor/limit#Semaphore.TryAcquire
+	if !c.containers.TryAcquire() {
+		c.logger.Debugf("waiting for a slot on the host for %s container", ctn.Name)
+
+		// https://pkg.go.dev/github.com/go-vela/pkg-executor/executor/limit#Semaphore.Acquire
+		err := c.containers.Acquire(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	// track the slot held by the container
+	c.slots.Store(ctn.ID, true)
+
+	return nil
+}
+
+// releaseContainer frees the slot on the
+// host held by the container, if any.
+func (c *client) releaseContainer(ctn *pipeline.Container) {
+	// check if the container holds a slot
+	_, ok := c.slots.LoadAndDelete(ctn.ID)
+	if ok {
+		// https://pkg.go.dev/github.com/go-vela/pkg-executor/executor/limit#Semaphore.Release
+		c.containers.Release()
+	}
+}
@@ -9,7 +9,10 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/go-vela/pkg-executor/executor/event"
+	"github.com/go-vela/pkg-executor/internal/stage"
 	"github.com/go-vela/pkg-executor/internal/step"
+	"github.com/go-vela/types/constants"
 	"github.com/go-vela/types/pipeline"
 )
 
@@ -23,42 +26,13 @@ func (c *client) CreateStage(ctx context.Context, s *pipeline.Stage) error {
 		return err
 	}
 
-	// update engine logger with stage metadata
-	//
-	// https://pkg.go.dev/github.com/sirupsen/logrus?tab=doc#Entry.WithField
-	logger := c.logger.WithField("stage", s.Name)
-
-	// update the init log with progress
+	c.logger.WithField("stage", s.Name).Debug("creating steps")
+	// create the steps for the stage, pulling their images in
+	// parallel, and update the init log with the image info
+	// for each step in order
 	//
 	// https://pkg.go.dev/github.com/go-vela/types/library?tab=doc#Log.AppendData
-	_log.AppendData([]byte(fmt.Sprintf("> Pulling step images for stage %s...\n", s.Name)))
-
-	// create the steps for the stage
-	for _, _step := range s.Steps {
-		// update the container environment with stage name
-		_step.Environment["VELA_STEP_STAGE"] = s.Name
-
-		logger.Debugf("creating %s step", _step.Name)
-		// create the step
-		err := c.CreateStep(ctx, _step)
-		if err != nil {
-			return err
-		}
-
-		logger.Infof("inspecting image for %s step", _step.Name)
-		// inspect the step image
-		image, err := c.Runtime.InspectImage(ctx, _step)
-		if err != nil {
-			return err
-		}
-
-		// update the init log with step image info
-		//
-		// https://pkg.go.dev/github.com/go-vela/types/library?tab=doc#Log.AppendData
-		_log.AppendData(image)
-	}
-
-	return nil
+	return c.pullImages(ctx, c.stagePulls(s), _log.AppendData)
 }
 
 // PlanStage prepares the stage for execution.
@@ -68,32 +42,17 @@ func (c *client) PlanStage(ctx context.Context, s *pipeline.Stage, m *sync.Map)
 	// https://pkg.go.dev/github.com/sirupsen/logrus?tab=doc#Entry.WithField
 	logger := c.logger.WithField("stage", s.Name)
 
-	logger.Debug("gathering stage dependency tree")
-	// ensure dependent stages have completed
-	for _, needs := range s.Needs {
-		logger.Debugf("looking up dependency %s", needs)
-		// check if a dependency stage has completed
-		stageErr, ok := m.Load(needs)
-		if !ok { // stage not found so we continue
-			continue
-		}
-
-		logger.Debugf("waiting for dependency %s", needs)
-		// wait for the stage channel to close
-		select {
-		case <-ctx.Done():
-			return fmt.Errorf("errgroup context is done")
-		case err := <-stageErr.(chan error):
-			if err != nil {
-				logger.Errorf("%s stage returned error: %v", needs, err)
-				return err
-			}
-
-			continue
-		}
+	// check if the stage depends on other stages
+	if len(s.Needs) > 0 {
+		// publish an event for the stage waiting on dependencies
+		c.publish(&event.Event{Type: event.StageWaiting, Stage: s.Name})
 	}
 
-	return nil
+	logger.Debug("waiting for dependency stages")
+	// ensure dependent stages have completed
+	//
+	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/stage#Wait
+	return stage.Wait(ctx, s, m, c.runsOnFailure)
 }
 
 // ExecStage runs a stage.
@@ -103,33 +62,66 @@ func (c *client) ExecStage(ctx context.Context, s *pipeline.Stage, m *sync.Map)
 	// https://pkg.go.dev/github.com/sirupsen/logrus?tab=doc#Entry.WithField
 	logger := c.logger.WithField("stage", s.Name)
 
-	// close the stage channel at the end
+	var err error
+
+	// publish an event for the start of the stage
+	c.publish(&event.Event{Type: event.StageStarted, Stage: s.Name})
+
+	// record the outcome of the stage at the end
 	defer func() {
-		// retrieve the error channel for the current stage
-		errChan, ok := m.Load(s.Name)
-		if !ok {
-			logger.Debugf("error channel for stage %s not found", s.Name)
+		e := &event.Event{Type: event.StageFinished, Stage: s.Name}
 
-			return
+		// check if the stage returned an error
+		if err != nil {
+			e.Error = err.Error()
 		}
 
-		// close the error channel
-		close(errChan.(chan error))
+		// publish an event for the end of the stage
+		c.publish(e)
+
+		// wake the stages that need the stage
+		c.finishStage(s, m, err)
 	}()
 
 	logger.Debug("starting execution of stage")
 	// execute the steps for the stage
 	for _, _step := range s.Steps {
+		// check if the step is a finalizer, which
+		// runs once the build has finished
+		//
+		// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/step#Finalizer
+		if step.Finalizer(_step) {
+			continue
+		}
+
+		// wait for the build to be resumed if it is paused
+		err = c.waitPaused(ctx)
+		if err != nil {
+			return fmt.Errorf("unable to exec stage %s: %w", s.Name, err)
+		}
+
 		// check if the step should be skipped
 		//
 		// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/step#Skip
-		if step.Skip(_step, c.build, c.repo) {
+		if c.skipInStage(s, _step) {
+			// publish an event for the skipped step
+			c.publish(&event.Event{Type: event.StepSkipped, Stage: s.Name, Name: _step.Name})
+
 			continue
 		}
 
+		// check if the step requires approval
+		if stepGated(_step) {
+			// wait for the step to be approved
+			err = c.waitApproval(ctx, s.Name, _step.Name)
+			if err != nil {
+				return fmt.Errorf("unable to exec stage %s: %w", s.Name, err)
+			}
+		}
+
 		logger.Debugf("planning %s step", _step.Name)
 		// plan the step
-		err := c.PlanStep(ctx, _step)
+		err = c.PlanStep(ctx, _step)
 		if err != nil {
 			return fmt.Errorf("unable to plan step %s: %w", _step.Name, err)
 		}
@@ -145,6 +137,118 @@ func (c *client) ExecStage(ctx context.Context, s *pipeline.Stage, m *sync.Map)
 	return nil
 }
 
+// finishStage records the outcome of the stage
+// and wakes the stages that need the stage.
+func (c *client) finishStage(s *pipeline.Stage, m *sync.Map, err error) {
+	// load the tracker for the stage from the map
+	//
+	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/stage#Load
+	t, lerr := stage.Load(s.Name, m)
+	if lerr != nil {
+		c.logger.Debugf("tracker for stage %s not found", s.Name)
+
+		return
+	}
+
+	// check if the outcome of the stage is already recorded
+	if t.Finished() {
+		return
+	}
+
+	status := c.stageOutcome(s, err)
+
+	// check if the stage was canceled
+	if status == constants.StatusCanceled {
+		// wait for the stages it needs since the stage is not
+		// run, rather than canceled, if one of them did not succeed
+		//
+		// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/stage#Wait
+		nerr := stage.Wait(context.Background(), s, m, c.runsOnFailure)
+		if nerr != nil {
+			status, err = stage.StatusSkipped, nerr
+		}
+	}
+
+	// check if the stage is not run
+	if status == stage.StatusSkipped {
+		c.logger.Infof("skipping %s stage: %v", s.Name, err)
+
+		// publish an event for the skipped stage
+		c.publish(&event.Event{Type: event.StageSkipped, Stage: s.Name, Error: err.Error()})
+	}
+
+	t.Finish(status, err)
+}
+
+// stageOutcome returns the status for the stage based off
+// the error it finished with and the status of its steps.
+func (c *client) stageOutcome(s *pipeline.Stage, err error) string {
+	// check if the stage finished with an error
+	if err != nil {
+		// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/stage#Outcome
+		return stage.Outcome(err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// iterate through all steps for the stage
+	for _, _step := range s.Steps {
+		// check if container failures should be ignored
+		//
+		// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/step#SoftFailure
+		if _step.Ruleset.Continue || step.SoftFailure(_step) {
+			continue
+		}
+
+		// load the step from the client
+		//
+		// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/step#Load
+		st, err := step.Load(_step, &c.steps)
+		if err != nil {
+			continue
+		}
+
+		switch st.GetStatus() {
+		case constants.StatusCanceled:
+			return constants.StatusCanceled
+		case constants.StatusError, constants.StatusFailure, constants.StatusKilled:
+			return constants.StatusFailure
+		}
+	}
+
+	return constants.StatusSuccess
+}
+
+// stageErrors returns the errors, in pipeline order, for the
+// stages that failed or the error provided if none did.
+func (c *client) stageErrors(m *sync.Map, err error) error {
+	errs := stage.Errors{}
+
+	// iterate through all stages in the pipeline
+	for _, s := range c.pipeline.Stages {
+		// load the tracker for the stage from the map
+		//
+		// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/stage#Load
+		t, lerr := stage.Load(s.Name, m)
+		if lerr != nil || t.Err() == nil {
+			continue
+		}
+
+		// check if the stage failed
+		if t.Status() == constants.StatusFailure {
+			errs = append(errs, fmt.Errorf("%s stage: %w", s.Name, t.Err()))
+		}
+	}
+
+	// check if any stage failed
+	if len(errs) == 0 {
+		return err
+	}
+
+	return errs
+}
+
 // DestroyStage cleans up the stage after execution.
 func (c *client) DestroyStage(ctx context.Context, s *pipeline.Stage) error {
 	// update engine logger with stage metadata
@@ -18,10 +18,13 @@ import (
 	"github.com/go-vela/compiler/compiler/native"
 	"github.com/go-vela/mock/server"
 
+	"github.com/go-vela/pkg-executor/internal/stage"
+
 	"github.com/go-vela/pkg-runtime/runtime/docker"
 
 	"github.com/go-vela/sdk-go/vela"
 
+	"github.com/go-vela/types/constants"
 	"github.com/go-vela/types/pipeline"
 )
 
@@ -162,19 +165,17 @@ func TestLinux_PlanStage(t *testing.T) {
 		t.Errorf("unable to create runtime engine: %v", err)
 	}
 
+	testTracker := stage.NewTracker()
+	testTracker.Finish(constants.StatusSuccess, nil)
+
 	testMap := new(sync.Map)
-	testMap.Store("foo", make(chan error, 1))
+	testMap.Store("foo", testTracker)
 
-	tm, _ := testMap.Load("foo")
-	tm.(chan error) <- nil
-	close(tm.(chan error))
+	errTracker := stage.NewTracker()
+	errTracker.Finish(constants.StatusFailure, errors.New("bar"))
 
 	errMap := new(sync.Map)
-	errMap.Store("foo", make(chan error, 1))
-
-	em, _ := errMap.Load("foo")
-	em.(chan error) <- errors.New("bar")
-	close(em.(chan error))
+	errMap.Store("foo", errTracker)
 
 	// setup tests
 	tests := []struct {
@@ -351,7 +352,7 @@ func TestLinux_ExecStage(t *testing.T) {
 	// run tests
 	for _, test := range tests {
 		stageMap := new(sync.Map)
-		stageMap.Store("echo", make(chan error, 1))
+		stageMap.Store("echo", stage.NewTracker())
 
 		_engine, err := New(
 			WithBuild(_build),
@@ -0,0 +1,244 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package linux
+
+import (
+	"github.com/go-vela/pkg-executor/internal/build"
+	"github.com/go-vela/pkg-executor/internal/stage"
+	"github.com/go-vela/pkg-executor/internal/step"
+	"github.com/go-vela/types/constants"
+	"github.com/go-vela/types/library"
+	"github.com/go-vela/types/pipeline"
+
+	"github.com/sirupsen/logrus"
+)
+
+// locked calls the function provided while holding the lock
+// for the resources tracked by the client, reverting any
+// invalid transition it made to the status of the build.
+//
+// The function must not call any other function that
+// acquires the lock for the client, or send requests to
+// the server, which is done with a copy of the resources
+// once the lock is released.
+func (c *client) locked(fn func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	from := c.build.GetStatus()
+
+	fn()
+
+	// validate the status transition for the build
+	//
+	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/build#Transition
+	err := build.Transition(from, c.build.GetStatus())
+	if err != nil {
+		c.logger.Warnf("ignoring build status: %v", err)
+
+		c.build.SetStatus(from)
+	}
+}
+
+// syncBuild calls the function provided, to update the build,
+// while holding the lock for the client and uploads a copy of
+// the build once the lock is released, so the lock is never
+// held while waiting on the server.
+func (c *client) syncBuild(update func()) {
+	var b library.Build
+
+	c.locked(func() {
+		update()
+
+		// copy the build since it is updated during execution
+		b = *c.build
+	})
+
+	// check if the Vela client is empty
+	if c.Vela == nil {
+		return
+	}
+
+	c.logger.Debug("uploading build state")
+	// send API call to update the build
+	//
+	// https://pkg.go.dev/github.com/go-vela/sdk-go/vela?tab=doc#BuildService.Update
+	_, _, err := c.Vela.Build.Update(c.repo.GetOrg(), c.repo.GetName(), &b)
+	if err != nil {
+		c.logger.Errorf("unable to upload build state: %v", err)
+	}
+}
+
+// syncStep calls the function provided, to update the step,
+// while holding the lock for the client and uploads a copy
+// of the step once the lock is released.
+func (c *client) syncStep(s *library.Step, logger *logrus.Entry, update func()) {
+	var (
+		number int
+		st     library.Step
+	)
+
+	c.locked(func() {
+		update()
+
+		// copy the step since it is updated during execution
+		number, st = c.number, *s
+	})
+
+	c.uploadStep(number, &st, logger)
+}
+
+// uploadStep uploads the copy of the step provided for the
+// build, and must be called without holding the lock.
+func (c *client) uploadStep(number int, s *library.Step, logger *logrus.Entry) {
+	// check if the Vela client is empty
+	if c.Vela == nil {
+		return
+	}
+
+	logger.Debug("uploading step state")
+	// send API call to update the step
+	//
+	// https://pkg.go.dev/github.com/go-vela/sdk-go/vela?tab=doc#StepService.Update
+	_, _, err := c.Vela.Step.Update(c.repo.GetOrg(), c.repo.GetName(), number, s)
+	if err != nil {
+		logger.Errorf("unable to upload step state: %v", err)
+	}
+}
+
+// syncService calls the function provided, to update the service,
+// while holding the lock for the client and uploads a copy of
+// the service once the lock is released.
+func (c *client) syncService(s *library.Service, logger *logrus.Entry, update func()) {
+	var (
+		number int
+		svc    library.Service
+	)
+
+	c.locked(func() {
+		update()
+
+		// copy the service since it is updated during execution
+		number, svc = c.number, *s
+	})
+
+	// check if the Vela client is empty
+	if c.Vela == nil {
+		return
+	}
+
+	logger.Debug("uploading service state")
+	// send API call to update the service
+	//
+	// https://pkg.go.dev/github.com/go-vela/sdk-go/vela?tab=doc#SvcService.Update
+	_, _, err := c.Vela.Svc.Update(c.repo.GetOrg(), c.repo.GetName(), number, &svc)
+	if err != nil {
+		logger.Errorf("unable to upload service state: %v", err)
+	}
+}
+
+// status gets the status of the build in execution.
+func (c *client) status() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.build.GetStatus()
+}
+
+// skip returns if the step should be skipped based
+// off the ruleset for the step and the build.
+func (c *client) skip(ctn *pipeline.Container) bool {
+	var skip bool
+
+	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/step#Skip
+	c.locked(func() { skip = step.Skip(ctn, c.build, c.repo) })
+
+	return skip
+}
+
+// skipInStage returns if the step for the stage should be skipped
+// based off the ruleset for the step and the build.
+//
+// A stage that keeps running when other stages fail ignores
+// their failures, so the ruleset for the step is evaluated
+// against the status of the build before any stage ran, the
+// status of the stages it needs and the status of the steps
+// for the stage.
+func (c *client) skipInStage(s *pipeline.Stage, ctn *pipeline.Container) bool {
+	// check if a failed stage stops every other stage
+	//
+	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/stage#Policy
+	if stage.Policy(s, c.failure) == stage.FailFast {
+		return c.skip(ctn)
+	}
+
+	var skip bool
+
+	c.locked(func() {
+		// copy the build to avoid modifying its status
+		b := *c.build
+
+		b.SetStatus(c.stageBase)
+
+		// iterate through all stages the stage needs
+		for _, needs := range s.Needs {
+			// load the tracker for the stage from the client
+			//
+			// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/stage#Load
+			t, err := stage.Load(needs, &c.stages)
+			if err == nil && t.Status() == constants.StatusFailure {
+				b.SetStatus(constants.StatusFailure)
+			}
+		}
+
+		// iterate through all steps for the stage
+		for _, _step := range s.Steps {
+			// load the step from the client
+			//
+			// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/step#Load
+			st, err := step.Load(_step, &c.steps)
+			if err != nil || _step.Ruleset.Continue || step.SoftFailure(_step) {
+				continue
+			}
+
+			// check if the step failed
+			switch st.GetStatus() {
+			case constants.StatusError, constants.StatusFailure, constants.StatusKilled:
+				b.SetStatus(constants.StatusFailure)
+			}
+		}
+
+		// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/step#Skip
+		skip = step.Skip(ctn, &b, c.repo)
+	})
+
+	return skip
+}
+
+// runsOnFailure returns if any step for the stage runs based
+// off its ruleset once the build fails, so the stage runs
+// even though a stage it needs failed.
+func (c *client) runsOnFailure(s *pipeline.Stage) bool {
+	var runs bool
+
+	c.locked(func() {
+		// copy the build to avoid modifying its status
+		b := *c.build
+
+		b.SetStatus(constants.StatusFailure)
+
+		// iterate through all steps for the stage
+		for _, _step := range s.Steps {
+			// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/step#Skip
+			if !step.Skip(_step, &b, c.repo) {
+				runs = true
+
+				return
+			}
+		}
+	})
+
+	return runs
+}
@@ -0,0 +1,226 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package linux
+
+import (
+	"testing"
+
+	"github.com/go-vela/pkg-executor/internal/stage"
+	"github.com/go-vela/pkg-executor/internal/step"
+
+	"github.com/go-vela/pkg-runtime/runtime/docker"
+
+	"github.com/go-vela/types/constants"
+	"github.com/go-vela/types/library"
+)
+
+func TestLinux_locked(t *testing.T) {
+	// setup types
+	_runtime, err := docker.NewMock()
+	if err != nil {
+		t.Errorf("unable to create runtime engine: %v", err)
+	}
+
+	// setup tests
+	tests := []struct {
+		from string
+		to   string
+		want string
+	}{
+		{ // pending to running
+			from: constants.StatusPending,
+			to:   constants.StatusRunning,
+			want: constants.StatusRunning,
+		},
+		{ // running to failure
+			from: constants.StatusRunning,
+			to:   constants.StatusFailure,
+			want: constants.StatusFailure,
+		},
+		{ // success to failure
+			from: constants.StatusSuccess,
+			to:   constants.StatusFailure,
+			want: constants.StatusSuccess,
+		},
+		{ // canceled to running
+			from: constants.StatusCanceled,
+			to:   constants.StatusRunning,
+			want: constants.StatusCanceled,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		_build := testBuild()
+		_build.SetStatus(test.from)
+
+		_engine, err := New(
+			WithBuild(_build),
+			WithPipeline(testSteps()),
+			WithRepo(testRepo()),
+			WithRuntime(_runtime),
+			WithUser(testUser()),
+		)
+		if err != nil {
+			t.Errorf("unable to create executor engine: %v", err)
+		}
+
+		_engine.locked(func() { _engine.build.SetStatus(test.to) })
+
+		got := _engine.status()
+
+		if got != test.want {
+			t.Errorf("locked from %s to %s is %s, want %s", test.from, test.to, got, test.want)
+		}
+	}
+}
+
+func TestLinux_skipInStage(t *testing.T) {
+	// setup types
+	_runtime, err := docker.NewMock()
+	if err != nil {
+		t.Errorf("unable to create runtime engine: %v", err)
+	}
+
+	// setup tests
+	tests := []struct {
+		policy string
+		needs  bool
+		failed bool
+		soft   bool
+		want   bool
+	}{
+		{ // other stage failed with fail-fast
+			policy: stage.FailFast,
+			want:   true,
+		},
+		{ // other stage failed with continue-independent
+			policy: stage.ContinueIndependent,
+			want:   false,
+		},
+		{ // same stage failed with continue-independent
+			policy: stage.ContinueIndependent,
+			failed: true,
+			want:   true,
+		},
+		{ // same stage soft failed with continue-independent
+			policy: stage.ContinueIndependent,
+			failed: true,
+			soft:   true,
+			want:   false,
+		},
+		{ // stage needed failed with continue-independent
+			policy: stage.ContinueIndependent,
+			needs:  true,
+			want:   true,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		_pipeline := testStages(1, 2)
+
+		s := _pipeline.Stages[1]
+
+		// run the steps only while the build succeeds
+		for _, _step := range s.Steps {
+			_step.Ruleset.If.Status = []string{constants.StatusSuccess}
+		}
+
+		_engine, err := New(
+			WithBuild(testBuild()),
+			WithPipeline(_pipeline),
+			WithRepo(testRepo()),
+			WithRuntime(_runtime),
+			WithStageFailure(test.policy),
+			WithUser(testUser()),
+		)
+		if err != nil {
+			t.Errorf("unable to create executor engine: %v", err)
+		}
+
+		_engine.stageBase = constants.StatusSuccess
+
+		// fail the build from another stage
+		_engine.build.SetStatus(constants.StatusFailure)
+
+		// check if a stage needed by the stage failed
+		if test.needs {
+			failure := stage.NewTracker()
+			failure.Finish(constants.StatusFailure, nil)
+
+			_engine.stages.Store("other", failure)
+
+			s.Needs = []string{"other"}
+		}
+
+		// check if the first step for the stage failed
+		if test.failed {
+			_step := new(library.Step)
+			_step.SetStatus(constants.StatusFailure)
+
+			_engine.steps.Store(s.Steps[0].ID, _step)
+		}
+
+		// check if the first step for the stage soft failed
+		if test.soft {
+			s.Steps[0].Environment[step.ExitCodesKey] = "1:soft-failure"
+			s.Steps[0].ExitCode = 1
+		}
+
+		got := _engine.skipInStage(s, s.Steps[1])
+
+		if got != test.want {
+			t.Errorf("skipInStage is %v, want %v", got, test.want)
+		}
+
+		if _engine.build.GetStatus() != constants.StatusFailure {
+			t.Errorf("skipInStage build status is %s, want %s", _engine.build.GetStatus(), constants.StatusFailure)
+		}
+	}
+}
+
+func TestLinux_runsOnFailure(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		status string
+		want   bool
+	}{
+		{ // step runs only while the build succeeds
+			status: constants.StatusSuccess,
+			want:   false,
+		},
+		{ // step runs once the build fails
+			status: constants.StatusFailure,
+			want:   true,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		_pipeline := testStages(1, 2)
+
+		s := _pipeline.Stages[1]
+
+		// run the last step for the stage based off the status
+		s.Steps[0].Ruleset.If.Status = []string{constants.StatusSuccess}
+		s.Steps[1].Ruleset.If.Status = []string{test.status}
+
+		_engine, err := New(
+			WithBuild(testBuild()),
+			WithPipeline(_pipeline),
+			WithRepo(testRepo()),
+		)
+		if err != nil {
+			t.Errorf("unable to create executor engine: %v", err)
+		}
+
+		got := _engine.runsOnFailure(s)
+
+		if got != test.want {
+			t.Errorf("runsOnFailure is %v, want %v", got, test.want)
+		}
+	}
+}
@@ -5,16 +5,19 @@
 package linux
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"time"
 
+	"github.com/go-vela/pkg-executor/executor/event"
+	"github.com/go-vela/pkg-executor/executor/fault"
 	"github.com/go-vela/pkg-executor/internal/step"
 	"github.com/go-vela/types/constants"
 	"github.com/go-vela/types/library"
 	"github.com/go-vela/types/pipeline"
-	"golang.org/x/sync/errgroup"
 )
 
 // CreateStep configures the step for execution.
@@ -29,13 +32,32 @@ func (c *client) CreateStep(ctx context.Context, ctn *pipeline.Container) error
 		return nil
 	}
 
-	logger.Debug("setting up container")
-	// setup the runtime container
-	err := c.Runtime.SetupContainer(ctx, ctn)
+	logger.Debug("validating exit codes")
+	// validate the exit codes mapped for the step
+	//
+	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/step#ExitCodes
+	_, err := step.ExitCodes(ctn)
+	if err != nil {
+		return err
+	}
+
+	logger.Debug("validating detach policy")
+	// validate the detach policy declared for the step
+	//
+	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/step#DetachPolicy
+	_, err = step.DetachPolicy(ctn)
 	if err != nil {
 		return err
 	}
 
+	logger.Debug("setting up container")
+	// setup the runtime container without the
+	// environment reserved for the executor
+	err = c.Runtime.SetupContainer(ctx, runtimeContainer(ctn))
+	if err != nil {
+		return c.infraError(fault.ErrImagePull, ctn.Name, err)
+	}
+
 	// create a library step object to facilitate injecting environment as early as possible
 	// (PlanStep is too late to inject environment vars for the kubernetes runtime).
 	_step := c.newLibraryStep(ctn)
@@ -44,17 +66,19 @@ func (c *client) CreateStep(ctx context.Context, ctn *pipeline.Container) error
 	// update the step container environment
 	//
 	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/step#Environment
-	err = step.Environment(ctn, c.build, c.repo, _step, c.Version)
+	c.locked(func() { err = step.Environment(ctn, c.build, c.repo, _step, c.Version) })
 	if err != nil {
 		return err
 	}
 
-	logger.Debug("escaping newlines in secrets")
-	escapeNewlineSecrets(c.Secrets)
+	logger.Debug("escaping newlines in secrets and injecting secrets")
+	// inject secrets for container, under the lock
+	// since containers may be created in parallel
+	c.locked(func() {
+		escapeNewlineSecrets(c.Secrets)
 
-	logger.Debug("injecting secrets")
-	// inject secrets for container
-	err = injectSecrets(ctn, c.Secrets)
+		err = injectSecrets(ctn, c.Secrets)
+	})
 	if err != nil {
 		return err
 	}
@@ -78,9 +102,14 @@ func (c *client) newLibraryStep(ctn *pipeline.Container) *library.Step {
 	_step.SetNumber(ctn.Number)
 	_step.SetImage(ctn.Image)
 	_step.SetStage(ctn.Environment["VELA_STEP_STAGE"])
-	_step.SetHost(c.build.GetHost())
-	_step.SetRuntime(c.build.GetRuntime())
-	_step.SetDistribution(c.build.GetDistribution())
+
+	// capture the build fields updated once the build is created
+	c.locked(func() {
+		_step.SetHost(c.build.GetHost())
+		_step.SetRuntime(c.build.GetRuntime())
+		_step.SetDistribution(c.build.GetDistribution())
+	})
+
 	return _step
 }
 
@@ -102,15 +131,15 @@ func (c *client) PlanStep(ctx context.Context, ctn *pipeline.Container) error {
 	// send API call to update the step
 	//
 	// https://pkg.go.dev/github.com/go-vela/sdk-go/vela?tab=doc#StepService.Update
-	_step, _, err = c.Vela.Step.Update(c.repo.GetOrg(), c.repo.GetName(), c.build.GetNumber(), _step)
+	_step, _, err = c.Vela.Step.Update(c.repo.GetOrg(), c.repo.GetName(), c.number, _step)
 	if err != nil {
-		return err
+		return c.infraError(fault.ErrUpload, ctn.Name, err)
 	}
 
 	// update the step container environment
 	//
 	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/step#Environment
-	err = step.Environment(ctn, c.build, c.repo, _step, c.Version)
+	c.locked(func() { err = step.Environment(ctn, c.build, c.repo, _step, c.Version) })
 	if err != nil {
 		return err
 	}
@@ -118,16 +147,27 @@ func (c *client) PlanStep(ctx context.Context, ctn *pipeline.Container) error {
 	// add a step to a map
 	c.steps.Store(ctn.ID, _step)
 
+	// publish an event for the planned step
+	c.publish(&event.Event{
+		Type:   event.StepPlanned,
+		Stage:  _step.GetStage(),
+		Name:   ctn.Name,
+		Status: _step.GetStatus(),
+	})
+
 	// get the step log here
 	logger.Debug("retrieve step log")
 	// send API call to capture the step log
 	//
 	// https://pkg.go.dev/github.com/go-vela/sdk-go/vela?tab=doc#LogService.GetStep
-	_log, _, err := c.Vela.Log.GetStep(c.repo.GetOrg(), c.repo.GetName(), c.build.GetNumber(), _step.GetNumber())
+	_log, _, err := c.Vela.Log.GetStep(c.repo.GetOrg(), c.repo.GetName(), c.number, _step.GetNumber())
 	if err != nil {
 		return err
 	}
 
+	// record the lines noted before the step was planned
+	c.flushNotes(ctn, _log)
+
 	// add a step log to a map
 	c.stepLogs.Store(ctn.ID, _log)
 
@@ -157,40 +197,112 @@ func (c *client) ExecStep(ctx context.Context, ctn *pipeline.Container) error {
 	// defer taking a snapshot of the step
 	//
 	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/step#Snapshot
-	defer func() { step.Snapshot(ctn, c.build, c.Vela, c.logger, c.repo, _step) }()
+	defer c.syncStep(_step, c.logger, func() { step.Snapshot(ctn, c.build, nil, c.logger, c.repo, _step) })
 
-	logger.Debug("running container")
-	// run the runtime container
-	err = c.Runtime.RunContainer(ctx, ctn, c.pipeline)
+	// create a context that is canceled when the step is canceled
+	//
+	// https://pkg.go.dev/context?tab=doc#WithCancel
+	stepCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// track the step as running so it can be canceled
+	c.running.Store(ctn.ID, cancel)
+	defer c.running.Delete(ctn.ID)
+
+	// check if the step was canceled before it started
+	if c.stepCanceled(ctn, _step) {
+		return nil
+	}
+
+	// wait for the lock group for the container to be free
+	err = c.lockContainer(stepCtx, ctn, c.noteStep)
 	if err != nil {
+		// check if the step was canceled while waiting
+		if c.stepCanceled(ctn, _step) {
+			return nil
+		}
+
 		return err
 	}
 
-	// create an error group with the parent context
-	//
-	// https://pkg.go.dev/golang.org/x/sync/errgroup?tab=doc#WithContext
-	logs, logCtx := errgroup.WithContext(ctx)
-
-	logs.Go(func() error {
-		logger.Debug("streaming logs for container")
-		// stream logs from container
-		err := c.StreamStep(logCtx, ctn)
+	// check if the container is detached, in which case it runs
+	// until the build is destroyed without a slot on the host
+	if !ctn.Detach {
+		// wait for a slot on the host to run the container
+		err = c.acquireContainer(stepCtx, ctn)
 		if err != nil {
-			logger.Error(err)
+			// check if the step was canceled while waiting
+			if c.stepCanceled(ctn, _step) {
+				return nil
+			}
+
+			return err
 		}
 
-		return nil
-	})
+		// release the slot and lock group once the container exits
+		defer c.unlockContainer(ctn)
+		defer c.releaseContainer(ctn)
+	}
+
+	logger.Debug("running container")
+	// run the runtime container without the
+	// environment reserved for the executor
+	err = c.Runtime.RunContainer(stepCtx, runtimeContainer(ctn), c.pipeline)
+	if err != nil {
+		// check if the step was canceled while starting
+		if c.stepCanceled(ctn, _step) {
+			return nil
+		}
+
+		// check if the build was canceled while starting
+		if c.buildCanceled(ctx, _step) {
+			return ctx.Err()
+		}
+
+		err = c.infraError(fault.ErrContainerStart, ctn.Name, err)
+
+		// update the step with the error
+		c.stepError(_step, err)
+
+		return err
+	}
+
+	// publish an event for the started step
+	c.publish(&event.Event{Type: event.StepStarted, Stage: _step.GetStage(), Name: ctn.Name})
+
+	logger.Debug("streaming logs for container")
+	// stream logs from container
+	c.stream(ctn, c.StreamStep)
 
 	// do not wait for detached containers
 	if ctn.Detach {
+		logger.Debug("watching detached container")
+		// record the final status once the container exits
+		c.watchDetached(ctn, _step)
+
 		return nil
 	}
 
 	logger.Debug("waiting for container")
 	// wait for the runtime container
-	err = c.Runtime.WaitContainer(ctx, ctn)
+	err = c.Runtime.WaitContainer(stepCtx, ctn)
+
+	// check if the step was canceled while running
+	if c.stepCanceled(ctn, _step) {
+		return nil
+	}
+
 	if err != nil {
+		// check if the build was canceled while running
+		if c.buildCanceled(ctx, _step) {
+			return ctx.Err()
+		}
+
+		err = c.infraError(fault.ErrRuntimeUnavailable, ctn.Name, err)
+
+		// update the step with the error
+		c.stepError(_step, err)
+
 		return err
 	}
 
@@ -198,9 +310,22 @@ func (c *client) ExecStep(ctx context.Context, ctn *pipeline.Container) error {
 	// inspect the runtime container
 	err = c.Runtime.InspectContainer(ctx, ctn)
 	if err != nil {
+		err = c.infraError(fault.ErrRuntimeUnavailable, ctn.Name, err)
+
+		// update the step with the error
+		c.stepError(_step, err)
+
 		return err
 	}
 
+	// publish an event for the exited step
+	c.publish(&event.Event{
+		Type:     event.StepExited,
+		Stage:    _step.GetStage(),
+		Name:     ctn.Name,
+		ExitCode: ctn.ExitCode,
+	})
+
 	return nil
 }
 
@@ -224,58 +349,62 @@ func (c *client) StreamStep(ctx context.Context, ctn *pipeline.Container) error
 		return err
 	}
 
-	// nolint: dupl // ignore similar code
-	defer func() {
-		// tail the runtime container
-		rc, err := c.Runtime.TailContainer(ctx, ctn)
-		if err != nil {
-			logger.Errorf("unable to tail container output for upload: %v", err)
+	logger.Debug("tailing container")
+	// tail the runtime container
+	rc, err := c.Runtime.TailContainer(ctx, ctn)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
 
-			return
-		}
-		defer rc.Close()
+	// capture the output streamed for the step so it
+	// is uploaded even once the container is removed
+	output := new(bytes.Buffer)
 
-		// read all output from the runtime container
-		data, err := ioutil.ReadAll(rc)
-		if err != nil {
-			logger.Errorf("unable to read container output for upload: %v", err)
+	defer func() {
+		// capture a copy of the log to upload
+		var upload library.Log
 
-			return
-		}
+		c.locked(func() {
+			// overwrite the existing log with all bytes following
+			// the lines recorded for the step before it started,
+			// such as the wait for its lock group
+			//
+			// https://pkg.go.dev/github.com/go-vela/types/library?tab=doc#Log.SetData
+			_log.SetData(append(append([]byte(nil), _log.GetData()...), output.Bytes()...))
 
-		// overwrite the existing log with all bytes
-		//
-		// https://pkg.go.dev/github.com/go-vela/types/library?tab=doc#Log.SetData
-		_log.SetData(data)
+			upload = *_log
+		})
 
 		logger.Debug("uploading logs")
 		// send API call to update the logs for the step
 		//
 		// https://pkg.go.dev/github.com/go-vela/sdk-go/vela?tab=doc#LogService.UpdateStep
-		_, _, err = c.Vela.Log.UpdateStep(c.repo.GetOrg(), c.repo.GetName(), c.build.GetNumber(), ctn.Number, _log)
+		_, _, err := c.Vela.Log.UpdateStep(c.repo.GetOrg(), c.repo.GetName(), c.number, ctn.Number, &upload)
 		if err != nil {
 			logger.Errorf("unable to upload container logs: %v", err)
 		}
 	}()
 
-	logger.Debug("tailing container")
-	// tail the runtime container
-	rc, err := c.Runtime.TailContainer(ctx, ctn)
-	if err != nil {
-		return err
-	}
-	defer rc.Close()
-
 	// set the timeout to the repo timeout
 	// to ensure the stream is not cut off
 	c.Vela.SetTimeout(time.Minute * time.Duration(c.repo.GetTimeout()))
 
+	// publish the output as log events while streaming it
+	logs := &captureReader{
+		ReadCloser: c.tailLogs(rc, ctn),
+		buf:        output,
+	}
+
 	// https://pkg.go.dev/github.com/go-vela/sdk-go/vela?tab=doc#StepService.Stream
-	_, err = c.Vela.Step.Stream(c.repo.GetOrg(), c.repo.GetName(), c.build.GetNumber(), ctn.Number, rc)
+	_, err = c.Vela.Step.Stream(c.repo.GetOrg(), c.repo.GetName(), c.number, ctn.Number, logs)
 	if err != nil {
 		logger.Errorf("unable to stream logs: %v", err)
 	}
 
+	// read the output left behind by a stream that ended early
+	_, _ = io.Copy(ioutil.Discard, logs)
+
 	logger.Info("finished streaming logs")
 
 	return nil
@@ -307,7 +436,17 @@ func (c *client) DestroyStep(ctx context.Context, ctn *pipeline.Container) error
 	// defer an upload of the step
 	//
 	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/step#Upload
-	defer func() { step.Upload(ctn, c.build, c.Vela, logger, c.repo, _step) }()
+	defer c.syncStep(_step, logger, func() { step.Upload(ctn, c.build, nil, logger, c.repo, _step) })
+
+	// release the slot on the host and
+	// lock group held by the container
+	defer c.unlockContainer(ctn)
+	defer c.releaseContainer(ctn)
+
+	logger.Debug("waiting for container")
+	// wait, up to the stop timeout, for the runtime container to
+	// exit so the output for the step finishes streaming
+	exited := c.waitContainer(ctx, ctn)
 
 	logger.Debug("inspecting container")
 	// inspect the runtime container
@@ -316,12 +455,6 @@ func (c *client) DestroyStep(ctx context.Context, ctn *pipeline.Container) error
 		return err
 	}
 
-	logger.Debug("removing container")
-	// remove the runtime container
-	err = c.Runtime.RemoveContainer(ctx, ctn)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	// remove the runtime container once its output has flushed
+	return c.removeContainer(ctx, ctn, exited)
 }
@@ -5,8 +5,18 @@
 package local
 
 import (
+	"errors"
 	"reflect"
 	"testing"
+	"time"
+
+	"github.com/go-vela/pkg-executor/executor/event"
+	"github.com/go-vela/pkg-executor/executor/status"
+	"github.com/go-vela/pkg-executor/internal/stage"
+
+	"github.com/go-vela/types/constants"
+	"github.com/go-vela/types/library"
+	"github.com/go-vela/types/pipeline"
 )
 
 func TestLocal_GetBuild(t *testing.T) {
@@ -152,3 +162,366 @@ func TestLocal_GetRepo(t *testing.T) {
 		}
 	}
 }
+
+func TestLocal_GetPhase(t *testing.T) {
+	// setup types
+	_engine, err := New(
+		WithBuild(testBuild()),
+	)
+	if err != nil {
+		t.Errorf("unable to create executor engine: %v", err)
+	}
+
+	_engine.publishPhase(event.BuildPhaseStarted, event.PhaseExec, nil)
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		engine  *client
+		want    string
+	}{
+		{
+			failure: false,
+			engine:  _engine,
+			want:    event.PhaseExec,
+		},
+		{
+			failure: true,
+			engine:  new(client),
+			want:    "",
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got, err := test.engine.GetPhase()
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("GetPhase should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("GetPhase returned err: %v", err)
+		}
+
+		if got != test.want {
+			t.Errorf("GetPhase is %v, want %v", got, test.want)
+		}
+	}
+}
+
+func TestLocal_GetStep(t *testing.T) {
+	// setup types
+	_steps := testSteps()
+
+	_step := new(library.Step)
+	_step.SetName("echo")
+	_step.SetNumber(3)
+	_step.SetStatus(constants.StatusRunning)
+
+	_engine, err := New(
+		WithPipeline(_steps),
+	)
+	if err != nil {
+		t.Errorf("unable to create executor engine: %v", err)
+	}
+
+	_engine.steps.Store(_steps.Steps[2].ID, _step)
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		engine  *client
+		name    string
+		want    *library.Step
+	}{
+		{ // step in execution
+			failure: false,
+			engine:  _engine,
+			name:    "echo",
+			want:    _step,
+		},
+		{ // step not planned yet
+			failure: true,
+			engine:  _engine,
+			name:    "clone",
+		},
+		{ // step not in pipeline
+			failure: true,
+			engine:  _engine,
+			name:    "foo",
+		},
+		{ // empty client
+			failure: true,
+			engine:  new(client),
+			name:    "echo",
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got, err := test.engine.GetStep("", test.name)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("GetStep should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("GetStep returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("GetStep is %v, want %v", got, test.want)
+		}
+	}
+}
+
+func TestLocal_GetService(t *testing.T) {
+	// setup types
+	_steps := testSteps()
+
+	_service := new(library.Service)
+	_service.SetName("postgres")
+	_service.SetNumber(1)
+	_service.SetStatus(constants.StatusRunning)
+
+	_engine, err := New(
+		WithPipeline(_steps),
+	)
+	if err != nil {
+		t.Errorf("unable to create executor engine: %v", err)
+	}
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		engine  *client
+		name    string
+		want    *library.Service
+	}{
+		{ // service in execution
+			failure: false,
+			engine:  _engine,
+			name:    "postgres",
+			want:    _service,
+		},
+		{ // service not in pipeline
+			failure: true,
+			engine:  _engine,
+			name:    "redis",
+		},
+		{ // empty client
+			failure: true,
+			engine:  new(client),
+			name:    "postgres",
+		},
+	}
+
+	_engine.services.Store(_steps.Services[0].ID, _service)
+
+	// run tests
+	for _, test := range tests {
+		got, err := test.engine.GetService(test.name)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("GetService should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("GetService returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("GetService is %v, want %v", got, test.want)
+		}
+	}
+}
+
+func TestLocal_GetServices(t *testing.T) {
+	// setup types
+	_steps := testSteps()
+
+	_service := new(library.Service)
+	_service.SetName("postgres")
+	_service.SetNumber(1)
+	_service.SetStatus(constants.StatusRunning)
+
+	_engine, err := New(
+		WithPipeline(_steps),
+	)
+	if err != nil {
+		t.Errorf("unable to create executor engine: %v", err)
+	}
+
+	_engine.services.Store(_steps.Services[0].ID, _service)
+
+	// run test
+	got, err := _engine.GetServices()
+	if err != nil {
+		t.Errorf("GetServices returned err: %v", err)
+	}
+
+	if !reflect.DeepEqual(got, []*library.Service{_service}) {
+		t.Errorf("GetServices is %v, want %v", got, []*library.Service{_service})
+	}
+
+	// updating the copy should not modify the service
+	got[0].SetStatus(constants.StatusSuccess)
+
+	if _service.GetStatus() != constants.StatusRunning {
+		t.Errorf("GetServices should return a copy of the service")
+	}
+
+	_, err = new(client).GetServices()
+	if err == nil {
+		t.Errorf("GetServices should have returned err")
+	}
+}
+
+func TestLocal_GetSteps(t *testing.T) {
+	// setup types
+	_steps := testSteps()
+
+	_step := new(library.Step)
+	_step.SetName("echo")
+	_step.SetNumber(3)
+	_step.SetStatus(constants.StatusRunning)
+
+	_engine, err := New(
+		WithPipeline(_steps),
+	)
+	if err != nil {
+		t.Errorf("unable to create executor engine: %v", err)
+	}
+
+	_engine.steps.Store(_steps.Steps[2].ID, _step)
+
+	// run test
+	got, err := _engine.GetSteps()
+	if err != nil {
+		t.Errorf("GetSteps returned err: %v", err)
+	}
+
+	if !reflect.DeepEqual(got, []*library.Step{_step}) {
+		t.Errorf("GetSteps is %v, want %v", got, []*library.Step{_step})
+	}
+
+	// updating the copy should not modify the step
+	got[0].SetStatus(constants.StatusSuccess)
+
+	if _step.GetStatus() != constants.StatusRunning {
+		t.Errorf("GetSteps should return a copy of the step")
+	}
+
+	_, err = new(client).GetSteps()
+	if err == nil {
+		t.Errorf("GetSteps should have returned err")
+	}
+}
+
+func TestLocal_GetStatus(t *testing.T) {
+	// setup types
+	_build := testBuild()
+	_build.SetStatus(constants.StatusRunning)
+
+	_stages := &pipeline.Build{
+		Version: "1",
+		ID:      "github_octocat_1",
+		Stages: pipeline.StageSlice{
+			{
+				Name: "test",
+				Steps: pipeline.ContainerSlice{
+					{
+						ID:    "github_octocat_1_test_test",
+						Image: "alpine:latest",
+						Name:  "test",
+					},
+				},
+			},
+			{
+				Name:  "deploy",
+				Needs: []string{"test"},
+				Steps: pipeline.ContainerSlice{
+					{
+						ID:    "github_octocat_1_deploy_deploy",
+						Image: "alpine:latest",
+						Name:  "deploy",
+					},
+				},
+			},
+			{
+				Name: "lint",
+				Steps: pipeline.ContainerSlice{
+					{
+						ID:    "github_octocat_1_lint_lint",
+						Image: "alpine:latest",
+						Name:  "lint",
+					},
+				},
+			},
+		},
+	}
+
+	_step := new(library.Step)
+	_step.SetName("test")
+	_step.SetStage("test")
+	_step.SetStatus(constants.StatusRunning)
+	_step.SetStarted(time.Now().UTC().Unix())
+
+	_engine, err := New(
+		WithBuild(_build),
+		WithPipeline(_stages),
+	)
+	if err != nil {
+		t.Errorf("unable to create executor engine: %v", err)
+	}
+
+	_engine.steps.Store(_stages.Stages[0].Steps[0].ID, _step)
+
+	_tracker := stage.NewTracker()
+	_tracker.Finish(constants.StatusFailure, errors.New("unable to plan stage"))
+
+	_engine.stages.Store("lint", _tracker)
+
+	want := &status.Summary{
+		Build:    constants.StatusRunning,
+		Running:  []*status.Item{{Name: "test", Stage: "test", Status: constants.StatusRunning}},
+		Queued:   []string{"deploy"},
+		Services: []*status.Item{},
+		Steps:    []*status.Item{{Name: "test", Stage: "test", Status: constants.StatusRunning}},
+		Stages:   map[string]string{"lint": constants.StatusFailure},
+		Errors:   map[string]string{"lint": "unable to plan stage"},
+	}
+
+	// run test
+	got, err := _engine.GetStatus()
+	if err != nil {
+		t.Errorf("GetStatus returned err: %v", err)
+	}
+
+	// the elapsed time depends on when the test runs
+	for _, item := range got.Steps {
+		item.Elapsed = 0
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetStatus is %v, want %v", got, want)
+	}
+
+	_, err = new(client).GetStatus()
+	if err == nil {
+		t.Errorf("GetStatus should have returned err")
+	}
+}
@@ -0,0 +1,355 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package local
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-vela/pkg-executor/executor/event"
+	"github.com/go-vela/pkg-executor/internal/step"
+	"github.com/go-vela/types/constants"
+	"github.com/go-vela/types/library"
+	"github.com/go-vela/types/pipeline"
+)
+
+// killer represents a runtime capable of sending
+// a signal to the process running in a container.
+//
+// The runtime.Engine does not support signals, so the
+// client falls back to forcefully removing a container
+// when the runtime does not implement this interface.
+type killer interface {
+	KillContainer(context.Context, *pipeline.Container, string) error
+}
+
+// CancelStep cancels the step, by stage and name, from the build
+// in execution without canceling the rest of the build.
+func (c *client) CancelStep(stage, name string) (*library.Step, error) {
+	// get the current pipeline from the client
+	p, err := c.GetPipeline()
+	if err != nil {
+		return nil, err
+	}
+
+	// find the step from the pipeline
+	//
+	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/step#Find
+	ctn, err := step.Find(p, stage, name)
+	if err != nil {
+		return nil, err
+	}
+
+	// TODO: remove hardcoded reference
+	if ctn.Name == "init" {
+		return nil, fmt.Errorf("unable to cancel %s step", ctn.Name)
+	}
+
+	return c.cancelStep(ctn, stage), nil
+}
+
+// CancelStage cancels all steps for the stage, by name, from the
+// build in execution without canceling the rest of the build.
+func (c *client) CancelStage(name string) ([]*library.Step, error) {
+	// get the current pipeline from the client
+	p, err := c.GetPipeline()
+	if err != nil {
+		return nil, err
+	}
+
+	// iterate through each stage in the pipeline
+	for _, s := range p.Stages {
+		// check if the stage matches the name provided
+		if s.Name != name {
+			continue
+		}
+
+		// TODO: remove hardcoded reference
+		if s.Name == "init" {
+			return nil, fmt.Errorf("unable to cancel %s stage", s.Name)
+		}
+
+		steps := []*library.Step{}
+
+		// cancel every step for the stage
+		for _, _step := range s.Steps {
+			steps = append(steps, c.cancelStep(_step, s.Name))
+		}
+
+		return steps, nil
+	}
+
+	return nil, fmt.Errorf("unable to find stage %s", name)
+}
+
+// cancelStep marks the step as canceled and stops the
+// container for the step if it is currently running.
+func (c *client) cancelStep(ctn *pipeline.Container, stage string) *library.Step {
+	// load the step from the client
+	//
+	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/step#Load
+	_step, err := step.Load(ctn, &c.steps)
+	if err != nil {
+		// create the library step object
+		_step = new(library.Step)
+		_step.SetName(ctn.Name)
+		_step.SetNumber(ctn.Number)
+		_step.SetImage(ctn.Image)
+		_step.SetStage(stage)
+		_step.SetHost(c.build.GetHost())
+		_step.SetRuntime(c.build.GetRuntime())
+		_step.SetDistribution(c.build.GetDistribution())
+	}
+
+	var (
+		completed bool
+		copied    library.Step
+	)
+
+	c.locked(func() {
+		// capture a copy of the step to return
+		defer func() { copied = *_step }()
+
+		// check if the step has already completed
+		switch _step.GetStatus() {
+		case constants.StatusCanceled, constants.StatusError,
+			constants.StatusFailure, constants.StatusKilled,
+			constants.StatusSuccess:
+			completed = true
+
+			return
+		}
+
+		// mark the step as canceled for the executor
+		c.canceled.Store(ctn.ID, true)
+
+		// update the step with a canceled state
+		_step.SetStatus(constants.StatusCanceled)
+		_step.SetFinished(time.Now().UTC().Unix())
+
+		// add the step to the map
+		c.steps.Store(ctn.ID, _step)
+	})
+
+	// check if the step has already completed
+	if completed {
+		return &copied
+	}
+
+	// publish an event for the canceled step
+	c.publish(&event.Event{Type: event.StepCanceled, Stage: stage, Name: ctn.Name})
+
+	// check if the step is currently running
+	cancel, ok := c.running.Load(ctn.ID)
+	if !ok {
+		return &copied
+	}
+
+	// stop the runtime container
+	err = c.stopContainer(context.Background(), ctn)
+	if err != nil {
+		fmt.Fprintln(os.Stdout, "unable to stop container:", err)
+	}
+
+	// stop waiting on the step
+	cancel.(context.CancelFunc)()
+
+	return &copied
+}
+
+// stepCanceled returns true if the step was canceled. The step
+// status is recorded as canceled and the build status is updated
+// the same way it would be for a failed step, so the rest of the
+// pipeline continues according to the ruleset.
+func (c *client) stepCanceled(ctn *pipeline.Container, s *library.Step) bool {
+	// check if the step was canceled
+	_, ok := c.canceled.Load(ctn.ID)
+	if !ok {
+		return false
+	}
+
+	c.locked(func() {
+		// update the step with a canceled state
+		s.SetStatus(constants.StatusCanceled)
+
+		// check if the step was not finished
+		if s.GetFinished() == 0 {
+			s.SetFinished(time.Now().UTC().Unix())
+		}
+
+		// check if container failures should be ignored
+		if !ctn.Ruleset.Continue {
+			// set build status to failure
+			c.build.SetStatus(constants.StatusFailure)
+		}
+	})
+
+	return true
+}
+
+// buildCanceled returns true if the build was canceled while
+// the step was in execution. The runtime returns the error for
+// the canceled context once the build is canceled, so the step
+// status is recorded as canceled rather than as an error.
+//
+// A build that exceeded its time limit is not
+// canceled and is reported as a timeout instead.
+func (c *client) buildCanceled(ctx context.Context, s *library.Step) bool {
+	// check if the context for the build was canceled
+	if !errors.Is(ctx.Err(), context.Canceled) || c.timedOut() {
+		return false
+	}
+
+	c.locked(func() {
+		// update the step with a canceled state
+		s.SetStatus(constants.StatusCanceled)
+
+		// check if the step was not finished
+		if s.GetFinished() == 0 {
+			s.SetFinished(time.Now().UTC().Unix())
+		}
+	})
+
+	return true
+}
+
+// buildContext creates a context from the one provided
+// that is also canceled once the build is canceled.
+func (c *client) buildContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	// https://pkg.go.dev/context?tab=doc#WithCancel
+	ctx, cancel := context.WithCancel(ctx)
+
+	done := c.canceledBuild()
+
+	// check if the build has already been canceled
+	select {
+	case <-done:
+		cancel()
+
+		return ctx, cancel
+	default:
+	}
+
+	go func() {
+		select {
+		case <-done:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, cancel
+}
+
+// canceledBuild returns a channel that is
+// closed once the build has been canceled.
+func (c *client) canceledBuild() <-chan struct{} {
+	c.doneMu.Lock()
+	defer c.doneMu.Unlock()
+
+	// check if the channel has been initialized
+	if c.done == nil {
+		c.done = make(chan struct{})
+	}
+
+	return c.done
+}
+
+// cancelBuild cancels the contexts for every
+// phase of the build currently in execution.
+func (c *client) cancelBuild() {
+	c.doneMu.Lock()
+	defer c.doneMu.Unlock()
+
+	// check if the channel has been initialized
+	if c.done == nil {
+		c.done = make(chan struct{})
+	}
+
+	// check if the build has already been canceled
+	select {
+	case <-c.done:
+		return
+	default:
+	}
+
+	close(c.done)
+}
+
+// stopContainer sends the stop signal to the container and
+// waits, up to the stop timeout, for it to exit before the
+// container is forcefully removed.
+func (c *client) stopContainer(ctx context.Context, ctn *pipeline.Container) error {
+	// check if the runtime is able to signal the container
+	k, ok := c.Runtime.(killer)
+	if ok {
+		// send the stop signal to the runtime container
+		err := k.KillContainer(ctx, ctn, c.stopSignal)
+		if err == nil && c.waitContainer(ctx, ctn) {
+			return nil
+		}
+	}
+
+	// forcefully remove the runtime container
+	return c.Runtime.RemoveContainer(ctx, ctn)
+}
+
+// waitContainer waits, up to the stop timeout, for the container
+// to exit. It returns false if the container is still running.
+func (c *client) waitContainer(ctx context.Context, ctn *pipeline.Container) bool {
+	// create a context for the grace period of the container
+	//
+	// https://pkg.go.dev/context?tab=doc#WithTimeout
+	waitCtx, cancel := context.WithTimeout(ctx, c.stopTimeout)
+	defer cancel()
+
+	// wait for the runtime container to exit
+	return c.Runtime.WaitContainer(waitCtx, ctn) == nil
+}
+
+// stopContainers stops all containers provided in parallel.
+func (c *client) stopContainers(ctx context.Context, containers []*pipeline.Container) {
+	wg := new(sync.WaitGroup)
+
+	// iterate through all containers provided
+	for _, ctn := range containers {
+		wg.Add(1)
+
+		// https://golang.org/doc/faq#closures_and_goroutines
+		go func(ctn *pipeline.Container) {
+			defer wg.Done()
+
+			err := c.stopContainer(ctx, ctn)
+			if err != nil {
+				fmt.Fprintln(os.Stdout, "unable to stop container:", err)
+			}
+		}(ctn)
+	}
+
+	wg.Wait()
+}
+
+// waitStreams waits, up to the stop timeout, for the
+// output of all containers to finish streaming.
+func (c *client) waitStreams() error {
+	done := make(chan struct{})
+
+	go func() {
+		c.streams.Wait()
+
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-time.After(c.stopTimeout):
+		return fmt.Errorf("timed out waiting %v for container output to flush", c.stopTimeout)
+	}
+}
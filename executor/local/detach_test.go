@@ -0,0 +1,132 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package local
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-vela/pkg-runtime/runtime/docker"
+
+	"github.com/go-vela/types/constants"
+	"github.com/go-vela/types/library"
+	"github.com/go-vela/types/pipeline"
+)
+
+func TestLocal_exitDetached(t *testing.T) {
+	// setup types
+	_step := &pipeline.Container{
+		ID:          "step_github_octocat_1_redis",
+		Detach:      true,
+		Directory:   "/vela/src/github.com/github/octocat",
+		Environment: map[string]string{"FOO": "bar"},
+		Image:       "redis:latest",
+		Name:        "redis",
+		Number:      1,
+		Pull:        "not_present",
+	}
+
+	_runtime, err := docker.NewMock()
+	if err != nil {
+		t.Errorf("unable to create runtime engine: %v", err)
+	}
+
+	// setup tests
+	tests := []struct {
+		status string
+		killed bool
+		want   string
+	}{
+		{
+			status: constants.StatusRunning,
+			want:   constants.StatusSuccess,
+		},
+		{
+			status: constants.StatusRunning,
+			killed: true,
+			want:   constants.StatusKilled,
+		},
+		{
+			status: constants.StatusCanceled,
+			want:   constants.StatusCanceled,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		_engine, err := New(
+			WithBuild(testBuild()),
+			WithPipeline(new(pipeline.Build)),
+			WithRepo(testRepo()),
+			WithRuntime(_runtime),
+			WithUser(testUser()),
+		)
+		if err != nil {
+			t.Errorf("unable to create executor engine: %v", err)
+		}
+
+		got := new(library.Step)
+		got.SetStatus(test.status)
+
+		_engine.exitDetached(context.Background(), _step, got, test.killed)
+
+		if got.GetStatus() != test.want {
+			t.Errorf("exitDetached status is %s, want %s", got.GetStatus(), test.want)
+		}
+
+		// check if the step reached its final status
+		if test.status == constants.StatusRunning && got.GetFinished() == 0 {
+			t.Errorf("exitDetached did not set the finished timestamp")
+		}
+	}
+}
+
+func TestLocal_stopDetached(t *testing.T) {
+	// setup types
+	_engine, err := New(
+		WithBuild(testBuild()),
+		WithPipeline(new(pipeline.Build)),
+	)
+	if err != nil {
+		t.Errorf("unable to create executor engine: %v", err)
+	}
+
+	_step := &pipeline.Container{
+		ID:     "step_github_octocat_1_redis",
+		Detach: true,
+		Name:   "redis",
+	}
+
+	got := new(library.Step)
+	got.SetStatus(constants.StatusFailure)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	d := &detached{
+		monitor: monitor{cancel: cancel, done: make(chan struct{})},
+		ctn:     _step,
+		step:    got,
+	}
+
+	_engine.detached.Store(_step.ID, d)
+
+	// simulate the watch for the detached step exiting once canceled
+	go func() {
+		<-ctx.Done()
+
+		close(d.done)
+	}()
+
+	// run test
+	_engine.stopDetached(context.Background())
+
+	if _, ok := _engine.detached.Load(_step.ID); ok {
+		t.Errorf("stopDetached did not remove the detached step")
+	}
+
+	if got.GetStatus() != constants.StatusFailure {
+		t.Errorf("stopDetached status is %s, want %s", got.GetStatus(), constants.StatusFailure)
+	}
+}
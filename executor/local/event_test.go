@@ -0,0 +1,194 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package local
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-vela/pkg-executor/executor/event"
+
+	"github.com/go-vela/pkg-runtime/runtime/docker"
+)
+
+func TestLocal_Subscribe(t *testing.T) {
+	// setup types
+	_build := testBuild()
+
+	_runtime, err := docker.NewMock()
+	if err != nil {
+		t.Errorf("unable to create runtime engine: %v", err)
+	}
+
+	// setup tests
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{ // phase without error
+			err:  nil,
+			want: "",
+		},
+		{ // phase with error
+			err:  errors.New("foo"),
+			want: "foo",
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		_engine, err := New(
+			WithBuild(_build),
+			WithPipeline(testSteps()),
+			WithRepo(testRepo()),
+			WithRuntime(_runtime),
+			WithUser(testUser()),
+		)
+		if err != nil {
+			t.Errorf("unable to create executor engine: %v", err)
+		}
+
+		events, cancel := _engine.Subscribe(1)
+
+		_engine.publishPhase(event.BuildPhaseStarted, event.PhaseCreate, test.err)
+
+		got := <-events
+
+		if got.Build != _build.GetNumber() {
+			t.Errorf("Subscribe build is %d, want %d", got.Build, _build.GetNumber())
+		}
+
+		if got.Type != event.BuildPhaseStarted {
+			t.Errorf("Subscribe type is %s, want %s", got.Type, event.BuildPhaseStarted)
+		}
+
+		if got.Error != test.want {
+			t.Errorf("Subscribe error is %s, want %s", got.Error, test.want)
+		}
+
+		cancel()
+	}
+}
+
+func TestLocal_FollowStep(t *testing.T) {
+	// setup types
+	_engine, err := New(
+		WithBuild(testBuild()),
+		WithPipeline(testSteps()),
+	)
+	if err != nil {
+		t.Errorf("unable to create executor engine: %v", err)
+	}
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		engine  *client
+		name    string
+	}{
+		{ // step in pipeline
+			failure: false,
+			engine:  _engine,
+			name:    "echo",
+		},
+		{ // step not in pipeline
+			failure: true,
+			engine:  _engine,
+			name:    "foo",
+		},
+		{ // empty client
+			failure: true,
+			engine:  new(client),
+			name:    "echo",
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		lines, cancel, err := test.engine.FollowStep("", test.name, 0)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("FollowStep should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("FollowStep returned err: %v", err)
+		}
+
+		_, _ = test.engine.tail(test.engine.pipeline.Steps[2]).Write([]byte("hello\n"))
+
+		got := <-lines
+		if got.Data != "hello" {
+			t.Errorf("FollowStep received %s, want hello", got.Data)
+		}
+
+		cancel()
+	}
+}
+
+func TestLocal_FollowService(t *testing.T) {
+	// setup types
+	_engine, err := New(
+		WithBuild(testBuild()),
+		WithPipeline(testSteps()),
+	)
+	if err != nil {
+		t.Errorf("unable to create executor engine: %v", err)
+	}
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		engine  *client
+		name    string
+	}{
+		{ // service in pipeline
+			failure: false,
+			engine:  _engine,
+			name:    "postgres",
+		},
+		{ // service not in pipeline
+			failure: true,
+			engine:  _engine,
+			name:    "redis",
+		},
+		{ // empty client
+			failure: true,
+			engine:  new(client),
+			name:    "postgres",
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		lines, cancel, err := test.engine.FollowService(test.name, 0)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("FollowService should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("FollowService returned err: %v", err)
+		}
+
+		// closing the logs should end the followers
+		test.engine.closeTails()
+
+		_, ok := <-lines
+		if ok {
+			t.Errorf("FollowService channel should be closed")
+		}
+
+		cancel()
+	}
+}
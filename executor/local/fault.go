@@ -0,0 +1,62 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package local
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/go-vela/pkg-executor/executor/fault"
+	"github.com/go-vela/types/constants"
+	"github.com/go-vela/types/library"
+)
+
+// infraError returns an infrastructure error of the kind provided
+// for the resource, by name, caused by the error provided.
+//
+// The error is reported as a timeout once the
+// build has exceeded its time limit.
+func (c *client) infraError(kind error, name string, err error) error {
+	// check if the build exceeded its time limit
+	if c.timedOut() {
+		kind = fault.ErrTimeout
+	}
+
+	// https://pkg.go.dev/github.com/go-vela/pkg-executor/executor/fault#Wrap
+	return fault.Wrap(kind, name, err)
+}
+
+// timedOut returns true once the build has exceeded its time limit.
+func (c *client) timedOut() bool {
+	return atomic.LoadInt32(&c.expired) == 1
+}
+
+// stepError updates the step with an error
+// status along with the error that caused it.
+func (c *client) stepError(s *library.Step, err error) {
+	c.locked(func() {
+		s.SetStatus(constants.StatusError)
+		s.SetError(err.Error())
+
+		// check if the step was not finished
+		if s.GetFinished() == 0 {
+			s.SetFinished(time.Now().UTC().Unix())
+		}
+	})
+}
+
+// serviceError updates the service with an error
+// status along with the error that caused it.
+func (c *client) serviceError(s *library.Service, err error) {
+	c.locked(func() {
+		s.SetStatus(constants.StatusError)
+		s.SetError(err.Error())
+
+		// check if the service was not finished
+		if s.GetFinished() == 0 {
+			s.SetFinished(time.Now().UTC().Unix())
+		}
+	})
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package local
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync/atomic"
+
+	"github.com/go-vela/pkg-executor/internal/step"
+)
+
+// finalize runs the finalizers for the build once, after the
+// build succeeds, fails, exceeds its time limit or is canceled,
+// and before the build is destroyed.
+//
+// The finalizers run in the order they appear in the pipeline,
+// without regard to their ruleset or the build being paused,
+// and with their own time limit since the context for the
+// build may already be canceled.
+func (c *client) finalize() {
+	c.finally.Do(func() {
+		// capture the finalizers for the pipeline
+		//
+		// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/step#Finalizers
+		finalizers := step.Finalizers(c.pipeline)
+
+		// check if the pipeline has any finalizers
+		if len(finalizers) == 0 {
+			return
+		}
+
+		// check if the build was assembled since the finalizers
+		// would run without their images or volumes
+		if atomic.LoadInt32(&c.assembled) == 0 {
+			fmt.Fprintln(os.Stdout, "skipping finalizers since the build was not assembled")
+
+			return
+		}
+
+		// create a context that is canceled once the
+		// finalizers exceed their time limit
+		//
+		// https://pkg.go.dev/context?tab=doc#WithTimeout
+		ctx, cancel := context.WithTimeout(context.Background(), c.finalizer)
+		defer cancel()
+
+		// iterate through all finalizers for the pipeline
+		for _, _step := range finalizers {
+			// plan the finalizer
+			err := c.PlanStep(ctx, _step)
+			if err != nil {
+				// output the error information to stdout
+				fmt.Fprintln(os.Stdout, "unable to plan finalizer:", err)
+
+				continue
+			}
+
+			// execute the finalizer
+			err = c.ExecStep(ctx, _step)
+			if err != nil {
+				// output the error information to stdout
+				fmt.Fprintln(os.Stdout, "unable to execute finalizer:", err)
+			}
+		}
+	})
+}
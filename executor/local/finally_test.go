@@ -0,0 +1,115 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package local
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-vela/pkg-executor/executor/event"
+	"github.com/go-vela/pkg-executor/internal/step"
+
+	"github.com/go-vela/pkg-runtime/runtime/docker"
+
+	"github.com/go-vela/types/constants"
+)
+
+func TestLocal_finalize(t *testing.T) {
+	// setup types
+	_pipeline := testSteps()
+
+	// mark the echo step as a finalizer
+	_pipeline.Steps[2].Environment[step.FinallyKey] = "true"
+
+	_runtime, err := docker.NewMock()
+	if err != nil {
+		t.Errorf("unable to create runtime engine: %v", err)
+	}
+
+	// setup tests
+	tests := []struct {
+		name      string
+		assembled bool
+		want      int
+	}{
+		{
+			name:      "assembled build",
+			assembled: true,
+			want:      1,
+		},
+		{
+			name:      "build not assembled",
+			assembled: false,
+			want:      0,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		_engine, err := New(
+			WithBuild(testBuild()),
+			WithPipeline(_pipeline),
+			WithRepo(testRepo()),
+			WithRuntime(_runtime),
+			WithUser(testUser()),
+		)
+		if err != nil {
+			t.Errorf("%s unable to create executor engine: %v", test.name, err)
+		}
+
+		// run create to init steps to be created properly
+		err = _engine.CreateBuild(context.Background())
+		if err != nil {
+			t.Errorf("%s unable to create build: %v", test.name, err)
+		}
+
+		// run plan and assemble to prepare the build
+		if test.assembled {
+			err = _engine.PlanBuild(context.Background())
+			if err != nil {
+				t.Errorf("%s unable to plan build: %v", test.name, err)
+			}
+
+			err = _engine.AssembleBuild(context.Background())
+			if err != nil {
+				t.Errorf("%s unable to assemble build: %v", test.name, err)
+			}
+		}
+
+		events, cancel := _engine.Subscribe(100)
+
+		// run test
+		_engine.finalize()
+		_engine.finalize()
+
+		cancel()
+
+		planned := 0
+
+		// count the times the finalizer was planned
+		for e := range events {
+			if e.Type == event.StepPlanned && e.Name == "echo" {
+				planned++
+			}
+		}
+
+		if planned != test.want {
+			t.Errorf("%s finalize planned echo %d times, want %d", test.name, planned, test.want)
+		}
+
+		if !test.assembled {
+			continue
+		}
+
+		got, err := _engine.GetStep("", "echo")
+		if err != nil {
+			t.Errorf("%s unable to get finalizer: %v", test.name, err)
+		}
+
+		if got.GetStatus() != constants.StatusSuccess {
+			t.Errorf("%s finalizer status is %s, want %s", test.name, got.GetStatus(), constants.StatusSuccess)
+		}
+	}
+}
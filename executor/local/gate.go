@@ -0,0 +1,174 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package local
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-vela/pkg-executor/executor/event"
+	"github.com/go-vela/types/pipeline"
+)
+
+const (
+	// approvalKey defines the reserved environment variable
+	// marking a container as requiring manual approval.
+	//
+	// A value of "step" requires approval before the step
+	// is planned and a value of "stage" requires approval
+	// before the stage containing the step is planned.
+	approvalKey = "VELA_APPROVAL"
+
+	// approvalStage defines the approval value for a stage.
+	approvalStage = "stage"
+
+	// approvalStep defines the approval value for a step.
+	approvalStep = "step"
+)
+
+// gate represents a manual approval that must be
+// granted before a stage or step is executed.
+type gate struct {
+	mu       sync.Mutex
+	done     chan struct{}
+	decided  bool
+	approved bool
+	user     string
+}
+
+// ApproveGate approves the gate waiting for the stage, or
+// the step by stage and name, with the user provided.
+func (c *client) ApproveGate(stage, name, user string) error {
+	return c.decideGate(stage, name, user, true)
+}
+
+// RejectGate rejects the gate waiting for the stage, or
+// the step by stage and name, with the user provided.
+func (c *client) RejectGate(stage, name, user string) error {
+	return c.decideGate(stage, name, user, false)
+}
+
+// decideGate records the decision for the gate waiting
+// for the stage, or the step by stage and name.
+func (c *client) decideGate(stage, name, user string, approved bool) error {
+	// load the gate from the client
+	g, ok := c.gates.Load(gateKey(stage, name))
+	if !ok {
+		return fmt.Errorf("no approval waiting for %s", gateName(stage, name))
+	}
+
+	return g.(*gate).decide(user, approved)
+}
+
+// decide records the decision for the gate and wakes
+// everything waiting on it.
+func (g *gate) decide(user string, approved bool) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	// check if the gate has already been decided
+	if g.decided {
+		return fmt.Errorf("approval already decided by %s", g.user)
+	}
+
+	g.decided = true
+	g.approved = approved
+	g.user = user
+
+	close(g.done)
+
+	return nil
+}
+
+// waitApproval blocks until the gate for the stage, or the step by
+// stage and name, is approved and returns an error if the gate is
+// rejected, the approval timeout expires or the context is canceled.
+func (c *client) waitApproval(ctx context.Context, stage, name string) error {
+	key := gateKey(stage, name)
+
+	g := &gate{done: make(chan struct{})}
+
+	// track the gate waiting for approval
+	c.gates.Store(key, g)
+	defer c.gates.Delete(key)
+
+	fmt.Fprintln(os.Stdout, "waiting for approval of", gateName(stage, name))
+
+	// publish an event for the gate waiting for approval
+	c.publish(&event.Event{Type: event.GateWaiting, Stage: stage, Name: name})
+
+	// https://pkg.go.dev/time?tab=doc#NewTimer
+	timer := time.NewTimer(c.approval)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		// reject the gate once the approval timeout expires
+		err := g.decide("", false)
+		if err == nil {
+			// publish an event for the rejected gate
+			c.publish(&event.Event{Type: event.GateRejected, Stage: stage, Name: name})
+
+			return fmt.Errorf("approval of %s timed out after %v", gateName(stage, name), c.approval)
+		}
+	case <-g.done:
+	}
+
+	// check if the gate was rejected
+	if !g.approved {
+		// publish an event for the rejected gate
+		c.publish(&event.Event{Type: event.GateRejected, Stage: stage, Name: name, User: g.user})
+
+		return fmt.Errorf("%s rejected by %s", gateName(stage, name), g.user)
+	}
+
+	fmt.Fprintln(os.Stdout, gateName(stage, name), "approved by", g.user)
+
+	// publish an event for the approved gate
+	c.publish(&event.Event{Type: event.GateApproved, Stage: stage, Name: name, User: g.user})
+
+	return nil
+}
+
+// stageGated returns if any step for the stage
+// requires approval before the stage is planned.
+func stageGated(s *pipeline.Stage) bool {
+	// iterate through all steps for the stage
+	for _, _step := range s.Steps {
+		if _step.Environment[approvalKey] == approvalStage {
+			return true
+		}
+	}
+
+	return false
+}
+
+// stepGated returns if the step requires
+// approval before the step is planned.
+func stepGated(ctn *pipeline.Container) bool {
+	return ctn.Environment[approvalKey] == approvalStep
+}
+
+// gateKey returns the key tracking the gate
+// for the stage, or the step by stage and name.
+func gateKey(stage, name string) string {
+	return stage + "/" + name
+}
+
+// gateName returns a readable name for the gate
+// for the stage, or the step by stage and name.
+func gateName(stage, name string) string {
+	// check if the gate is for a stage
+	if len(name) == 0 {
+		return fmt.Sprintf("%s stage", stage)
+	}
+
+	return fmt.Sprintf("%s step", name)
+}
@@ -5,8 +5,17 @@
 package local
 
 import (
+	"context"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/go-vela/pkg-executor/executor/event"
+	"github.com/go-vela/pkg-executor/executor/limit"
+	"github.com/go-vela/pkg-executor/executor/lock"
+	"github.com/go-vela/pkg-executor/executor/mirror"
+	"github.com/go-vela/pkg-executor/executor/policy"
+	"github.com/go-vela/pkg-executor/internal/stage"
 	"github.com/go-vela/pkg-runtime/runtime"
 	"github.com/go-vela/sdk-go/vela"
 	"github.com/go-vela/types/library"
@@ -23,13 +32,57 @@ type (
 
 		// private fields
 		init     *pipeline.Container
+		done     chan struct{}
+		doneMu   sync.Mutex
+		events   *event.Bus
+		tails    sync.Map
+		phase    atomic.Value
+		mu       sync.Mutex
+		pause    *pause
+		pauseCh  chan struct{}
+		pauseMu  sync.Mutex
 		build    *library.Build
+		number   int
 		pipeline *pipeline.Build
 		repo     *library.Repo
+		canceled sync.Map
+		gates    sync.Map
+		held     sync.Map
+		running  sync.Map
 		services sync.Map
+		stages   sync.Map
+		queued   sync.Map
+		slots    sync.Map
 		steps    sync.Map
+		streams  sync.WaitGroup
 		user     *library.User
 		err      error
+
+		stopSignal  string
+		stopTimeout time.Duration
+		approval    time.Duration
+		expired     int32
+		assembled   int32
+		finalizer   time.Duration
+		finally     sync.Once
+		readiness   time.Duration
+		probeImage  string
+		failure     string
+		stageBase   string
+		maxStages   int
+		maxPulls    int
+		pullBackoff time.Duration
+		pullRetries int
+		pullTimeout time.Duration
+		mirrors     mirror.Rules
+		policy      *policy.Policy
+		containers  *limit.Semaphore
+		locks       *lock.Registry
+		monitors    sync.Map
+		detached    sync.Map
+		streamers   sync.Map
+		crash       error
+		crashed     context.CancelFunc
 	}
 )
 
@@ -40,6 +93,23 @@ func New(opts ...Opt) (*client, error) {
 	// create new local client
 	c := new(client)
 
+	// instantiate the bus for build events
+	c.events = new(event.Bus)
+
+	// set the default configuration for stopping containers
+	c.stopSignal = defaultStopSignal
+	c.stopTimeout = defaultStopTimeout
+	c.approval = defaultApprovalTimeout
+	c.finalizer = defaultFinalizerTimeout
+	c.readiness = defaultReadinessTimeout
+	c.probeImage = defaultProbeImage
+	c.maxPulls = defaultMaxPulls
+	c.pullBackoff = defaultPullBackoff
+	c.pullRetries = defaultPullRetries
+	c.pullTimeout = defaultPullTimeout
+	c.failure = stage.FailFast
+	c.locks = lock.NewRegistry()
+
 	// apply all provided configuration options
 	for _, opt := range opts {
 		err := opt(c)
@@ -5,6 +5,7 @@
 package local
 
 import (
+	"fmt"
 	"net/http/httptest"
 	"testing"
 
@@ -218,3 +219,51 @@ func testSteps() *pipeline.Build {
 		},
 	}
 }
+
+// testStages returns a stages pipeline with the number of
+// stages provided, each running the number of steps provided.
+func testStages(stages, steps int) *pipeline.Build {
+	p := &pipeline.Build{
+		Version: "1",
+		ID:      "github_octocat_1",
+		Stages: pipeline.StageSlice{
+			{
+				Name: "init",
+				Steps: pipeline.ContainerSlice{
+					{
+						ID:          "github_octocat_1_init_init",
+						Directory:   "/home/github/octocat",
+						Environment: map[string]string{"FOO": "bar"},
+						Image:       "#init",
+						Name:        "init",
+						Number:      1,
+						Pull:        "always",
+					},
+				},
+			},
+		},
+	}
+
+	// create the stages for the pipeline
+	for i := 0; i < stages; i++ {
+		s := &pipeline.Stage{Name: fmt.Sprintf("stage%d", i)}
+
+		// create the steps for the stage
+		for j := 0; j < steps; j++ {
+			s.Steps = append(s.Steps, &pipeline.Container{
+				ID:          fmt.Sprintf("github_octocat_1_%s_echo%d", s.Name, j),
+				Commands:    []string{"echo hello"},
+				Directory:   "/home/github/octocat",
+				Environment: map[string]string{"FOO": "bar", "VELA_STEP_STAGE": s.Name},
+				Image:       "alpine:latest",
+				Name:        fmt.Sprintf("echo%d", j),
+				Number:      2 + i*steps + j,
+				Pull:        "always",
+			})
+		}
+
+		p.Stages = append(p.Stages, s)
+	}
+
+	return p
+}
@@ -0,0 +1,235 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package local
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/go-vela/types/pipeline"
+)
+
+const (
+	// lockKey defines the reserved environment variable naming
+	// the lock group for a container, so only one member of
+	// the lock group runs at a time.
+	lockKey = "VELA_LOCK"
+
+	// stageLockKey defines the reserved environment variable,
+	// set on any step for a stage, naming the lock group for
+	// the stage containing the step.
+	stageLockKey = "VELA_STAGE_LOCK"
+)
+
+// lockStage blocks until the lock group for the stage, if any,
+// is free and returns the function releasing the lock group.
+//
+// The wait is recorded in the log for the first step of the stage.
+func (c *client) lockStage(ctx context.Context, s *pipeline.Stage) (func(), error) {
+	group := stageLock(s)
+
+	// check if the stage is in a lock group
+	if len(group) == 0 {
+		return func() {}, nil
+	}
+
+	return c.lockGroup(ctx, group, func(line string) { c.noteStep(s.Steps[0], line) })
+}
+
+// lockContainer blocks until the lock group for the container, if
+// any, is free and records the wait with the function provided.
+//
+// The lock group is held until the container is unlocked.
+func (c *client) lockContainer(ctx context.Context, ctn *pipeline.Container, note func(*pipeline.Container, string)) error {
+	group := c.containerLock(ctn)
+
+	// check if the container is in a lock group
+	if len(group) == 0 {
+		return nil
+	}
+
+	unlock, err := c.lockGroup(ctx, group, func(line string) { note(ctn, line) })
+	if err != nil {
+		return err
+	}
+
+	// track the lock group held by the container
+	c.held.Store(ctn.ID, unlock)
+
+	return nil
+}
+
+// unlockContainer releases the lock group
+// held by the container, if any.
+func (c *client) unlockContainer(ctn *pipeline.Container) {
+	// check if the container holds a lock group
+	unlock, ok := c.held.LoadAndDelete(ctn.ID)
+	if ok {
+		unlock.(func())()
+	}
+}
+
+// containerLock returns the lock group for the container
+// unless the stage for the container already holds it.
+func (c *client) containerLock(ctn *pipeline.Container) string {
+	group := ctn.Environment[lockKey]
+
+	// check if the container is a step for a stage
+	name, ok := ctn.Environment["VELA_STEP_STAGE"]
+	if len(group) == 0 || !ok || c.pipeline == nil {
+		return group
+	}
+
+	// iterate through all stages in the pipeline
+	for _, s := range c.pipeline.Stages {
+		// check if the stage already holds the lock group
+		if s.Name == name && stageLock(s) == group {
+			return ""
+		}
+	}
+
+	return group
+}
+
+// lockGroup blocks until the lock group is free and returns the
+// function releasing it or an error once the context provided
+// is canceled. The time spent waiting is recorded with the
+// function provided.
+func (c *client) lockGroup(ctx context.Context, group string, note func(string)) (func(), error) {
+	start := time.Now()
+
+	// check if the lock group is free without waiting
+	//
+	// https://pkg.go.dev/github.com/go-vela/pkg-executor/executor/lock#Registry.TryLock
+	unlock, ok := c.locks.TryLock(group)
+	if !ok {
+		note(fmt.Sprintf("> Waiting for lock group %s...\n", group))
+
+		var err error
+
+		// https://pkg.go.dev/github.com/go-vela/pkg-executor/executor/lock#Registry.Lock
+		unlock, err = c.locks.Lock(ctx, group)
+		if err != nil {
+			return nil, fmt.Errorf("unable to lock group %s: %w", group, err)
+		}
+	}
+
+	note(fmt.Sprintf("> Locked group %s after waiting %v\n", group, time.Since(start).Round(time.Millisecond)))
+
+	return unlock, nil
+}
+
+// validateLocks returns an error if the lock group held by a
+// service or detached step, which is only released once the
+// build is destroyed, is shared with any other container or
+// stage in the pipeline, since they would wait for it forever.
+func validateLocks(p *pipeline.Build) error {
+	type holder struct {
+		group string
+		name  string
+	}
+
+	members := map[string]int{}
+	holders := []holder{}
+
+	// count the member for the lock group provided, if any
+	count := func(group, name string, held bool) {
+		// check if the container is in a lock group
+		if len(group) == 0 {
+			return
+		}
+
+		members[group]++
+
+		// check if the lock group is held until the build is destroyed
+		if held {
+			holders = append(holders, holder{group: group, name: name})
+		}
+	}
+
+	// iterate through all services in the pipeline
+	for _, ctn := range p.Services {
+		count(ctn.Environment[lockKey], ctn.Name, true)
+	}
+
+	// iterate through all steps in the pipeline
+	for _, ctn := range p.Steps {
+		count(ctn.Environment[lockKey], ctn.Name, ctn.Detach)
+	}
+
+	// iterate through all stages in the pipeline
+	for _, s := range p.Stages {
+		group := stageLock(s)
+
+		count(group, s.Name, false)
+
+		// iterate through all steps for the stage
+		for _, ctn := range s.Steps {
+			// skip over steps in the lock group held by the stage
+			if ctn.Environment[lockKey] == group {
+				continue
+			}
+
+			count(ctn.Environment[lockKey], ctn.Name, ctn.Detach)
+		}
+	}
+
+	// iterate through all lock groups held until the build is destroyed
+	for _, h := range holders {
+		// check if the lock group is shared
+		if members[h.group] > 1 {
+			return fmt.Errorf("lock group %s is held by %s until the build is destroyed and can not be shared", h.group, h.name)
+		}
+	}
+
+	return nil
+}
+
+// stageLock returns the lock group for the stage, if any.
+func stageLock(s *pipeline.Stage) string {
+	// iterate through all steps for the stage
+	for _, _step := range s.Steps {
+		// check if the step sets the lock group for the stage
+		if len(_step.Environment[stageLockKey]) > 0 {
+			return _step.Environment[stageLockKey]
+		}
+	}
+
+	return ""
+}
+
+// noteStep records the line provided in the log for the step.
+func (c *client) noteStep(ctn *pipeline.Container, line string) {
+	// create a step pattern for log output
+	_pattern := fmt.Sprintf(stepPattern, ctn.Name)
+
+	// check if the container provided is for stages
+	_stage, ok := ctn.Environment["VELA_STEP_STAGE"]
+	if ok && len(_stage) > 0 {
+		// create a stage pattern for log output
+		_pattern = fmt.Sprintf(stagePattern, _stage, ctn.Name)
+	}
+
+	// ensure we output to stdout
+	fmt.Fprint(os.Stdout, _pattern, " ", line)
+
+	c.note(ctn, line)
+}
+
+// noteService records the line provided in the log for the service.
+func (c *client) noteService(ctn *pipeline.Container, line string) {
+	// ensure we output to stdout
+	fmt.Fprint(os.Stdout, fmt.Sprintf(servicePattern, ctn.Name), " ", line)
+
+	c.note(ctn, line)
+}
+
+// note records the line provided in the buffered
+// log for the container, which publishes it as a log event.
+func (c *client) note(ctn *pipeline.Container, line string) {
+	_, _ = c.tail(ctn).Write([]byte(line))
+}
@@ -0,0 +1,239 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package local
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/go-vela/pkg-executor/executor/event"
+	"github.com/go-vela/pkg-executor/internal/service"
+	"github.com/go-vela/types/constants"
+	"github.com/go-vela/types/library"
+	"github.com/go-vela/types/pipeline"
+)
+
+// maxRestarts defines the number of times a service with
+// the restart crash policy is restarted during a build.
+const maxRestarts = 3
+
+// monitor represents the watch on a running service.
+type monitor struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// monitorService watches the container for the service in the
+// background and applies the crash policy for the service
+// once it exits before the service is destroyed.
+func (c *client) monitorService(ctn *pipeline.Container, s *library.Service) {
+	// create a context with a background context so the
+	// service is still watched after a build is canceled
+	//
+	// https://pkg.go.dev/context?tab=doc#WithCancel
+	ctx, cancel := context.WithCancel(context.Background())
+
+	m := &monitor{cancel: cancel, done: make(chan struct{})}
+
+	// add the monitor to the map
+	c.monitors.Store(ctn.ID, m)
+
+	go func() {
+		defer close(m.done)
+
+		c.watchService(ctx, ctn, s)
+	}()
+}
+
+// unmonitorService stops watching the container for the service
+// and waits for the monitor to exit, so the container is not
+// reported as crashed while the service is destroyed.
+func (c *client) unmonitorService(ctn *pipeline.Container) {
+	// remove the monitor from the map
+	m, ok := c.monitors.LoadAndDelete(ctn.ID)
+	if !ok {
+		return
+	}
+
+	m.(*monitor).cancel()
+
+	<-m.(*monitor).done
+}
+
+// watchService waits for the container for the service to exit
+// and applies the crash policy for the service when it exits
+// while the service is still expected to be running.
+func (c *client) watchService(ctx context.Context, ctn *pipeline.Container, s *library.Service) {
+	// capture the crash policy for the service
+	//
+	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/service#CrashPolicy
+	policy, err := service.CrashPolicy(ctn)
+	if err != nil {
+		fmt.Fprintln(os.Stdout, "unable to monitor service:", err)
+
+		return
+	}
+
+	for restarts := 0; ; restarts++ {
+		// wait for the runtime container to exit
+		err = c.Runtime.WaitContainer(ctx, ctn)
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err != nil {
+			fmt.Fprintln(os.Stdout, "unable to wait for service:", err)
+
+			return
+		}
+
+		// check if the service is no longer expected to run
+		if !c.serviceRunning(s) {
+			return
+		}
+
+		// inspect the runtime container
+		err = c.Runtime.InspectContainer(ctx, ctn)
+		if err != nil {
+			fmt.Fprintln(os.Stdout, "unable to inspect service:", err)
+		}
+
+		// publish an event for the crashed service
+		c.publish(&event.Event{Type: event.ServiceCrashed, Name: ctn.Name, ExitCode: ctn.ExitCode})
+
+		c.noteService(ctn, fmt.Sprintf("> Service exited unexpectedly with exit code %d\n", ctn.ExitCode))
+
+		// record the crash in the log for every running step
+		for _, _step := range c.runningSteps() {
+			c.noteStep(_step, fmt.Sprintf("> Service %s exited unexpectedly with exit code %d\n", ctn.Name, ctn.ExitCode))
+		}
+
+		// check if the service should be restarted
+		if policy == service.CrashRestart && restarts < maxRestarts {
+			err = c.restartService(ctx, ctn, restarts+1)
+			if err == nil {
+				continue
+			}
+
+			// check if the service is being destroyed
+			if ctx.Err() != nil {
+				return
+			}
+
+			fmt.Fprintln(os.Stdout, "unable to restart service:", err)
+		}
+
+		err = fmt.Errorf("%s service exited unexpectedly with exit code %d", ctn.Name, ctn.ExitCode)
+
+		// update the service with a failure state
+		c.locked(func() {
+			s.SetStatus(constants.StatusFailure)
+			s.SetExitCode(ctn.ExitCode)
+			s.SetError(err.Error())
+			s.SetFinished(time.Now().UTC().Unix())
+		})
+
+		// check if the crashed service fails the build
+		if policy == service.CrashFail {
+			c.failBuild(err)
+		}
+
+		return
+	}
+}
+
+// restartService removes the exited container for the
+// service and runs it again in its place.
+func (c *client) restartService(ctx context.Context, ctn *pipeline.Container, attempt int) error {
+	c.noteService(ctn, fmt.Sprintf("> Restarting service (%d of %d)...\n", attempt, maxRestarts))
+
+	// remove the exited runtime container
+	err := c.Runtime.RemoveContainer(ctx, ctn)
+	if err != nil {
+		return err
+	}
+
+	// run the runtime container without the
+	// environment reserved for the executor
+	err = c.Runtime.RunContainer(ctx, runtimeContainer(ctn), c.pipeline)
+	if err != nil {
+		return err
+	}
+
+	// publish an event for the restarted service
+	c.publish(&event.Event{Type: event.ServiceStarted, Name: ctn.Name})
+
+	// stream logs from the restarted container
+	c.stream(ctn, c.StreamService)
+
+	return nil
+}
+
+// serviceRunning returns true if the service
+// has not reached a terminal status.
+func (c *client) serviceRunning(s *library.Service) bool {
+	var running bool
+
+	c.locked(func() { running = s.GetStatus() == constants.StatusRunning })
+
+	return running
+}
+
+// runningSteps returns the containers for
+// every step that is currently running.
+func (c *client) runningSteps() []*pipeline.Container {
+	steps := []*pipeline.Container{}
+
+	// capture the steps from every stage in the pipeline
+	containers := append(pipeline.ContainerSlice{}, c.pipeline.Steps...)
+	for _, _stage := range c.pipeline.Stages {
+		containers = append(containers, _stage.Steps...)
+	}
+
+	// iterate through all steps in the pipeline
+	for _, _step := range containers {
+		// check if the step is currently running
+		if _, ok := c.running.Load(_step.ID); ok {
+			steps = append(steps, _step)
+		}
+	}
+
+	return steps
+}
+
+// failBuild fails the build in execution with the error
+// provided from a service that exited unexpectedly.
+func (c *client) failBuild(err error) {
+	var cancel context.CancelFunc
+
+	c.locked(func() {
+		// check if the build was already failed by a service
+		if c.crash == nil {
+			c.crash = err
+		}
+
+		// set build status to failure
+		c.build.SetStatus(constants.StatusFailure)
+
+		cancel = c.crashed
+	})
+
+	// check if the build is in execution
+	if cancel != nil {
+		cancel()
+	}
+}
+
+// crashError returns the error from a service
+// that failed the build, if any.
+func (c *client) crashError() error {
+	var err error
+
+	c.locked(func() { err = c.crash })
+
+	return err
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package local
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-vela/types/constants"
+)
+
+func TestLocal_runningSteps(t *testing.T) {
+	// setup types
+	_pipeline := testSteps()
+
+	_engine, err := New(
+		WithBuild(testBuild()),
+		WithPipeline(_pipeline),
+	)
+	if err != nil {
+		t.Errorf("unable to create executor engine: %v", err)
+	}
+
+	// mark the echo step as running
+	_engine.running.Store(_pipeline.Steps[2].ID, context.CancelFunc(func() {}))
+
+	// run test
+	got := _engine.runningSteps()
+
+	if len(got) != 1 {
+		t.Errorf("runningSteps is %d steps, want 1", len(got))
+
+		return
+	}
+
+	if got[0].Name != "echo" {
+		t.Errorf("runningSteps is %s, want echo", got[0].Name)
+	}
+}
+
+func TestLocal_failBuild(t *testing.T) {
+	// setup types
+	first := errors.New("postgres service exited unexpectedly with exit code 137")
+	second := errors.New("redis service exited unexpectedly with exit code 1")
+
+	_engine, err := New(
+		WithBuild(testBuild()),
+		WithPipeline(testSteps()),
+	)
+	if err != nil {
+		t.Errorf("unable to create executor engine: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_engine.crashed = cancel
+
+	// run test
+	_engine.failBuild(first)
+	_engine.failBuild(second)
+
+	if ctx.Err() == nil {
+		t.Errorf("failBuild did not cancel the build")
+	}
+
+	if !errors.Is(_engine.crashError(), first) {
+		t.Errorf("crashError is %v, want %v", _engine.crashError(), first)
+	}
+
+	if _engine.build.GetStatus() != constants.StatusFailure {
+		t.Errorf("build status is %s, want %s", _engine.build.GetStatus(), constants.StatusFailure)
+	}
+}
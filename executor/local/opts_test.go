@@ -8,11 +8,17 @@ import (
 	"net/http/httptest"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/go-vela/mock/server"
 
+	"github.com/go-vela/pkg-executor/executor/limit"
+	"github.com/go-vela/pkg-executor/executor/lock"
+	"github.com/go-vela/pkg-executor/executor/mirror"
+	"github.com/go-vela/pkg-executor/executor/policy"
+
 	"github.com/go-vela/pkg-runtime/runtime"
 	"github.com/go-vela/pkg-runtime/runtime/docker"
 
@@ -22,6 +28,53 @@ import (
 	"github.com/go-vela/types/pipeline"
 )
 
+func TestLocal_Opt_WithApprovalTimeout(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		failure bool
+		timeout time.Duration
+		want    time.Duration
+	}{
+		{
+			failure: false,
+			timeout: 5 * time.Minute,
+			want:    5 * time.Minute,
+		},
+		{
+			failure: false,
+			timeout: 0,
+			want:    60 * time.Minute,
+		},
+		{
+			failure: true,
+			timeout: -1 * time.Minute,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		_engine, err := New(
+			WithApprovalTimeout(test.timeout),
+		)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("WithApprovalTimeout should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("WithApprovalTimeout returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(_engine.approval, test.want) {
+			t.Errorf("WithApprovalTimeout is %v, want %v", _engine.approval, test.want)
+		}
+	}
+}
+
 func TestLocal_Opt_WithBuild(t *testing.T) {
 	// setup types
 	_build := testBuild()
@@ -51,6 +104,87 @@ func TestLocal_Opt_WithBuild(t *testing.T) {
 	}
 }
 
+func TestLocal_Opt_WithContainerLimit(t *testing.T) {
+	// setup types
+	_containers := limit.New(2)
+
+	// setup tests
+	tests := []struct {
+		limit *limit.Semaphore
+		want  *limit.Semaphore
+	}{
+		{
+			limit: _containers,
+			want:  _containers,
+		},
+		{
+			limit: nil,
+			want:  limit.New(0),
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		_engine, err := New(
+			WithContainerLimit(test.limit),
+		)
+		if err != nil {
+			t.Errorf("unable to create local engine: %v", err)
+		}
+
+		if !reflect.DeepEqual(_engine.containers, test.want) {
+			t.Errorf("WithContainerLimit is %v, want %v", _engine.containers, test.want)
+		}
+	}
+}
+
+func TestLocal_Opt_WithFinalizerTimeout(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		failure bool
+		timeout time.Duration
+		want    time.Duration
+	}{
+		{
+			failure: false,
+			timeout: time.Minute,
+			want:    time.Minute,
+		},
+		{
+			failure: false,
+			timeout: 0,
+			want:    5 * time.Minute,
+		},
+		{
+			failure: true,
+			timeout: -1 * time.Minute,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		_engine, err := New(
+			WithFinalizerTimeout(test.timeout),
+		)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("WithFinalizerTimeout should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("WithFinalizerTimeout returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(_engine.finalizer, test.want) {
+			t.Errorf("WithFinalizerTimeout is %v, want %v", _engine.finalizer, test.want)
+		}
+	}
+}
+
 func TestLocal_Opt_WithHostname(t *testing.T) {
 	// setup tests
 	tests := []struct {
@@ -82,6 +216,194 @@ func TestLocal_Opt_WithHostname(t *testing.T) {
 	}
 }
 
+func TestLocal_Opt_WithLockRegistry(t *testing.T) {
+	// setup types
+	_locks := lock.NewRegistry()
+
+	// setup tests
+	tests := []struct {
+		registry *lock.Registry
+		shared   bool
+	}{
+		{
+			registry: _locks,
+			shared:   true,
+		},
+		{
+			registry: nil,
+			shared:   false,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		_engine, err := New(
+			WithLockRegistry(test.registry),
+		)
+		if err != nil {
+			t.Errorf("unable to create local engine: %v", err)
+		}
+
+		if _engine.locks == nil {
+			t.Errorf("WithLockRegistry is nil, want a registry")
+		}
+
+		if (_engine.locks == _locks) != test.shared {
+			t.Errorf("WithLockRegistry shared is %v, want %v", _engine.locks == _locks, test.shared)
+		}
+	}
+}
+
+func TestLocal_Opt_WithMaxPulls(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		failure bool
+		max     int
+		want    int
+	}{
+		{
+			failure: false,
+			max:     5,
+			want:    5,
+		},
+		{
+			failure: false,
+			max:     0,
+			want:    4,
+		},
+		{
+			failure: true,
+			max:     -1,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		_engine, err := New(
+			WithMaxPulls(test.max),
+		)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("WithMaxPulls should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("WithMaxPulls returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(_engine.maxPulls, test.want) {
+			t.Errorf("WithMaxPulls is %v, want %v", _engine.maxPulls, test.want)
+		}
+	}
+}
+
+func TestLocal_Opt_WithMaxStages(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		failure bool
+		max     int
+		want    int
+	}{
+		{
+			failure: false,
+			max:     5,
+			want:    5,
+		},
+		{
+			failure: false,
+			max:     0,
+			want:    0,
+		},
+		{
+			failure: true,
+			max:     -1,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		_engine, err := New(
+			WithMaxStages(test.max),
+		)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("WithMaxStages should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("WithMaxStages returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(_engine.maxStages, test.want) {
+			t.Errorf("WithMaxStages is %v, want %v", _engine.maxStages, test.want)
+		}
+	}
+}
+
+func TestLocal_Opt_WithMirrors(t *testing.T) {
+	// setup types
+	_mirrors := mirror.Rules{
+		{Prefix: "docker.io/", Replacement: "mirror.example.com/docker/", Fallback: true},
+	}
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		mirrors mirror.Rules
+		want    mirror.Rules
+	}{
+		{
+			failure: false,
+			mirrors: _mirrors,
+			want:    _mirrors,
+		},
+		{
+			failure: false,
+			mirrors: nil,
+			want:    nil,
+		},
+		{
+			failure: true,
+			mirrors: mirror.Rules{{Prefix: "docker.io/"}},
+		},
+		{
+			failure: true,
+			mirrors: mirror.Rules{nil},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		_engine, err := New(
+			WithMirrors(test.mirrors),
+		)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("WithMirrors should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("WithMirrors returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(_engine.mirrors, test.want) {
+			t.Errorf("WithMirrors is %v, want %v", _engine.mirrors, test.want)
+		}
+	}
+}
+
 func TestLocal_Opt_WithPipeline(t *testing.T) {
 	// setup types
 	_steps := testSteps()
@@ -125,6 +447,275 @@ func TestLocal_Opt_WithPipeline(t *testing.T) {
 	}
 }
 
+func TestLocal_Opt_WithPolicy(t *testing.T) {
+	// setup types
+	_policy := &policy.Policy{Privileged: policy.PrivilegedTrusted, MaxSteps: 10}
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		policy  *policy.Policy
+		want    *policy.Policy
+	}{
+		{
+			failure: false,
+			policy:  _policy,
+			want:    _policy,
+		},
+		{
+			failure: false,
+			policy:  nil,
+			want:    nil,
+		},
+		{
+			failure: true,
+			policy:  &policy.Policy{Privileged: "sometimes"},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		_engine, err := New(
+			WithPolicy(test.policy),
+		)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("WithPolicy should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("WithPolicy returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(_engine.policy, test.want) {
+			t.Errorf("WithPolicy is %v, want %v", _engine.policy, test.want)
+		}
+	}
+}
+
+func TestLocal_Opt_WithProbeImage(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		image string
+		want  string
+	}{
+		{
+			image: "busybox:latest",
+			want:  "busybox:latest",
+		},
+		{
+			image: "",
+			want:  "alpine:latest",
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		_engine, err := New(
+			WithProbeImage(test.image),
+		)
+		if err != nil {
+			t.Errorf("WithProbeImage returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(_engine.probeImage, test.want) {
+			t.Errorf("WithProbeImage is %v, want %v", _engine.probeImage, test.want)
+		}
+	}
+}
+
+func TestLocal_Opt_WithPullBackoff(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		failure bool
+		backoff time.Duration
+		want    time.Duration
+	}{
+		{
+			failure: false,
+			backoff: time.Second,
+			want:    time.Second,
+		},
+		{
+			failure: false,
+			backoff: 0,
+			want:    5 * time.Second,
+		},
+		{
+			failure: true,
+			backoff: -1 * time.Second,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		_engine, err := New(
+			WithPullBackoff(test.backoff),
+		)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("WithPullBackoff should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("WithPullBackoff returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(_engine.pullBackoff, test.want) {
+			t.Errorf("WithPullBackoff is %v, want %v", _engine.pullBackoff, test.want)
+		}
+	}
+}
+
+func TestLocal_Opt_WithPullRetries(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		failure bool
+		retries int
+		want    int
+	}{
+		{
+			failure: false,
+			retries: 5,
+			want:    5,
+		},
+		{
+			failure: false,
+			retries: 0,
+			want:    0,
+		},
+		{
+			failure: true,
+			retries: -1,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		_engine, err := New(
+			WithPullRetries(test.retries),
+		)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("WithPullRetries should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("WithPullRetries returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(_engine.pullRetries, test.want) {
+			t.Errorf("WithPullRetries is %v, want %v", _engine.pullRetries, test.want)
+		}
+	}
+}
+
+func TestLocal_Opt_WithPullTimeout(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		failure bool
+		timeout time.Duration
+		want    time.Duration
+	}{
+		{
+			failure: false,
+			timeout: time.Minute,
+			want:    time.Minute,
+		},
+		{
+			failure: false,
+			timeout: 0,
+			want:    10 * time.Minute,
+		},
+		{
+			failure: true,
+			timeout: -1 * time.Minute,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		_engine, err := New(
+			WithPullTimeout(test.timeout),
+		)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("WithPullTimeout should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("WithPullTimeout returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(_engine.pullTimeout, test.want) {
+			t.Errorf("WithPullTimeout is %v, want %v", _engine.pullTimeout, test.want)
+		}
+	}
+}
+
+func TestLocal_Opt_WithReadinessTimeout(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		failure bool
+		timeout time.Duration
+		want    time.Duration
+	}{
+		{
+			failure: false,
+			timeout: time.Minute,
+			want:    time.Minute,
+		},
+		{
+			failure: false,
+			timeout: 0,
+			want:    2 * time.Minute,
+		},
+		{
+			failure: true,
+			timeout: -1 * time.Minute,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		_engine, err := New(
+			WithReadinessTimeout(test.timeout),
+		)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("WithReadinessTimeout should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("WithReadinessTimeout returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(_engine.readiness, test.want) {
+			t.Errorf("WithReadinessTimeout is %v, want %v", _engine.readiness, test.want)
+		}
+	}
+}
+
 func TestLocal_Opt_WithRepo(t *testing.T) {
 	// setup types
 	_repo := testRepo()
@@ -200,6 +791,131 @@ func TestLocal_Opt_WithRuntime(t *testing.T) {
 	}
 }
 
+func TestLocal_Opt_WithStageFailure(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		failure bool
+		policy  string
+		want    string
+	}{
+		{
+			failure: false,
+			policy:  "continue-independent",
+			want:    "continue-independent",
+		},
+		{
+			failure: false,
+			policy:  "",
+			want:    "fail-fast",
+		},
+		{
+			failure: true,
+			policy:  "foo",
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		_engine, err := New(
+			WithStageFailure(test.policy),
+		)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("WithStageFailure should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("WithStageFailure returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(_engine.failure, test.want) {
+			t.Errorf("WithStageFailure is %v, want %v", _engine.failure, test.want)
+		}
+	}
+}
+
+func TestLocal_Opt_WithStopSignal(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		signal string
+		want   string
+	}{
+		{
+			signal: "SIGINT",
+			want:   "SIGINT",
+		},
+		{
+			signal: "",
+			want:   "SIGTERM",
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		_engine, err := New(
+			WithStopSignal(test.signal),
+		)
+		if err != nil {
+			t.Errorf("unable to create local engine: %v", err)
+		}
+
+		if !reflect.DeepEqual(_engine.stopSignal, test.want) {
+			t.Errorf("WithStopSignal is %v, want %v", _engine.stopSignal, test.want)
+		}
+	}
+}
+
+func TestLocal_Opt_WithStopTimeout(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		failure bool
+		timeout time.Duration
+		want    time.Duration
+	}{
+		{
+			failure: false,
+			timeout: 30 * time.Second,
+			want:    30 * time.Second,
+		},
+		{
+			failure: false,
+			timeout: 0,
+			want:    10 * time.Second,
+		},
+		{
+			failure: true,
+			timeout: -1 * time.Second,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		_engine, err := New(
+			WithStopTimeout(test.timeout),
+		)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("WithStopTimeout should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("WithStopTimeout returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(_engine.stopTimeout, test.want) {
+			t.Errorf("WithStopTimeout is %v, want %v", _engine.stopTimeout, test.want)
+		}
+	}
+}
+
 func TestLocal_Opt_WithUser(t *testing.T) {
 	// setup types
 	_user := testUser()
@@ -0,0 +1,310 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package local
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-vela/pkg-executor/executor/event"
+	"github.com/go-vela/pkg-executor/internal/service"
+	"github.com/go-vela/pkg-executor/internal/step"
+	"github.com/go-vela/types/constants"
+	"github.com/go-vela/types/pipeline"
+)
+
+// freezer represents a runtime capable of freezing
+// and thawing the processes running in a container.
+//
+// The runtime.Engine does not support freezing, so a
+// build can only be paused with frozen containers when
+// the runtime implements this interface.
+type freezer interface {
+	PauseContainer(context.Context, *pipeline.Container) error
+	UnpauseContainer(context.Context, *pipeline.Container) error
+}
+
+// pause represents the state of a paused build.
+type pause struct {
+	user   string
+	frozen []*pipeline.Container
+}
+
+// GetPause gets the user that paused the build in execution.
+func (c *client) GetPause() (string, error) {
+	c.pauseMu.Lock()
+	defer c.pauseMu.Unlock()
+
+	// check if the build is paused
+	if c.pause == nil {
+		return "", fmt.Errorf("build is not paused")
+	}
+
+	return c.pause.user, nil
+}
+
+// PauseBuild stops scheduling new steps and stages for the
+// build in execution and, when freeze is provided, freezes
+// the containers that are currently running.
+func (c *client) PauseBuild(user string, freeze bool) error {
+	c.pauseMu.Lock()
+	defer c.pauseMu.Unlock()
+
+	// check if the build is already paused
+	if c.pause != nil {
+		return fmt.Errorf("build already paused by %s", c.pause.user)
+	}
+
+	p := &pause{user: user}
+
+	// check if the running containers should be frozen
+	if freeze {
+		// check if the runtime is able to freeze containers
+		f, ok := c.Runtime.(freezer)
+		if !ok {
+			return fmt.Errorf("unable to freeze containers: unsupported by runtime")
+		}
+
+		// iterate through all running containers for the build
+		for _, ctn := range c.runningContainers() {
+			// freeze the runtime container
+			err := f.PauseContainer(context.Background(), ctn)
+			if err != nil {
+				// thaw the containers that were already frozen
+				_ = c.thaw(p)
+
+				return fmt.Errorf("unable to freeze %s container: %w", ctn.Name, err)
+			}
+
+			p.frozen = append(p.frozen, ctn)
+		}
+	}
+
+	c.pause = p
+
+	// notify everything waiting on the paused state
+	c.pauseChanged()
+
+	fmt.Fprintln(os.Stdout, "build paused by", user)
+
+	// publish an event for the paused build
+	c.publish(&event.Event{Type: event.BuildPaused, User: user})
+
+	return nil
+}
+
+// ResumeBuild thaws the containers frozen for the build in
+// execution and continues scheduling new steps and stages.
+func (c *client) ResumeBuild(user string) error {
+	c.pauseMu.Lock()
+	defer c.pauseMu.Unlock()
+
+	// check if the build is paused
+	if c.pause == nil {
+		return fmt.Errorf("build is not paused")
+	}
+
+	// thaw the containers frozen for the build
+	err := c.thaw(c.pause)
+
+	c.pause = nil
+
+	// notify everything waiting on the paused state
+	c.pauseChanged()
+
+	fmt.Fprintln(os.Stdout, "build resumed by", user)
+
+	// publish an event for the resumed build
+	c.publish(&event.Event{Type: event.BuildResumed, User: user})
+
+	return err
+}
+
+// thaw unfreezes all containers frozen for the paused build
+// and returns the first error that was encountered.
+func (c *client) thaw(p *pause) error {
+	var result error
+
+	// check if the runtime is able to thaw containers
+	f, ok := c.Runtime.(freezer)
+	if !ok {
+		return nil
+	}
+
+	// iterate through all frozen containers for the build
+	for _, ctn := range p.frozen {
+		// thaw the runtime container
+		err := f.UnpauseContainer(context.Background(), ctn)
+		if err != nil && result == nil {
+			result = fmt.Errorf("unable to thaw %s container: %w", ctn.Name, err)
+		}
+	}
+
+	return result
+}
+
+// paused returns if the build is paused along with a channel
+// that is closed the next time the paused state changes.
+func (c *client) paused() (bool, <-chan struct{}) {
+	c.pauseMu.Lock()
+	defer c.pauseMu.Unlock()
+
+	// check if the channel has been initialized
+	if c.pauseCh == nil {
+		c.pauseCh = make(chan struct{})
+	}
+
+	return c.pause != nil, c.pauseCh
+}
+
+// pauseChanged closes the channel for the paused state
+// to wake everything waiting on it.
+//
+// The caller must hold the lock for the paused state.
+func (c *client) pauseChanged() {
+	// check if the channel has been initialized
+	if c.pauseCh != nil {
+		close(c.pauseCh)
+	}
+
+	c.pauseCh = make(chan struct{})
+}
+
+// waitPaused blocks while the build is paused and returns
+// an error once the context provided has been canceled.
+func (c *client) waitPaused(ctx context.Context) error {
+	for {
+		// check if the context has been canceled
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		paused, changed := c.paused()
+		if !paused {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-changed:
+		}
+	}
+}
+
+// timeLimit creates a context from the one provided that is
+// canceled once the build has run for the duration provided.
+//
+// The time the build spends paused does not count
+// towards the duration provided. A deadline already set
+// on the context provided still counts paused time, so
+// callers rely on this limit rather than their own.
+func (c *client) timeLimit(ctx context.Context, limit time.Duration) (context.Context, context.CancelFunc) {
+	// https://pkg.go.dev/context?tab=doc#WithCancel
+	ctx, cancel := context.WithCancel(ctx)
+
+	// check if a time limit was provided
+	if limit <= 0 {
+		return ctx, cancel
+	}
+
+	go func() {
+		remaining := limit
+
+		for {
+			paused, changed := c.paused()
+
+			// check if the build is paused
+			if paused {
+				select {
+				case <-ctx.Done():
+					return
+				case <-changed:
+					continue
+				}
+			}
+
+			start := time.Now()
+
+			// https://pkg.go.dev/time?tab=doc#NewTimer
+			timer := time.NewTimer(remaining)
+
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+
+				return
+			case <-timer.C:
+				fmt.Fprintln(os.Stdout, "build exceeded time limit of", limit)
+
+				// record the build exceeded its time limit
+				atomic.StoreInt32(&c.expired, 1)
+
+				cancel()
+
+				return
+			case <-changed:
+				timer.Stop()
+
+				remaining -= time.Since(start)
+			}
+		}
+	}()
+
+	return ctx, cancel
+}
+
+// runningContainers captures the services and steps
+// for the build that are currently running.
+func (c *client) runningContainers() []*pipeline.Container {
+	containers := []*pipeline.Container{}
+
+	// check if a pipeline was provided
+	if c.pipeline == nil {
+		return containers
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// iterate through all services in the pipeline
+	for _, _service := range c.pipeline.Services {
+		// load the service from the client
+		//
+		// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/service#Load
+		s, err := service.Load(_service, &c.services)
+		if err == nil && s.GetStatus() == constants.StatusRunning {
+			containers = append(containers, _service)
+		}
+	}
+
+	// copy the steps to avoid modifying the pipeline
+	steps := append([]*pipeline.Container{}, c.pipeline.Steps...)
+
+	// iterate through all stages in the pipeline
+	for _, _stage := range c.pipeline.Stages {
+		steps = append(steps, _stage.Steps...)
+	}
+
+	// iterate through all steps in the pipeline
+	for _, _step := range steps {
+		// TODO: remove hardcoded reference
+		if _step.Name == "init" {
+			continue
+		}
+
+		// load the step from the client
+		//
+		// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/step#Load
+		s, err := step.Load(_step, &c.steps)
+		if err == nil && s.GetStatus() == constants.StatusRunning {
+			containers = append(containers, _step)
+		}
+	}
+
+	return containers
+}
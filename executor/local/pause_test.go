@@ -0,0 +1,183 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package local
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-vela/pkg-runtime/runtime/docker"
+
+	"github.com/go-vela/pkg-executor/executor/event"
+)
+
+func TestLocal_PauseBuild(t *testing.T) {
+	// setup types
+	_runtime, err := docker.NewMock()
+	if err != nil {
+		t.Errorf("unable to create runtime engine: %v", err)
+	}
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		paused  bool
+		freeze  bool
+	}{
+		{ // build not paused
+			failure: false,
+			paused:  false,
+			freeze:  false,
+		},
+		{ // build already paused
+			failure: true,
+			paused:  true,
+			freeze:  false,
+		},
+		{ // runtime unable to freeze containers
+			failure: true,
+			paused:  false,
+			freeze:  true,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		_engine, err := New(
+			WithPipeline(testSteps()),
+			WithRuntime(_runtime),
+		)
+		if err != nil {
+			t.Errorf("unable to create executor engine: %v", err)
+		}
+
+		events, cancel := _engine.Subscribe(1)
+
+		if test.paused {
+			_ = _engine.PauseBuild("octocat", false)
+
+			<-events
+		}
+
+		err = _engine.PauseBuild("octocat", test.freeze)
+
+		cancel()
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("PauseBuild should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("PauseBuild returned err: %v", err)
+		}
+
+		got, err := _engine.GetPause()
+		if err != nil {
+			t.Errorf("GetPause returned err: %v", err)
+		}
+
+		if got != "octocat" {
+			t.Errorf("GetPause is %s, want %s", got, "octocat")
+		}
+
+		e := <-events
+		if e.Type != event.BuildPaused || e.User != "octocat" {
+			t.Errorf("PauseBuild published %v, want %s by %s", e, event.BuildPaused, "octocat")
+		}
+	}
+}
+
+func TestLocal_ResumeBuild(t *testing.T) {
+	// setup types
+	_engine, err := New()
+	if err != nil {
+		t.Errorf("unable to create executor engine: %v", err)
+	}
+
+	// run test
+	err = _engine.ResumeBuild("octocat")
+	if err == nil {
+		t.Errorf("ResumeBuild should have returned err")
+	}
+
+	err = _engine.PauseBuild("octocat", false)
+	if err != nil {
+		t.Errorf("PauseBuild returned err: %v", err)
+	}
+
+	// the paused build should block scheduling
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err = _engine.waitPaused(ctx)
+	if err == nil {
+		t.Errorf("waitPaused should have returned err")
+	}
+
+	done := make(chan error)
+
+	go func() {
+		done <- _engine.waitPaused(context.Background())
+	}()
+
+	err = _engine.ResumeBuild("octocat")
+	if err != nil {
+		t.Errorf("ResumeBuild returned err: %v", err)
+	}
+
+	select {
+	case err = <-done:
+		if err != nil {
+			t.Errorf("waitPaused returned err: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("waitPaused should have returned after ResumeBuild")
+	}
+
+	_, err = _engine.GetPause()
+	if err == nil {
+		t.Errorf("GetPause should have returned err")
+	}
+}
+
+func TestLocal_timeLimit(t *testing.T) {
+	// setup types
+	_engine, err := New()
+	if err != nil {
+		t.Errorf("unable to create executor engine: %v", err)
+	}
+
+	// run test
+	ctx, cancel := _engine.timeLimit(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err = _engine.PauseBuild("octocat", false)
+	if err != nil {
+		t.Errorf("PauseBuild returned err: %v", err)
+	}
+
+	// the time limit should not run while paused
+	select {
+	case <-ctx.Done():
+		t.Errorf("timeLimit should not expire while the build is paused")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	err = _engine.ResumeBuild("octocat")
+	if err != nil {
+		t.Errorf("ResumeBuild returned err: %v", err)
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Errorf("timeLimit should have expired after the build resumed")
+	}
+}
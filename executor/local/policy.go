@@ -0,0 +1,35 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package local
+
+import "fmt"
+
+// enforcePolicy checks the containers for the pipeline, and the
+// readiness probes for its services, against the policy and writes
+// the explanation for every violation with the function provided,
+// returning an error if there were any.
+func (c *client) enforcePolicy(write func(string)) error {
+	// check the pipeline against the policy
+	//
+	// https://pkg.go.dev/github.com/go-vela/pkg-executor/executor/policy#Policy.Check
+	violations := c.policy.Check(c.pipeline, c.repo)
+
+	// check the containers running the readiness
+	// probes for the services against the policy
+	violations = append(violations, c.probeViolations()...)
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	write("> Checking container policy...")
+
+	// iterate through all violations of the policy
+	for _, v := range violations {
+		write(fmt.Sprintf("> Policy violation for %s", v))
+	}
+
+	return fmt.Errorf("pipeline has %d policy violations", len(violations))
+}
@@ -0,0 +1,452 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package local
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/go-vela/pkg-executor/executor/fault"
+	"github.com/go-vela/pkg-executor/executor/limit"
+	"github.com/go-vela/pkg-executor/executor/mirror"
+	"github.com/go-vela/types/constants"
+	"github.com/go-vela/types/pipeline"
+)
+
+// pull represents a container, created along with its
+// image, or a line of progress for the init log.
+type pull struct {
+	line   string
+	kind   string
+	ctn    *pipeline.Container
+	create func(context.Context, *pipeline.Container) error
+
+	op       string
+	original string
+	rule     *mirror.Rule
+	notes    []string
+	image    []byte
+	digest   string
+	created  bool
+	attempts int
+	mirrored int
+	duration time.Duration
+	err      error
+}
+
+// maxPullBackoff defines the longest time waited between
+// attempts to pull an image, however many attempts are made.
+const maxPullBackoff = 5 * time.Minute
+
+// digestPattern matches the digest for an image.
+var digestPattern = regexp.MustCompile(`sha256:[a-f0-9]{64}`)
+
+// pullLine returns the line of progress provided for the init log.
+func pullLine(line string) *pull {
+	return &pull{line: line}
+}
+
+// output returns the line of progress, or the
+// image output for the container, for the init log.
+func (p *pull) output() string {
+	// check if the pull is a line of progress
+	if p.ctn == nil {
+		return p.line
+	}
+
+	return string(p.image)
+}
+
+// summary returns the line recording the digest, duration and
+// attempts, including those made on a mirror before falling
+// back, for the image pulled for the container, or the error
+// for the image that could not be pulled.
+func (p *pull) summary() string {
+	duration := p.duration.Round(time.Millisecond)
+
+	// check if the image could not be pulled
+	if p.err != nil {
+		return fmt.Sprintf("> Unable to pull image %s (duration: %v, attempts: %d): %v", p.ctn.Image, duration, p.mirrored+p.attempts, p.err)
+	}
+
+	digest := p.digest
+
+	// check if the digest for the image is unknown
+	if len(digest) == 0 {
+		digest = "unknown"
+	}
+
+	return fmt.Sprintf("> Pulled image %s (digest: %s, duration: %v, attempts: %d)", p.ctn.Image, digest, duration, p.mirrored+p.attempts)
+}
+
+// skipped returns the line recording the image for the
+// container was not pulled since another container, in
+// the same set of containers, could not be created.
+func (p *pull) skipped() string {
+	return fmt.Sprintf("> Skipped pulling image %s for %s %s since another container could not be created", p.ctn.Image, p.ctn.Name, p.kind)
+}
+
+// stagePulls returns the containers, created along
+// with their images, for the steps of the stage.
+func (c *client) stagePulls(s *pipeline.Stage) []*pull {
+	pulls := []*pull{pullLine(fmt.Sprintf("> Pulling step images for stage %s ...", s.Name))}
+
+	// iterate through all steps for the stage
+	for _, _step := range s.Steps {
+		// update the container environment with stage name
+		_step.Environment["VELA_STEP_STAGE"] = s.Name
+
+		pulls = append(pulls, &pull{kind: "step", ctn: _step, create: c.CreateStep})
+	}
+
+	return pulls
+}
+
+// pullSlots returns the semaphore limiting the
+// containers prepared at the same time for the build.
+func (c *client) pullSlots() *limit.Semaphore {
+	// check if the runtime requires the containers to be prepared in order
+	if c.Runtime.Driver() == constants.DriverKubernetes {
+		return limit.New(1)
+	}
+
+	return limit.New(c.maxPulls)
+}
+
+// pullImages creates the containers provided, pulling their images
+// in parallel up to the pull limit, and writes the progress for each
+// container in order with the function provided. Once a container
+// could not be created, the containers not yet created are skipped
+// and the error for the first container, in order, that could not
+// be created is returned.
+func (c *client) pullImages(ctx context.Context, pulls []*pull, write func(string)) error {
+	slots := c.pullSlots()
+
+	// track if any container could not be created
+	var failed int32
+
+	// create an error group for the containers
+	//
+	// https://pkg.go.dev/golang.org/x/sync/errgroup?tab=doc#Group
+	group := new(errgroup.Group)
+
+	// iterate through all containers provided
+	for _, p := range pulls {
+		// check if the pull is a line of progress
+		if p.ctn == nil {
+			continue
+		}
+
+		// https://golang.org/doc/faq#closures_and_goroutines
+		p := p
+
+		group.Go(func() error {
+			// wait for a slot to pull the image
+			err := slots.Acquire(ctx)
+			if err != nil {
+				p.op, p.err = "create", err
+
+				return nil
+			}
+			defer slots.Release()
+
+			// check if another container could not be created
+			if atomic.LoadInt32(&failed) == 1 {
+				return nil
+			}
+
+			c.pullImage(ctx, p)
+
+			// check if the container could not be created
+			if p.err != nil {
+				atomic.StoreInt32(&failed, 1)
+			}
+
+			return nil
+		})
+	}
+
+	_ = group.Wait()
+
+	return writePulls(pulls, write)
+}
+
+// writePulls writes the progress for each container, in order, with
+// the function provided and returns the error for the first container,
+// in order, that could not be created.
+func writePulls(pulls []*pull, write func(string)) error {
+	var result error
+
+	// iterate through all containers provided in order
+	for _, p := range pulls {
+		// check if the pull is for a container
+		if p.ctn != nil {
+			// record the mirror rewrites for the image
+			for _, note := range p.notes {
+				write(note)
+			}
+		}
+
+		// check if the container could not be created
+		if p.err != nil {
+			// check if the image for the container could not be pulled
+			if errors.Is(p.err, fault.ErrImagePull) {
+				// record the failed attempts to pull the image
+				write(p.summary())
+			}
+
+			// capture the error for the first container that could not be created
+			if result == nil {
+				result = fmt.Errorf("unable to %s %s %s: %w", p.op, p.ctn.Name, p.kind, p.err)
+			}
+
+			continue
+		}
+
+		// check if the container was skipped
+		if p.ctn != nil && p.image == nil {
+			// record the image was skipped since
+			// another container could not be created
+			write(p.skipped())
+
+			continue
+		}
+
+		write(p.output())
+
+		// check if the pull is for a container
+		if p.ctn != nil {
+			// record the digest, duration and attempts for the image
+			write(p.summary())
+		}
+	}
+
+	return result
+}
+
+// pullImage creates the container, which pulls its image, and
+// inspects the image for the container, after rewriting the
+// image to a registry mirror if a mirror rule matches it.
+func (c *client) pullImage(ctx context.Context, p *pull) {
+	started := time.Now()
+
+	// record the time spent pulling the image across every attempt
+	defer func() { p.duration = time.Since(started) }()
+
+	// rewrite the image for the container to a registry mirror
+	c.mirrorImage(p)
+
+	c.retryImage(ctx, p)
+
+	// check if the original image is pulled instead of the mirror
+	if c.fallbackImage(ctx, p) {
+		c.retryImage(ctx, p)
+	}
+}
+
+// retryImage makes attempts to pull the image for the container,
+// with a backoff between each attempt, until the image is pulled
+// or no more attempts are allowed.
+func (c *client) retryImage(ctx context.Context, p *pull) {
+	for {
+		p.attempts++
+
+		c.attemptPull(ctx, p)
+
+		// check if the pull can not be retried
+		if !c.retryPull(ctx, p) {
+			return
+		}
+
+		// capture the backoff for the attempts already made
+		backoff := c.retryBackoff(p.attempts)
+
+		// https://pkg.go.dev/time?tab=doc#NewTimer
+		timer := time.NewTimer(backoff)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// retryBackoff returns the time waited before the next attempt to
+// pull an image, which doubles from the pull backoff for each
+// attempt already made, up to the maximum pull backoff.
+func (c *client) retryBackoff(attempts int) time.Duration {
+	backoff := c.pullBackoff
+
+	// double the backoff for each attempt after the first
+	for i := 1; i < attempts && backoff < maxPullBackoff; i++ {
+		backoff *= 2
+	}
+
+	// check if the backoff exceeds the maximum pull backoff,
+	// unless the pull backoff configured is already longer
+	if backoff > maxPullBackoff && c.pullBackoff < maxPullBackoff {
+		backoff = maxPullBackoff
+	}
+
+	return backoff
+}
+
+// attemptPull makes an attempt, up to the pull timeout, to create
+// the container and inspect the image for the container.
+func (c *client) attemptPull(ctx context.Context, p *pull) {
+	// create a context that is canceled once
+	// the attempt exceeds the pull timeout
+	//
+	// https://pkg.go.dev/context?tab=doc#WithTimeout
+	pullCtx, cancel := context.WithTimeout(ctx, c.pullTimeout)
+	defer cancel()
+
+	// check if the container was created by a previous attempt
+	if !p.created {
+		// create the container
+		err := p.create(pullCtx, p.ctn)
+		if err != nil {
+			p.op, p.err = "create", c.attemptError(ctx, pullCtx, err)
+
+			return
+		}
+
+		p.created = true
+	}
+
+	// inspect the image for the container
+	image, err := c.Runtime.InspectImage(pullCtx, p.ctn)
+	if err != nil {
+		p.op, p.err = "inspect", c.infraError(fault.ErrImagePull, p.ctn.Name, c.attemptError(ctx, pullCtx, err))
+
+		return
+	}
+
+	// ensure an empty image output is still written
+	if image == nil {
+		image = []byte{}
+	}
+
+	p.image, p.digest, p.err = image, imageDigest(image), nil
+}
+
+// attemptError returns the error for an attempt to pull an
+// image, noting when the attempt exceeded the pull timeout.
+func (c *client) attemptError(ctx, pullCtx context.Context, err error) error {
+	// check if the attempt, but not the build, exceeded the pull timeout
+	if errors.Is(pullCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil {
+		return fmt.Errorf("timed out after %v: %w", c.pullTimeout, err)
+	}
+
+	return err
+}
+
+// retryPull returns true when the image for the container
+// could not be pulled and another attempt is allowed.
+func (c *client) retryPull(ctx context.Context, p *pull) bool {
+	// check if the image was pulled or the error
+	// is not caused by the image failing to pull
+	if p.err == nil || !errors.Is(p.err, fault.ErrImagePull) {
+		return false
+	}
+
+	// check if the build was canceled or exceeded its time limit
+	if ctx.Err() != nil || c.timedOut() {
+		return false
+	}
+
+	// check if the runtime configures the container, rather than
+	// pulling its image, in which case creating the container
+	// again would add it to the runtime build twice
+	if !p.created && c.Runtime.Driver() == constants.DriverKubernetes {
+		return false
+	}
+
+	return p.attempts <= c.pullRetries
+}
+
+// imageDigest returns the digest found in the output from
+// inspecting the image for a container, if any.
+func imageDigest(image []byte) string {
+	return string(digestPattern.Find(image))
+}
+
+// startServices plans and executes the services for the pipeline
+// in parallel, up to the pull limit, and returns the error for the
+// first service, in pipeline order, that could not be started.
+func (c *client) startServices(ctx context.Context) error {
+	slots := c.pullSlots()
+
+	// capture the error for each service in pipeline order
+	errs := make([]error, len(c.pipeline.Services))
+
+	// create an error group for the services
+	//
+	// https://pkg.go.dev/golang.org/x/sync/errgroup?tab=doc#Group
+	group := new(errgroup.Group)
+
+	// iterate through all services in the pipeline
+	for i, _service := range c.pipeline.Services {
+		// https://golang.org/doc/faq#closures_and_goroutines
+		i, _service := i, _service
+
+		group.Go(func() error {
+			// wait for a slot to start the service
+			err := slots.Acquire(ctx)
+			if err != nil {
+				errs[i] = fmt.Errorf("unable to execute build: %w", err)
+
+				return nil
+			}
+			defer slots.Release()
+
+			errs[i] = c.startService(ctx, _service)
+
+			return nil
+		})
+	}
+
+	_ = group.Wait()
+
+	// iterate through all errors in pipeline order
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// startService plans and executes the service.
+func (c *client) startService(ctx context.Context, ctn *pipeline.Container) error {
+	// check if the build has been canceled
+	if ctx.Err() != nil {
+		return fmt.Errorf("unable to execute build: %w", ctx.Err())
+	}
+
+	// plan the service
+	err := c.PlanService(ctx, ctn)
+	if err != nil {
+		return fmt.Errorf("unable to plan service: %w", err)
+	}
+
+	// execute the service
+	err = c.ExecService(ctx, ctn)
+	if err != nil {
+		return fmt.Errorf("unable to execute service: %w", err)
+	}
+
+	return nil
+}
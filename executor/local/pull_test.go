@@ -0,0 +1,368 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package local
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-vela/pkg-executor/executor/fault"
+
+	"github.com/go-vela/pkg-runtime/runtime/docker"
+
+	"github.com/go-vela/types/pipeline"
+)
+
+func TestLocal_pullImages(t *testing.T) {
+	// setup types
+	_runtime, err := docker.NewMock()
+	if err != nil {
+		t.Errorf("unable to create runtime engine: %v", err)
+	}
+
+	_pulls := func(names ...string) []*pull {
+		pulls := []*pull{pullLine("> Pulling step images...")}
+
+		for i, name := range names {
+			ctn := &pipeline.Container{
+				ID:     fmt.Sprintf("step_github_octocat_1_%s", name),
+				Image:  "alpine:latest",
+				Name:   name,
+				Number: i + 1,
+			}
+
+			attempts := 0
+
+			// create the containers with a delay so the
+			// images finish pulling in reverse order
+			create := func(ctx context.Context, ctn *pipeline.Container) error {
+				time.Sleep(time.Duration(10-ctn.Number) * time.Millisecond)
+
+				attempts++
+
+				switch {
+				case strings.HasSuffix(ctn.Name, "-fail"):
+					return errors.New("invalid exit codes")
+				case strings.HasSuffix(ctn.Name, "-broken"),
+					strings.HasSuffix(ctn.Name, "-flaky") && attempts == 1:
+					return fault.Wrap(fault.ErrImagePull, ctn.Name, errors.New("registry unavailable"))
+				}
+
+				return nil
+			}
+
+			pulls = append(pulls, &pull{kind: "step", ctn: ctn, create: create})
+		}
+
+		return pulls
+	}
+
+	// capture the output for the images pulled in order
+	image, err := _runtime.InspectImage(context.Background(), &pipeline.Container{Image: "alpine:latest"})
+	if err != nil {
+		t.Errorf("unable to inspect image: %v", err)
+	}
+
+	digest := imageDigest(image)
+	if len(digest) == 0 {
+		digest = "unknown"
+	}
+
+	pulled := func(attempts int) string {
+		return fmt.Sprintf("> Pulled image alpine:latest (digest: %s, duration: 0s, attempts: %d)", digest, attempts)
+	}
+
+	want := []string{"> Pulling step images..."}
+	for i := 0; i < 4; i++ {
+		want = append(want, string(image), pulled(1))
+	}
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		max     int
+		pulls   []*pull
+		want    []string
+	}{
+		{ // every image pulled at once
+			failure: false,
+			max:     0,
+			pulls:   _pulls("clone", "install", "test", "build"),
+			want:    want,
+		},
+		{ // images pulled one at a time
+			failure: false,
+			max:     1,
+			pulls:   _pulls("clone", "install", "test", "build"),
+			want:    want,
+		},
+		{ // images pulled two at a time
+			failure: false,
+			max:     2,
+			pulls:   _pulls("clone", "install", "test", "build"),
+			want:    want,
+		},
+		{ // image pulled after a retry
+			failure: false,
+			max:     2,
+			pulls:   _pulls("clone", "install-flaky"),
+			want: []string{
+				"> Pulling step images...",
+				string(image), pulled(1),
+				string(image), pulled(2),
+			},
+		},
+		{ // image that could not be pulled after every retry
+			failure: true,
+			max:     0,
+			pulls:   _pulls("clone", "install-broken", "test", "build"),
+			want: []string{
+				"> Pulling step images...",
+				string(image), pulled(1),
+				"> Unable to pull image alpine:latest (duration: 0s, attempts: 3): install-broken: image pull failed: registry unavailable",
+				string(image), pulled(1),
+				string(image), pulled(1),
+			},
+		},
+		{ // container that could not be created
+			failure: true,
+			max:     0,
+			pulls:   _pulls("clone", "install-fail", "test", "build"),
+			want: []string{
+				"> Pulling step images...",
+				string(image), pulled(1),
+				string(image), pulled(1),
+				string(image), pulled(1),
+			},
+		},
+	}
+
+	// ignore the time spent pulling each image
+	duration := regexp.MustCompile(`duration: [^,]+,`)
+
+	// run tests
+	for _, test := range tests {
+		_engine, err := New(
+			WithMaxPulls(test.max),
+			WithPullBackoff(time.Millisecond),
+			WithPullRetries(2),
+			WithRuntime(_runtime),
+		)
+		if err != nil {
+			t.Errorf("unable to create executor engine: %v", err)
+		}
+
+		got := []string{}
+
+		err = _engine.pullImages(context.Background(), test.pulls, func(line string) {
+			got = append(got, duration.ReplaceAllString(line, "duration: 0s,"))
+		})
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("pullImages should have returned err")
+			}
+		}
+
+		if !test.failure && err != nil {
+			t.Errorf("pullImages returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("pullImages wrote %q, want %q", got, test.want)
+		}
+	}
+}
+
+func TestLocal_writePulls(t *testing.T) {
+	// setup types
+	_pull := func(name string, image []byte, err error) *pull {
+		return &pull{
+			kind:  "step",
+			ctn:   &pipeline.Container{Image: "alpine:latest", Name: name},
+			op:    "create",
+			image: image,
+			err:   err,
+		}
+	}
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		pulls   []*pull
+		want    []string
+	}{
+		{ // every image pulled
+			failure: false,
+			pulls:   []*pull{pullLine("> Pulling step images..."), _pull("clone", []byte("image"), nil)},
+			want: []string{
+				"> Pulling step images...",
+				"image",
+				"> Pulled image alpine:latest (digest: unknown, duration: 0s, attempts: 0)",
+			},
+		},
+		{ // images skipped after a container could not be created
+			failure: true,
+			pulls: []*pull{
+				pullLine("> Pulling step images..."),
+				_pull("clone", nil, nil),
+				_pull("install", nil, errors.New("invalid exit codes")),
+				_pull("test", nil, nil),
+			},
+			want: []string{
+				"> Pulling step images...",
+				"> Skipped pulling image alpine:latest for clone step since another container could not be created",
+				"> Skipped pulling image alpine:latest for test step since another container could not be created",
+			},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got := []string{}
+
+		err := writePulls(test.pulls, func(data string) {
+			got = append(got, data)
+		})
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("writePulls should have returned err")
+			}
+		}
+
+		if !test.failure && err != nil {
+			t.Errorf("writePulls returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("writePulls wrote %q, want %q", got, test.want)
+		}
+	}
+}
+
+func TestLocal_retryBackoff(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		backoff  time.Duration
+		attempts int
+		want     time.Duration
+	}{
+		{
+			backoff:  time.Second,
+			attempts: 1,
+			want:     time.Second,
+		},
+		{
+			backoff:  time.Second,
+			attempts: 3,
+			want:     4 * time.Second,
+		},
+		{
+			backoff:  time.Second,
+			attempts: 100,
+			want:     maxPullBackoff,
+		},
+		{
+			backoff:  2 * maxPullBackoff,
+			attempts: 100,
+			want:     2 * maxPullBackoff,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		_engine, err := New(
+			WithPullBackoff(test.backoff),
+		)
+		if err != nil {
+			t.Errorf("unable to create executor engine: %v", err)
+		}
+
+		got := _engine.retryBackoff(test.attempts)
+
+		if got != test.want {
+			t.Errorf("retryBackoff is %v, want %v", got, test.want)
+		}
+	}
+}
+
+func TestLocal_pullImage_Timeout(t *testing.T) {
+	// setup types
+	_runtime, err := docker.NewMock()
+	if err != nil {
+		t.Errorf("unable to create runtime engine: %v", err)
+	}
+
+	_engine, err := New(
+		WithPullBackoff(time.Millisecond),
+		WithPullRetries(1),
+		WithPullTimeout(10*time.Millisecond),
+		WithRuntime(_runtime),
+	)
+	if err != nil {
+		t.Errorf("unable to create executor engine: %v", err)
+	}
+
+	p := &pull{
+		kind: "step",
+		ctn:  &pipeline.Container{ID: "step_github_octocat_1_clone", Image: "alpine:latest", Name: "clone"},
+		// create the container until the attempt exceeds the pull timeout
+		create: func(ctx context.Context, ctn *pipeline.Container) error {
+			<-ctx.Done()
+
+			return fault.Wrap(fault.ErrImagePull, ctn.Name, ctx.Err())
+		},
+	}
+
+	// run test
+	_engine.pullImage(context.Background(), p)
+
+	if !errors.Is(p.err, fault.ErrImagePull) {
+		t.Errorf("pullImage returned err %v, want %v", p.err, fault.ErrImagePull)
+	}
+
+	if !strings.Contains(p.err.Error(), "timed out after 10ms") {
+		t.Errorf("pullImage returned err %v, want timed out err", p.err)
+	}
+
+	if p.attempts != 2 {
+		t.Errorf("pullImage made %d attempts, want 2", p.attempts)
+	}
+}
+
+func TestLocal_startServices(t *testing.T) {
+	// setup types
+	_runtime, err := docker.NewMock()
+	if err != nil {
+		t.Errorf("unable to create runtime engine: %v", err)
+	}
+
+	_engine, err := New(
+		WithBuild(testBuild()),
+		WithPipeline(testSteps()),
+		WithRepo(testRepo()),
+		WithRuntime(_runtime),
+		WithUser(testUser()),
+	)
+	if err != nil {
+		t.Errorf("unable to create executor engine: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// run test
+	err = _engine.startServices(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("startServices returned err %v, want %v", err, context.Canceled)
+	}
+}
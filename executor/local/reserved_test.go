@@ -0,0 +1,45 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package local
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-vela/pkg-executor/internal/step"
+	"github.com/go-vela/types/pipeline"
+)
+
+func TestLocal_runtimeContainer(t *testing.T) {
+	// setup types
+	ctn := &pipeline.Container{
+		ID:    "step_github_octocat_1_test",
+		Image: "alpine:latest",
+		Name:  "test",
+		Environment: map[string]string{
+			"VELA_STEP_STAGE": "test",
+			lockKey:           "db",
+			step.NeedsKey:     "clone",
+		},
+	}
+
+	want := map[string]string{"VELA_STEP_STAGE": "test"}
+
+	// run test
+	got := runtimeContainer(ctn)
+
+	if !reflect.DeepEqual(got.Environment, want) {
+		t.Errorf("runtimeContainer environment is %v, want %v", got.Environment, want)
+	}
+
+	if got.Image != ctn.Image {
+		t.Errorf("runtimeContainer image is %s, want %s", got.Image, ctn.Image)
+	}
+
+	// the environment for the container is left for the executor
+	if ctn.Environment[lockKey] != "db" || ctn.Environment[step.NeedsKey] != "clone" {
+		t.Errorf("runtimeContainer removed the reserved environment from the container")
+	}
+}
@@ -11,6 +11,8 @@ import (
 	"os"
 	"time"
 
+	"github.com/go-vela/pkg-executor/executor/event"
+	"github.com/go-vela/pkg-executor/executor/fault"
 	"github.com/go-vela/pkg-executor/internal/service"
 
 	"github.com/go-vela/types/constants"
@@ -23,16 +25,33 @@ const servicePattern = "[service: %s]"
 
 // CreateService configures the service for execution.
 func (c *client) CreateService(ctx context.Context, ctn *pipeline.Container) error {
-	// setup the runtime container
-	err := c.Runtime.SetupContainer(ctx, ctn)
+	// validate the readiness probe declared for the service
+	//
+	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/service#Readiness
+	_, err := service.Readiness(ctn)
 	if err != nil {
 		return err
 	}
 
+	// validate the crash policy declared for the service
+	//
+	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/service#CrashPolicy
+	_, err = service.CrashPolicy(ctn)
+	if err != nil {
+		return err
+	}
+
+	// setup the runtime container without the
+	// environment reserved for the executor
+	err = c.Runtime.SetupContainer(ctx, runtimeContainer(ctn))
+	if err != nil {
+		return c.infraError(fault.ErrImagePull, ctn.Name, err)
+	}
+
 	// update the service container environment
 	//
 	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/service#Environment
-	err = service.Environment(ctn, c.build, c.repo, nil, c.Version)
+	c.locked(func() { err = service.Environment(ctn, c.build, c.repo, nil, c.Version) })
 	if err != nil {
 		return err
 	}
@@ -57,14 +76,20 @@ func (c *client) PlanService(ctx context.Context, ctn *pipeline.Container) error
 	_service.SetImage(ctn.Image)
 	_service.SetStatus(constants.StatusRunning)
 	_service.SetStarted(time.Now().UTC().Unix())
-	_service.SetHost(c.build.GetHost())
-	_service.SetRuntime(c.build.GetRuntime())
-	_service.SetDistribution(c.build.GetDistribution())
+
+	// capture the build fields updated once the build is created
+	c.locked(func() {
+		_service.SetHost(c.build.GetHost())
+		_service.SetRuntime(c.build.GetRuntime())
+		_service.SetDistribution(c.build.GetDistribution())
+	})
+
+	var err error
 
 	// update the service container environment
 	//
 	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/service#Environment
-	err := service.Environment(ctn, c.build, c.repo, _service, c.Version)
+	c.locked(func() { err = service.Environment(ctn, c.build, c.repo, _service, c.Version) })
 	if err != nil {
 		return err
 	}
@@ -88,21 +113,34 @@ func (c *client) ExecService(ctx context.Context, ctn *pipeline.Container) error
 	// defer taking a snapshot of the service
 	//
 	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/service#Snapshot
-	defer func() { service.Snapshot(ctn, c.build, nil, nil, nil, _service) }()
+	defer c.locked(func() { service.Snapshot(ctn, c.build, nil, nil, nil, _service) })
 
-	// run the runtime container
-	err = c.Runtime.RunContainer(ctx, ctn, c.pipeline)
+	// wait for the lock group for the container to be free
+	err = c.lockContainer(ctx, ctn, c.noteService)
 	if err != nil {
 		return err
 	}
 
-	go func() {
-		// stream logs from container
-		err := c.StreamService(context.Background(), ctn)
-		if err != nil {
-			fmt.Fprintln(os.Stdout, "unable to stream logs for service:", err)
-		}
-	}()
+	// run the runtime container without the
+	// environment reserved for the executor
+	err = c.Runtime.RunContainer(ctx, runtimeContainer(ctn), c.pipeline)
+	if err != nil {
+		err = c.infraError(fault.ErrContainerStart, ctn.Name, err)
+
+		// update the service with the error
+		c.serviceError(_service, err)
+
+		return err
+	}
+
+	// publish an event for the started service
+	c.publish(&event.Event{Type: event.ServiceStarted, Name: ctn.Name})
+
+	// watch the container for the service exiting unexpectedly
+	c.monitorService(ctn, _service)
+
+	// stream logs from container
+	c.stream(ctn, c.StreamService)
 
 	return nil
 }
@@ -119,6 +157,10 @@ func (c *client) StreamService(ctx context.Context, ctn *pipeline.Container) err
 	// create a service pattern for log output
 	_pattern := fmt.Sprintf(servicePattern, ctn.Name)
 
+	// capture the buffered log for the service
+	logs := c.tail(ctn)
+	defer logs.Close()
+
 	// create new scanner from the container output
 	scanner := bufio.NewScanner(rc)
 
@@ -126,6 +168,10 @@ func (c *client) StreamService(ctx context.Context, ctn *pipeline.Container) err
 	for scanner.Scan() {
 		// ensure we output to stdout
 		fmt.Fprintln(os.Stdout, _pattern, scanner.Text())
+
+		// record the line of output in the buffered log,
+		// which publishes the line as a log event
+		_, _ = logs.Write(append([]byte(scanner.Text()), '\n'))
 	}
 
 	return scanner.Err()
@@ -147,7 +193,13 @@ func (c *client) DestroyService(ctx context.Context, ctn *pipeline.Container) er
 	// defer an upload of the service
 	//
 	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/service#Upload
-	defer func() { service.Upload(ctn, c.build, nil, nil, nil, _service) }()
+	defer c.locked(func() { service.Upload(ctn, c.build, nil, nil, nil, _service) })
+
+	// release the lock group held by the container
+	defer c.unlockContainer(ctn)
+
+	// stop watching the container before it is removed
+	c.unmonitorService(ctn)
 
 	// inspect the runtime container
 	err = c.Runtime.InspectContainer(ctx, ctn)
@@ -155,11 +207,10 @@ func (c *client) DestroyService(ctx context.Context, ctn *pipeline.Container) er
 		return err
 	}
 
-	// remove the runtime container
-	err = c.Runtime.RemoveContainer(ctx, ctn)
-	if err != nil {
-		return err
-	}
+	// publish an event for the stopped service
+	c.publish(&event.Event{Type: event.ServiceDied, Name: ctn.Name, ExitCode: ctn.ExitCode})
 
-	return nil
+	// remove the runtime container before its output is flushed,
+	// since a service runs until its container is removed
+	return c.removeContainer(ctx, ctn, false)
 }
@@ -0,0 +1,110 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package local
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-vela/pkg-executor/executor/event"
+	"github.com/go-vela/pkg-executor/executor/limit"
+	"github.com/go-vela/types/pipeline"
+)
+
+func TestLocal_queueStage(t *testing.T) {
+	// setup types
+	s := &pipeline.Stage{Name: "test"}
+
+	slots := limit.New(1)
+
+	_engine, err := New()
+	if err != nil {
+		t.Errorf("unable to create executor engine: %v", err)
+	}
+
+	events, cancel := _engine.Subscribe(1)
+	defer cancel()
+
+	// run test
+	err = _engine.queueStage(context.Background(), s, slots)
+	if err != nil {
+		t.Errorf("queueStage returned err: %v", err)
+	}
+
+	if len(events) > 0 {
+		t.Errorf("queueStage published an event without waiting for a slot")
+	}
+
+	done := make(chan error)
+
+	go func() {
+		done <- _engine.queueStage(context.Background(), s, slots)
+	}()
+
+	got := <-events
+	if got.Type != event.StageQueued {
+		t.Errorf("queueStage published %s, want %s", got.Type, event.StageQueued)
+	}
+
+	if !_engine.stageQueued(s) {
+		t.Errorf("stageQueued is false, want true")
+	}
+
+	slots.Release()
+
+	err = <-done
+	if err != nil {
+		t.Errorf("queueStage returned err: %v", err)
+	}
+
+	if _engine.stageQueued(s) {
+		t.Errorf("stageQueued is true, want false")
+	}
+
+	ctx, stop := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer stop()
+
+	err = _engine.queueStage(ctx, s, slots)
+	if err == nil {
+		t.Errorf("queueStage should have returned err")
+	}
+}
+
+func TestLocal_acquireContainer(t *testing.T) {
+	// setup types
+	ctn := &pipeline.Container{ID: "step_github_octocat_1_echo", Name: "echo"}
+
+	_containers := limit.New(1)
+
+	_engine, err := New(
+		WithContainerLimit(_containers),
+	)
+	if err != nil {
+		t.Errorf("unable to create executor engine: %v", err)
+	}
+
+	// run test
+	err = _engine.acquireContainer(context.Background(), ctn)
+	if err != nil {
+		t.Errorf("acquireContainer returned err: %v", err)
+	}
+
+	ctx, stop := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer stop()
+
+	err = _engine.acquireContainer(ctx, &pipeline.Container{ID: "step_github_octocat_1_test", Name: "test"})
+	if err == nil {
+		t.Errorf("acquireContainer should have returned err")
+	}
+
+	// releasing the container more than once only frees one slot
+	_engine.releaseContainer(ctn)
+	_engine.releaseContainer(ctn)
+
+	if _containers.InUse() != 0 {
+		t.Errorf("releaseContainer slots in use is %d, want 0", _containers.InUse())
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package local
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/go-vela/pkg-executor/internal/build"
+	"github.com/go-vela/pkg-executor/internal/stage"
+	"github.com/go-vela/pkg-executor/internal/step"
+	"github.com/go-vela/types/constants"
+	"github.com/go-vela/types/pipeline"
+)
+
+// locked calls the function provided while holding the lock
+// for the resources tracked by the client, reverting any
+// invalid transition it made to the status of the build.
+//
+// The function must not call any other function
+// that acquires the lock for the client.
+func (c *client) locked(fn func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	from := c.build.GetStatus()
+
+	fn()
+
+	// validate the status transition for the build
+	//
+	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/build#Transition
+	err := build.Transition(from, c.build.GetStatus())
+	if err != nil {
+		fmt.Fprintln(os.Stdout, "ignoring build status:", err)
+
+		c.build.SetStatus(from)
+	}
+}
+
+// status gets the status of the build in execution.
+func (c *client) status() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.build.GetStatus()
+}
+
+// skip returns if the step should be skipped based
+// off the ruleset for the step and the build.
+func (c *client) skip(ctn *pipeline.Container) bool {
+	var skip bool
+
+	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/step#Skip
+	c.locked(func() { skip = step.Skip(ctn, c.build, c.repo) })
+
+	return skip
+}
+
+// skipInStage returns if the step for the stage should be skipped
+// based off the ruleset for the step and the build.
+//
+// A stage that keeps running when other stages fail ignores
+// their failures, so the ruleset for the step is evaluated
+// against the status of the build before any stage ran, the
+// status of the stages it needs and the status of the steps
+// for the stage.
+func (c *client) skipInStage(s *pipeline.Stage, ctn *pipeline.Container) bool {
+	// check if a failed stage stops every other stage
+	//
+	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/stage#Policy
+	if stage.Policy(s, c.failure) == stage.FailFast {
+		return c.skip(ctn)
+	}
+
+	var skip bool
+
+	c.locked(func() {
+		// copy the build to avoid modifying its status
+		b := *c.build
+
+		b.SetStatus(c.stageBase)
+
+		// iterate through all stages the stage needs
+		for _, needs := range s.Needs {
+			// load the tracker for the stage from the client
+			//
+			// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/stage#Load
+			t, err := stage.Load(needs, &c.stages)
+			if err == nil && t.Status() == constants.StatusFailure {
+				b.SetStatus(constants.StatusFailure)
+			}
+		}
+
+		// iterate through all steps for the stage
+		for _, _step := range s.Steps {
+			// load the step from the client
+			//
+			// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/step#Load
+			st, err := step.Load(_step, &c.steps)
+			if err != nil || _step.Ruleset.Continue || step.SoftFailure(_step) {
+				continue
+			}
+
+			// check if the step failed
+			switch st.GetStatus() {
+			case constants.StatusError, constants.StatusFailure, constants.StatusKilled:
+				b.SetStatus(constants.StatusFailure)
+			}
+		}
+
+		// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/step#Skip
+		skip = step.Skip(ctn, &b, c.repo)
+	})
+
+	return skip
+}
+
+// runsOnFailure returns if any step for the stage runs based
+// off its ruleset once the build fails, so the stage runs
+// even though a stage it needs failed.
+func (c *client) runsOnFailure(s *pipeline.Stage) bool {
+	var runs bool
+
+	c.locked(func() {
+		// copy the build to avoid modifying its status
+		b := *c.build
+
+		b.SetStatus(constants.StatusFailure)
+
+		// iterate through all steps for the stage
+		for _, _step := range s.Steps {
+			// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/step#Skip
+			if !step.Skip(_step, &b, c.repo) {
+				runs = true
+
+				return
+			}
+		}
+	})
+
+	return runs
+}
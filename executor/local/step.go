@@ -11,6 +11,8 @@ import (
 	"os"
 	"time"
 
+	"github.com/go-vela/pkg-executor/executor/event"
+	"github.com/go-vela/pkg-executor/executor/fault"
 	"github.com/go-vela/pkg-executor/internal/step"
 	"github.com/go-vela/types/constants"
 	"github.com/go-vela/types/library"
@@ -27,12 +29,29 @@ func (c *client) CreateStep(ctx context.Context, ctn *pipeline.Container) error
 		return nil
 	}
 
-	// setup the runtime container
-	err := c.Runtime.SetupContainer(ctx, ctn)
+	// validate the exit codes mapped for the step
+	//
+	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/step#ExitCodes
+	_, err := step.ExitCodes(ctn)
+	if err != nil {
+		return err
+	}
+
+	// validate the detach policy declared for the step
+	//
+	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/step#DetachPolicy
+	_, err = step.DetachPolicy(ctn)
 	if err != nil {
 		return err
 	}
 
+	// setup the runtime container without the
+	// environment reserved for the executor
+	err = c.Runtime.SetupContainer(ctx, runtimeContainer(ctn))
+	if err != nil {
+		return c.infraError(fault.ErrImagePull, ctn.Name, err)
+	}
+
 	// create a library step object to facilitate injecting environment as early as possible
 	// (PlanStep is too late to inject environment vars for the kubernetes runtime).
 	_step := c.newLibraryStep(ctn)
@@ -41,7 +60,7 @@ func (c *client) CreateStep(ctx context.Context, ctn *pipeline.Container) error
 	// update the step container environment
 	//
 	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/step#Environment
-	err = step.Environment(ctn, c.build, c.repo, _step, c.Version)
+	c.locked(func() { err = step.Environment(ctn, c.build, c.repo, _step, c.Version) })
 	if err != nil {
 		return err
 	}
@@ -64,9 +83,14 @@ func (c *client) newLibraryStep(ctn *pipeline.Container) *library.Step {
 	_step.SetNumber(ctn.Number)
 	_step.SetImage(ctn.Image)
 	_step.SetStage(ctn.Environment["VELA_STEP_STAGE"])
-	_step.SetHost(c.build.GetHost())
-	_step.SetRuntime(c.build.GetRuntime())
-	_step.SetDistribution(c.build.GetDistribution())
+
+	// capture the build fields updated once the build is created
+	c.locked(func() {
+		_step.SetHost(c.build.GetHost())
+		_step.SetRuntime(c.build.GetRuntime())
+		_step.SetDistribution(c.build.GetDistribution())
+	})
+
 	return _step
 }
 
@@ -77,10 +101,12 @@ func (c *client) PlanStep(ctx context.Context, ctn *pipeline.Container) error {
 	_step.SetStatus(constants.StatusRunning)
 	_step.SetStarted(time.Now().UTC().Unix())
 
+	var err error
+
 	// update the step container environment
 	//
 	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/step#Environment
-	err := step.Environment(ctn, c.build, c.repo, _step, c.Version)
+	c.locked(func() { err = step.Environment(ctn, c.build, c.repo, _step, c.Version) })
 	if err != nil {
 		return err
 	}
@@ -88,6 +114,14 @@ func (c *client) PlanStep(ctx context.Context, ctn *pipeline.Container) error {
 	// add the step to the client map
 	c.steps.Store(ctn.ID, _step)
 
+	// publish an event for the planned step
+	c.publish(&event.Event{
+		Type:   event.StepPlanned,
+		Stage:  _step.GetStage(),
+		Name:   ctn.Name,
+		Status: _step.GetStatus(),
+	})
+
 	return nil
 }
 
@@ -109,40 +143,130 @@ func (c *client) ExecStep(ctx context.Context, ctn *pipeline.Container) error {
 	// defer taking a snapshot of the step
 	//
 	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/step#Snapshot
-	defer func() { step.Snapshot(ctn, c.build, nil, nil, nil, _step) }()
+	defer c.locked(func() { step.Snapshot(ctn, c.build, nil, nil, nil, _step) })
+
+	// create a context that is canceled when the step is canceled
+	//
+	// https://pkg.go.dev/context?tab=doc#WithCancel
+	stepCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
 
-	// run the runtime container
-	err = c.Runtime.RunContainer(ctx, ctn, c.pipeline)
+	// track the step as running so it can be canceled
+	c.running.Store(ctn.ID, cancel)
+	defer c.running.Delete(ctn.ID)
+
+	// check if the step was canceled before it started
+	if c.stepCanceled(ctn, _step) {
+		return nil
+	}
+
+	// wait for the lock group for the container to be free
+	err = c.lockContainer(stepCtx, ctn, c.noteStep)
 	if err != nil {
+		// check if the step was canceled while waiting
+		if c.stepCanceled(ctn, _step) {
+			return nil
+		}
+
 		return err
 	}
 
-	go func() {
-		// stream logs from container
-		err := c.StreamStep(context.Background(), ctn)
+	// check if the container is detached, in which case it runs
+	// until the build is destroyed without a slot on the host
+	if !ctn.Detach {
+		// wait for a slot on the host to run the container
+		err = c.acquireContainer(stepCtx, ctn)
 		if err != nil {
-			// TODO: Should this be changed or removed?
-			fmt.Println(err)
+			// check if the step was canceled while waiting
+			if c.stepCanceled(ctn, _step) {
+				return nil
+			}
+
+			return err
+		}
+
+		// release the slot and lock group once the container exits
+		defer c.unlockContainer(ctn)
+		defer c.releaseContainer(ctn)
+	}
+
+	// run the runtime container without the
+	// environment reserved for the executor
+	err = c.Runtime.RunContainer(stepCtx, runtimeContainer(ctn), c.pipeline)
+	if err != nil {
+		// check if the step was canceled while starting
+		if c.stepCanceled(ctn, _step) {
+			return nil
+		}
+
+		// check if the build was canceled while starting
+		if c.buildCanceled(ctx, _step) {
+			return ctx.Err()
 		}
-	}()
+
+		err = c.infraError(fault.ErrContainerStart, ctn.Name, err)
+
+		// update the step with the error
+		c.stepError(_step, err)
+
+		return err
+	}
+
+	// publish an event for the started step
+	c.publish(&event.Event{Type: event.StepStarted, Stage: _step.GetStage(), Name: ctn.Name})
+
+	// stream logs from container
+	c.stream(ctn, c.StreamStep)
 
 	// do not wait for detached containers
 	if ctn.Detach {
+		// record the final status once the container exits
+		c.watchDetached(ctn, _step)
+
 		return nil
 	}
 
 	// wait for the runtime container
-	err = c.Runtime.WaitContainer(ctx, ctn)
+	err = c.Runtime.WaitContainer(stepCtx, ctn)
+
+	// check if the step was canceled while running
+	if c.stepCanceled(ctn, _step) {
+		return nil
+	}
+
 	if err != nil {
+		// check if the build was canceled while running
+		if c.buildCanceled(ctx, _step) {
+			return ctx.Err()
+		}
+
+		err = c.infraError(fault.ErrRuntimeUnavailable, ctn.Name, err)
+
+		// update the step with the error
+		c.stepError(_step, err)
+
 		return err
 	}
 
 	// inspect the runtime container
 	err = c.Runtime.InspectContainer(ctx, ctn)
 	if err != nil {
+		err = c.infraError(fault.ErrRuntimeUnavailable, ctn.Name, err)
+
+		// update the step with the error
+		c.stepError(_step, err)
+
 		return err
 	}
 
+	// publish an event for the exited step
+	c.publish(&event.Event{
+		Type:     event.StepExited,
+		Stage:    _step.GetStage(),
+		Name:     ctn.Name,
+		ExitCode: ctn.ExitCode,
+	})
+
 	return nil
 }
 
@@ -173,6 +297,10 @@ func (c *client) StreamStep(ctx context.Context, ctn *pipeline.Container) error
 		}
 	}
 
+	// capture the buffered log for the step
+	logs := c.tail(ctn)
+	defer logs.Close()
+
 	// create new scanner from the container output
 	scanner := bufio.NewScanner(rc)
 
@@ -180,6 +308,10 @@ func (c *client) StreamStep(ctx context.Context, ctn *pipeline.Container) error
 	for scanner.Scan() {
 		// ensure we output to stdout
 		fmt.Fprintln(os.Stdout, _pattern, scanner.Text())
+
+		// record the line of output in the buffered log,
+		// which publishes the line as a log event
+		_, _ = logs.Write(append([]byte(scanner.Text()), '\n'))
 	}
 
 	return scanner.Err()
@@ -206,7 +338,16 @@ func (c *client) DestroyStep(ctx context.Context, ctn *pipeline.Container) error
 	// defer an upload of the step
 	//
 	// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/step#Upload
-	defer func() { step.Upload(ctn, c.build, nil, nil, nil, _step) }()
+	defer c.locked(func() { step.Upload(ctn, c.build, nil, nil, nil, _step) })
+
+	// release the slot on the host and
+	// lock group held by the container
+	defer c.unlockContainer(ctn)
+	defer c.releaseContainer(ctn)
+
+	// wait, up to the stop timeout, for the runtime container to
+	// exit so the output for the step finishes streaming
+	exited := c.waitContainer(ctx, ctn)
 
 	// inspect the runtime container
 	err = c.Runtime.InspectContainer(ctx, ctn)
@@ -214,11 +355,6 @@ func (c *client) DestroyStep(ctx context.Context, ctn *pipeline.Container) error
 		return err
 	}
 
-	// remove the runtime container
-	err = c.Runtime.RemoveContainer(ctx, ctn)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	// remove the runtime container once its output has flushed
+	return c.removeContainer(ctx, ctn, exited)
 }
@@ -8,6 +8,8 @@ import (
 	"context"
 	"testing"
 
+	"github.com/go-vela/pkg-executor/internal/step"
+
 	"github.com/go-vela/pkg-runtime/runtime/docker"
 
 	"github.com/go-vela/types/library"
@@ -66,6 +68,18 @@ func TestLocal_CreateStep(t *testing.T) {
 				Pull:        "not_present",
 			},
 		},
+		{ // step container with invalid exit codes
+			failure: true,
+			container: &pipeline.Container{
+				ID:          "step_github_octocat_1_echo",
+				Directory:   "/vela/src/github.com/github/octocat",
+				Environment: map[string]string{step.ExitCodesKey: "1:foo"},
+				Image:       "alpine:latest",
+				Name:        "echo",
+				Number:      1,
+				Pull:        "not_present",
+			},
+		},
 		{ // empty step container
 			failure:   true,
 			container: new(pipeline.Container),
@@ -0,0 +1,143 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package local
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/go-vela/types/pipeline"
+)
+
+// streamer represents the output for a
+// container streaming in the background.
+type streamer struct {
+	done chan struct{}
+	err  error
+	prev *streamer
+}
+
+// stream runs the function provided in the background to stream
+// the output for the container and tracks it until it finishes.
+func (c *client) stream(ctn *pipeline.Container, fn func(context.Context, *pipeline.Container) error) {
+	s := &streamer{done: make(chan struct{})}
+
+	// capture the stream for a previous run of the container
+	if prev, ok := c.streamers.Load(ctn.ID); ok {
+		s.prev = prev.(*streamer)
+	}
+
+	// add the streamer to the map
+	c.streamers.Store(ctn.ID, s)
+
+	// track the stream until the output is flushed
+	c.streams.Add(1)
+
+	go func() {
+		defer c.streams.Done()
+		defer close(s.done)
+
+		// create a background context so the output
+		// is still flushed after a build is canceled
+		s.err = fn(context.Background(), ctn)
+		if s.err != nil {
+			fmt.Fprintln(os.Stdout, "unable to stream logs:", s.err)
+		}
+	}()
+}
+
+// flushStream waits, up to the stop timeout, for the output of the
+// container to finish streaming and returns the error from the stream.
+//
+// The stream is still tracked if the output has not finished
+// streaming so it is waited on once the build is destroyed.
+func (c *client) flushStream(ctn *pipeline.Container) error {
+	v, ok := c.streamers.Load(ctn.ID)
+	if !ok {
+		return nil
+	}
+
+	// https://pkg.go.dev/time?tab=doc#NewTimer
+	timer := time.NewTimer(c.stopTimeout)
+	defer timer.Stop()
+
+	// wait for every run of the container to finish streaming
+	for s := v.(*streamer); s != nil; s = s.prev {
+		select {
+		case <-s.done:
+		case <-timer.C:
+			return fmt.Errorf("timed out waiting %v for %s output to flush", c.stopTimeout, ctn.Name)
+		}
+	}
+
+	// remove the streamer from the map
+	c.streamers.Delete(ctn.ID)
+
+	// capture the errors from every run of the container
+	for s := v.(*streamer); s != nil; s = s.prev {
+		if s.err != nil {
+			return fmt.Errorf("unable to stream %s output: %w", ctn.Name, s.err)
+		}
+	}
+
+	return nil
+}
+
+// flushStreams waits, up to the stop timeout, for the output of every
+// step still streaming to finish and reports the stream errors. The
+// output for each service is flushed once the service is destroyed.
+func (c *client) flushStreams() {
+	containers := []*pipeline.Container{}
+
+	// capture the containers from every step and stage
+	containers = append(containers, c.pipeline.Steps...)
+
+	for _, _stage := range c.pipeline.Stages {
+		containers = append(containers, _stage.Steps...)
+	}
+
+	// iterate through all containers in the pipeline
+	for _, ctn := range containers {
+		err := c.flushStream(ctn)
+		if err != nil {
+			// output the error information to stdout
+			fmt.Fprintln(os.Stdout, "unable to flush container output:", err)
+		}
+	}
+}
+
+// removeContainer removes the container after its output
+// has flushed, since removing the container cuts off any
+// output that has not finished streaming.
+//
+// The output for a container that did not exit within the stop
+// timeout only ends once the container is removed, so its output
+// is flushed after it is removed instead.
+func (c *client) removeContainer(ctx context.Context, ctn *pipeline.Container, exited bool) error {
+	var err error
+
+	// check if the container exited so its output
+	// finishes streaming before it is removed
+	if exited {
+		// wait for the output for the container to flush
+		err = c.flushStream(ctn)
+	}
+
+	// remove the runtime container
+	rerr := c.Runtime.RemoveContainer(ctx, ctn)
+	if rerr != nil {
+		return rerr
+	}
+
+	// check if the output for the container ends with the container
+	if !exited {
+		// wait for the output for the container to flush
+		err = c.flushStream(ctn)
+	}
+
+	return err
+}
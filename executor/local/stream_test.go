@@ -0,0 +1,81 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package local
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-vela/types/pipeline"
+)
+
+func TestLocal_flushStream(t *testing.T) {
+	// setup types
+	_service := &pipeline.Container{
+		ID:    "service_github_octocat_1_postgres",
+		Image: "postgres:12-alpine",
+		Name:  "postgres",
+	}
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		fn      func(context.Context, *pipeline.Container) error
+	}{
+		{
+			failure: false,
+			fn: func(context.Context, *pipeline.Container) error {
+				return nil
+			},
+		},
+		{
+			failure: true,
+			fn: func(context.Context, *pipeline.Container) error {
+				return errors.New("unable to upload logs")
+			},
+		},
+		{
+			failure: true,
+			fn: func(context.Context, *pipeline.Container) error {
+				time.Sleep(time.Second)
+
+				return nil
+			},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		_engine, err := New(
+			WithPipeline(testSteps()),
+			WithStopTimeout(100*time.Millisecond),
+		)
+		if err != nil {
+			t.Errorf("unable to create executor engine: %v", err)
+		}
+
+		_engine.stream(_service, test.fn)
+
+		err = _engine.flushStream(_service)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("flushStream should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("flushStream returned err: %v", err)
+		}
+
+		if _, ok := _engine.streamers.Load(_service.ID); ok {
+			t.Errorf("flushStream did not remove the stream")
+		}
+	}
+}
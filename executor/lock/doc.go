@@ -0,0 +1,11 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+// Package lock provides the ability for Vela to run only
+// one member of a named group at a time.
+//
+// Usage:
+//
+// 	import "github.com/go-vela/pkg-executor/executor/lock"
+package lock
@@ -0,0 +1,85 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package lock
+
+import (
+	"context"
+	"sync"
+)
+
+// Registry represents the named lock groups that is safe
+// to share between multiple engines in the same process.
+type Registry struct {
+	mu     sync.Mutex
+	groups map[string]chan struct{}
+}
+
+// NewRegistry creates a registry without any lock groups.
+func NewRegistry() *Registry {
+	return new(Registry)
+}
+
+// Lock blocks until the lock group, by name, is free and returns
+// the function releasing the lock group or an error once the
+// context provided has been canceled.
+func (r *Registry) Lock(ctx context.Context, name string) (func(), error) {
+	g := r.group(name)
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case g <- struct{}{}:
+		return release(g), nil
+	}
+}
+
+// TryLock takes the lock group, by name, without blocking and
+// returns the function releasing the lock group if it was free.
+func (r *Registry) TryLock(name string) (func(), bool) {
+	g := r.group(name)
+
+	select {
+	case g <- struct{}{}:
+		return release(g), true
+	default:
+		return nil, false
+	}
+}
+
+// Locked returns if the lock group, by name, is taken.
+func (r *Registry) Locked(name string) bool {
+	return len(r.group(name)) > 0
+}
+
+// group returns the lock group, by name,
+// creating it when it does not exist yet.
+func (r *Registry) group(name string) chan struct{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// check if the lock groups have been initialized
+	if r.groups == nil {
+		r.groups = make(map[string]chan struct{})
+	}
+
+	g, ok := r.groups[name]
+	if !ok {
+		g = make(chan struct{}, 1)
+
+		r.groups[name] = g
+	}
+
+	return g
+}
+
+// release returns the function releasing the lock
+// group that is safe to call more than once.
+func release(g chan struct{}) func() {
+	var once sync.Once
+
+	return func() {
+		once.Do(func() { <-g })
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package lock
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLock_Registry_Lock(t *testing.T) {
+	// setup types
+	r := NewRegistry()
+
+	// run test
+	unlock, err := r.Lock(context.Background(), "db")
+	if err != nil {
+		t.Errorf("Lock returned err: %v", err)
+	}
+
+	if !r.Locked("db") {
+		t.Errorf("Locked is false, want true")
+	}
+
+	// other lock groups are not affected
+	other, ok := r.TryLock("cache")
+	if !ok {
+		t.Errorf("TryLock is false, want true")
+	}
+
+	other()
+
+	_, ok = r.TryLock("db")
+	if ok {
+		t.Errorf("TryLock is true, want false")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = r.Lock(ctx, "db")
+	if err == nil {
+		t.Errorf("Lock should have returned err")
+	}
+
+	// releasing the lock group more than once only frees it once
+	unlock()
+	unlock()
+
+	if r.Locked("db") {
+		t.Errorf("Locked is true, want false")
+	}
+
+	unlock, ok = r.TryLock("db")
+	if !ok {
+		t.Errorf("TryLock is false, want true")
+	}
+
+	unlock()
+}
+
+func TestLock_Registry_Wait(t *testing.T) {
+	// setup types
+	r := NewRegistry()
+
+	unlock, _ := r.TryLock("db")
+
+	done := make(chan error)
+
+	// run test
+	go func() {
+		next, err := r.Lock(context.Background(), "db")
+		if err == nil {
+			next()
+		}
+
+		done <- err
+	}()
+
+	unlock()
+
+	err := <-done
+	if err != nil {
+		t.Errorf("Lock returned err: %v", err)
+	}
+}
@@ -0,0 +1,11 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+// Package mirror provides the ability for Vela to rewrite
+// the images for containers to internal registry mirrors.
+//
+// Usage:
+//
+// 	import "github.com/go-vela/pkg-executor/executor/mirror"
+package mirror
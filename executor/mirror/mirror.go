@@ -0,0 +1,127 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package mirror
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// fallbackOption defines the option for a rule where the
+	// original image is pulled when the mirror can not be.
+	fallbackOption = "fallback"
+)
+
+// Rule represents the rewrite of the images
+// starting with a prefix to a registry mirror.
+type Rule struct {
+	// Prefix is the start of the images rewritten by the rule.
+	Prefix string
+	// Replacement is the start of the images on the mirror.
+	Replacement string
+	// Fallback is true when the original image is
+	// pulled if the image on the mirror can not be.
+	Fallback bool
+}
+
+// Rules represents the rules rewriting images to registry mirrors.
+type Rules []*Rule
+
+// Parse returns the rules from the values provided, each in the
+// form prefix=replacement with an optional ;fallback suffix, or
+// an error if any of the values provided is invalid.
+func Parse(values []string) (Rules, error) {
+	rules := Rules{}
+
+	for _, value := range values {
+		// check if the value is empty
+		if len(strings.TrimSpace(value)) == 0 {
+			continue
+		}
+
+		r := new(Rule)
+
+		// capture the options for the rule
+		parts := strings.Split(value, ";")
+
+		for _, option := range parts[1:] {
+			switch strings.TrimSpace(option) {
+			case fallbackOption:
+				r.Fallback = true
+			default:
+				return nil, fmt.Errorf("unsupported option %s for mirror rule %s", option, value)
+			}
+		}
+
+		// capture the prefix and replacement for the rule
+		mapping := strings.SplitN(parts[0], "=", 2)
+		if len(mapping) != 2 {
+			return nil, fmt.Errorf("invalid mirror rule %s: want prefix=replacement", value)
+		}
+
+		r.Prefix = strings.TrimSpace(mapping[0])
+		r.Replacement = strings.TrimSpace(mapping[1])
+
+		// check if the prefix or replacement is empty
+		if len(r.Prefix) == 0 || len(r.Replacement) == 0 {
+			return nil, fmt.Errorf("invalid mirror rule %s: empty prefix or replacement", value)
+		}
+
+		rules = append(rules, r)
+	}
+
+	return rules, nil
+}
+
+// Rewrite returns the image rewritten by the rule with the longest
+// prefix matching the image, along with the rule, or the image as
+// is when no rule matches.
+//
+// Images without a registry, like alpine:latest, are also
+// matched in their full form, like docker.io/library/alpine:latest.
+func (r Rules) Rewrite(image string) (string, *Rule) {
+	// iterate through the image and the full form of the image
+	for _, name := range []string{image, Normalize(image)} {
+		var match *Rule
+
+		// iterate through all rules to find the longest prefix
+		for _, rule := range r {
+			if !strings.HasPrefix(name, rule.Prefix) {
+				continue
+			}
+
+			if match == nil || len(rule.Prefix) > len(match.Prefix) {
+				match = rule
+			}
+		}
+
+		// check if a rule matched the image
+		if match != nil {
+			return match.Replacement + strings.TrimPrefix(name, match.Prefix), match
+		}
+	}
+
+	return image, nil
+}
+
+// Normalize returns the full form of the image, including
+// the registry and repository, for images on Docker Hub.
+func Normalize(image string) string {
+	i := strings.Index(image, "/")
+
+	// check if the image has no repository
+	if i < 0 {
+		return "docker.io/library/" + image
+	}
+
+	// check if the first part of the image names a registry
+	registry := image[:i]
+	if strings.ContainsAny(registry, ".:") || registry == "localhost" {
+		return image
+	}
+
+	return "docker.io/" + image
+}
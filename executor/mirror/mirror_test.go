@@ -0,0 +1,164 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package mirror
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMirror_Parse(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		failure bool
+		values  []string
+		want    Rules
+	}{
+		{ // rules with and without fallback
+			failure: false,
+			values: []string{
+				"docker.io/=mirror.example.com/docker/",
+				" ghcr.io/ = mirror.example.com/ghcr/ ;fallback",
+				"",
+			},
+			want: Rules{
+				{Prefix: "docker.io/", Replacement: "mirror.example.com/docker/"},
+				{Prefix: "ghcr.io/", Replacement: "mirror.example.com/ghcr/", Fallback: true},
+			},
+		},
+		{ // no rules
+			failure: false,
+			values:  nil,
+			want:    Rules{},
+		},
+		{ // rule without replacement
+			failure: true,
+			values:  []string{"docker.io/"},
+		},
+		{ // rule with empty prefix
+			failure: true,
+			values:  []string{"=mirror.example.com/docker/"},
+		},
+		{ // rule with unsupported option
+			failure: true,
+			values:  []string{"docker.io/=mirror.example.com/docker/;retry"},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got, err := Parse(test.values)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("Parse should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Parse returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Parse is %v, want %v", got, test.want)
+		}
+	}
+}
+
+func TestMirror_Rules_Rewrite(t *testing.T) {
+	// setup types
+	rules := Rules{
+		{Prefix: "docker.io/", Replacement: "mirror.example.com/docker/"},
+		{Prefix: "docker.io/library/", Replacement: "mirror.example.com/official/"},
+		{Prefix: "ghcr.io/", Replacement: "mirror.example.com/ghcr/", Fallback: true},
+	}
+
+	// setup tests
+	tests := []struct {
+		image string
+		want  string
+		rule  *Rule
+	}{
+		{
+			image: "docker.io/target/vela-git:v0.4.0",
+			want:  "mirror.example.com/docker/target/vela-git:v0.4.0",
+			rule:  rules[0],
+		},
+		{
+			image: "target/vela-git:v0.4.0",
+			want:  "mirror.example.com/docker/target/vela-git:v0.4.0",
+			rule:  rules[0],
+		},
+		{
+			image: "alpine:latest",
+			want:  "mirror.example.com/official/alpine:latest",
+			rule:  rules[1],
+		},
+		{
+			image: "ghcr.io/go-vela/server:latest",
+			want:  "mirror.example.com/ghcr/go-vela/server:latest",
+			rule:  rules[2],
+		},
+		{
+			image: "quay.io/coreos/etcd:latest",
+			want:  "quay.io/coreos/etcd:latest",
+			rule:  nil,
+		},
+		{
+			image: "localhost:5000/alpine:latest",
+			want:  "localhost:5000/alpine:latest",
+			rule:  nil,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got, rule := rules.Rewrite(test.image)
+
+		if got != test.want {
+			t.Errorf("Rewrite is %s, want %s", got, test.want)
+		}
+
+		if rule != test.rule {
+			t.Errorf("Rewrite rule is %v, want %v", rule, test.rule)
+		}
+	}
+}
+
+func TestMirror_Normalize(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		image string
+		want  string
+	}{
+		{
+			image: "alpine:latest",
+			want:  "docker.io/library/alpine:latest",
+		},
+		{
+			image: "target/vela-git:v0.4.0",
+			want:  "docker.io/target/vela-git:v0.4.0",
+		},
+		{
+			image: "ghcr.io/go-vela/server:latest",
+			want:  "ghcr.io/go-vela/server:latest",
+		},
+		{
+			image: "localhost/alpine:latest",
+			want:  "localhost/alpine:latest",
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got := Normalize(test.image)
+
+		if got != test.want {
+			t.Errorf("Normalize is %s, want %s", got, test.want)
+		}
+	}
+}
@@ -0,0 +1,11 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+// Package policy provides the ability for Vela to restrict
+// the containers a build is allowed to run.
+//
+// Usage:
+//
+// 	import "github.com/go-vela/pkg-executor/executor/policy"
+package policy
@@ -0,0 +1,285 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package policy
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/go-vela/pkg-executor/executor/mirror"
+
+	"github.com/go-vela/types/library"
+	"github.com/go-vela/types/pipeline"
+)
+
+const (
+	// PrivilegedAlways defines the privileged policy
+	// where every repo may run privileged containers.
+	PrivilegedAlways = "always"
+
+	// PrivilegedTrusted defines the privileged policy where
+	// only trusted repos may run privileged containers.
+	PrivilegedTrusted = "trusted"
+
+	// PrivilegedNever defines the privileged policy
+	// where no repo may run privileged containers.
+	PrivilegedNever = "never"
+)
+
+// Policy represents the restrictions on the
+// containers a build is allowed to run.
+type Policy struct {
+	// AllowedImages are the patterns for the images containers
+	// may run, where every image is allowed when none are set.
+	AllowedImages []string
+	// DeniedImages are the patterns for the images
+	// containers may not run, checked after allowed images.
+	DeniedImages []string
+	// Privileged is the policy for the repos
+	// allowed to run privileged containers.
+	Privileged string
+	// AllowedVolumes are the patterns for the host paths containers
+	// may mount, where every path is allowed when none are set.
+	AllowedVolumes []string
+	// MaxServices is the number of services a
+	// build may run, where zero is no limit.
+	MaxServices int
+	// MaxSteps is the number of steps, including the steps
+	// for stages, a build may run, where zero is no limit.
+	MaxSteps int
+
+	// patterns are the regular expressions, by pattern, compiled
+	// from the image and volume patterns once the policy is validated.
+	patterns map[string]*regexp.Regexp
+}
+
+// Violation represents a container, or the
+// build, that does not comply with the policy.
+type Violation struct {
+	// Kind is the kind of resource, like step or service.
+	Kind string
+	// Name is the name of the resource.
+	Name string
+	// Reason explains how the resource violates the policy.
+	Reason string
+}
+
+// String returns the explanation for the violation.
+func (v *Violation) String() string {
+	// check if the violation is for the build
+	if len(v.Name) == 0 {
+		return fmt.Sprintf("%s: %s", v.Kind, v.Reason)
+	}
+
+	return fmt.Sprintf("%s %s: %s", v.Kind, v.Name, v.Reason)
+}
+
+// Validate returns an error if the policy is invalid and
+// compiles the image and volume patterns for the policy.
+func (p *Policy) Validate() error {
+	// check if the policy is empty
+	if p == nil {
+		return nil
+	}
+
+	switch p.Privileged {
+	case "", PrivilegedAlways, PrivilegedTrusted, PrivilegedNever:
+	default:
+		return fmt.Errorf("unsupported privileged policy %s", p.Privileged)
+	}
+
+	// check if the max services or steps is invalid
+	if p.MaxServices < 0 || p.MaxSteps < 0 {
+		return fmt.Errorf("invalid max services %d or steps %d", p.MaxServices, p.MaxSteps)
+	}
+
+	patterns := make(map[string]*regexp.Regexp)
+
+	// iterate through all image and volume patterns for the policy
+	for _, group := range [][]string{p.AllowedImages, p.DeniedImages, p.AllowedVolumes} {
+		for _, pattern := range group {
+			expr, err := compile(pattern)
+			if err != nil {
+				return fmt.Errorf("invalid pattern %s: %w", pattern, err)
+			}
+
+			patterns[pattern] = expr
+		}
+	}
+
+	p.patterns = patterns
+
+	return nil
+}
+
+// Check returns the violations of the policy
+// for the pipeline from the repo provided.
+func (p *Policy) Check(b *pipeline.Build, r *library.Repo) []*Violation {
+	violations := []*Violation{}
+
+	// check if the policy or pipeline is empty
+	if p == nil || b == nil {
+		return violations
+	}
+
+	steps := pipeline.ContainerSlice{}
+
+	// capture the steps for the pipeline
+	for _, s := range b.Steps {
+		// TODO: remove hardcoded reference
+		if s.Name == "init" {
+			continue
+		}
+
+		steps = append(steps, s)
+	}
+
+	// capture the steps for the stages
+	for _, s := range b.Stages {
+		// TODO: remove hardcoded reference
+		if s.Name == "init" {
+			continue
+		}
+
+		steps = append(steps, s.Steps...)
+	}
+
+	// check if the pipeline has too many services
+	if p.MaxServices > 0 && len(b.Services) > p.MaxServices {
+		violations = append(violations, &Violation{
+			Kind:   "build",
+			Reason: fmt.Sprintf("%d services exceed the maximum of %d", len(b.Services), p.MaxServices),
+		})
+	}
+
+	// check if the pipeline has too many steps
+	if p.MaxSteps > 0 && len(steps) > p.MaxSteps {
+		violations = append(violations, &Violation{
+			Kind:   "build",
+			Reason: fmt.Sprintf("%d steps exceed the maximum of %d", len(steps), p.MaxSteps),
+		})
+	}
+
+	// check the services for the pipeline
+	for _, ctn := range b.Services {
+		violations = append(violations, p.container("service", ctn, r)...)
+	}
+
+	// check the steps for the pipeline
+	for _, ctn := range steps {
+		violations = append(violations, p.container("step", ctn, r)...)
+	}
+
+	// check the secrets for the pipeline
+	for _, s := range b.Secrets {
+		// skip over non-plugin secrets
+		if s.Origin.Empty() {
+			continue
+		}
+
+		violations = append(violations, p.container("secret", s.Origin, r)...)
+	}
+
+	return violations
+}
+
+// CheckContainer returns the violations of the policy for a
+// container, of the kind provided, that the executor runs for
+// the pipeline from the repo provided, like the readiness probe
+// for a service, rather than a container from the pipeline.
+func (p *Policy) CheckContainer(kind string, ctn *pipeline.Container, r *library.Repo) []*Violation {
+	// check if the policy or container is empty
+	if p == nil || ctn == nil {
+		return []*Violation{}
+	}
+
+	return p.container(kind, ctn, r)
+}
+
+// container returns the violations of the
+// policy for the container provided.
+func (p *Policy) container(kind string, ctn *pipeline.Container, r *library.Repo) []*Violation {
+	violations := []*Violation{}
+
+	violation := func(format string, a ...interface{}) {
+		violations = append(violations, &Violation{Kind: kind, Name: ctn.Name, Reason: fmt.Sprintf(format, a...)})
+	}
+
+	// check if the image is not allowed
+	if len(p.AllowedImages) > 0 && len(p.matchImage(p.AllowedImages, ctn.Image)) == 0 {
+		violation("image %s is not allowed", ctn.Image)
+	}
+
+	// check if the image is denied
+	if pattern := p.matchImage(p.DeniedImages, ctn.Image); len(pattern) > 0 {
+		violation("image %s is denied by pattern %s", ctn.Image, pattern)
+	}
+
+	// check if the container is privileged
+	if ctn.Privileged {
+		switch p.Privileged {
+		case PrivilegedNever:
+			violation("privileged containers are not allowed")
+		case PrivilegedTrusted:
+			// check if the repo is trusted
+			if !r.GetTrusted() {
+				violation("privileged containers are only allowed for trusted repos")
+			}
+		}
+	}
+
+	// check if any volume is not allowed
+	if len(p.AllowedVolumes) > 0 {
+		for _, v := range ctn.Volumes {
+			// capture the host path for the volume
+			source := strings.SplitN(v, ":", 2)[0]
+
+			if len(p.match(p.AllowedVolumes, source)) == 0 {
+				violation("volume %s is not allowed", source)
+			}
+		}
+	}
+
+	return violations
+}
+
+// matchImage returns the first pattern matching the image, or
+// the full form of the image, from the patterns provided.
+func (p *Policy) matchImage(patterns []string, image string) string {
+	// check if the pattern matches the image
+	if pattern := p.match(patterns, image); len(pattern) > 0 {
+		return pattern
+	}
+
+	// https://pkg.go.dev/github.com/go-vela/pkg-executor/executor/mirror#Normalize
+	return p.match(patterns, mirror.Normalize(image))
+}
+
+// match returns the first pattern matching the value from the
+// patterns provided, where a * in a pattern matches anything.
+func (p *Policy) match(patterns []string, value string) string {
+	for _, pattern := range patterns {
+		expr, ok := p.patterns[pattern]
+		if !ok {
+			// compile the pattern for a policy that was not validated
+			expr, _ = compile(pattern)
+		}
+
+		if expr != nil && expr.MatchString(value) {
+			return pattern
+		}
+	}
+
+	return ""
+}
+
+// compile converts the pattern, where a * matches
+// anything, to an anchored regular expression.
+func compile(pattern string) (*regexp.Regexp, error) {
+	expr := strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*")
+
+	return regexp.Compile("^" + expr + "$")
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package policy
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-vela/types/library"
+	"github.com/go-vela/types/pipeline"
+)
+
+func TestPolicy_Validate(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		failure bool
+		policy  *Policy
+	}{
+		{
+			failure: false,
+			policy:  nil,
+		},
+		{
+			failure: false,
+			policy:  &Policy{Privileged: PrivilegedTrusted, MaxServices: 2, MaxSteps: 10},
+		},
+		{
+			failure: true,
+			policy:  &Policy{Privileged: "sometimes"},
+		},
+		{
+			failure: true,
+			policy:  &Policy{MaxSteps: -1},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := test.policy.Validate()
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("Validate should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Validate returned err: %v", err)
+		}
+	}
+}
+
+func TestPolicy_Check(t *testing.T) {
+	// setup types
+	_repo := new(library.Repo)
+	_repo.SetTrusted(false)
+
+	_pipeline := &pipeline.Build{
+		ID: "github_octocat_1",
+		Services: pipeline.ContainerSlice{
+			{Name: "postgres", Image: "postgres:12-alpine"},
+		},
+		Steps: pipeline.ContainerSlice{
+			{Name: "init", Image: "#init"},
+			{Name: "clone", Image: "target/vela-git:v0.4.0"},
+			{Name: "docker", Image: "ghcr.io/octocat/docker:latest", Privileged: true},
+			{Name: "cache", Image: "alpine:latest", Volumes: []string{"/var/cache:/cache", "/tmp"}},
+		},
+	}
+
+	// setup tests
+	tests := []struct {
+		policy *Policy
+		want   []string
+	}{
+		{ // empty policy
+			policy: nil,
+			want:   []string{},
+		},
+		{ // permissive policy
+			policy: &Policy{Privileged: PrivilegedAlways},
+			want:   []string{},
+		},
+		{ // restrictive policy
+			policy: &Policy{
+				AllowedImages:  []string{"docker.io/*"},
+				DeniedImages:   []string{"docker.io/library/postgres:*"},
+				Privileged:     PrivilegedTrusted,
+				AllowedVolumes: []string{"/tmp"},
+				MaxServices:    1,
+				MaxSteps:       2,
+			},
+			want: []string{
+				"build: 3 steps exceed the maximum of 2",
+				"service postgres: image postgres:12-alpine is denied by pattern docker.io/library/postgres:*",
+				"step docker: image ghcr.io/octocat/docker:latest is not allowed",
+				"step docker: privileged containers are only allowed for trusted repos",
+				"step cache: volume /var/cache is not allowed",
+			},
+		},
+		{ // policy denying privileged containers
+			policy: &Policy{Privileged: PrivilegedNever},
+			want: []string{
+				"step docker: privileged containers are not allowed",
+			},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := test.policy.Validate()
+		if err != nil {
+			t.Errorf("Validate returned err: %v", err)
+		}
+
+		got := []string{}
+
+		for _, v := range test.policy.Check(_pipeline, _repo) {
+			got = append(got, v.String())
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Check is %v, want %v", got, test.want)
+		}
+	}
+}
+
+func TestPolicy_CheckContainer(t *testing.T) {
+	// setup types
+	_probe := &pipeline.Container{Name: "probe-postgres", Image: "alpine:latest"}
+
+	// setup tests
+	tests := []struct {
+		policy *Policy
+		want   []string
+	}{
+		{ // empty policy
+			policy: nil,
+			want:   []string{},
+		},
+		{ // policy allowing images from a mirror
+			policy: &Policy{AllowedImages: []string{"mirror.example.com/*"}},
+			want: []string{
+				"probe probe-postgres: image alpine:latest is not allowed",
+			},
+		},
+		{ // policy allowing the image
+			policy: &Policy{AllowedImages: []string{"docker.io/library/alpine:*"}},
+			want:   []string{},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := test.policy.Validate()
+		if err != nil {
+			t.Errorf("Validate returned err: %v", err)
+		}
+
+		got := []string{}
+
+		for _, v := range test.policy.CheckContainer("probe", _probe, new(library.Repo)) {
+			got = append(got, v.String())
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("CheckContainer is %v, want %v", got, test.want)
+		}
+	}
+}
@@ -7,11 +7,16 @@ package executor
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/go-vela/sdk-go/vela"
 
+	"github.com/go-vela/pkg-executor/executor/limit"
 	"github.com/go-vela/pkg-executor/executor/linux"
 	"github.com/go-vela/pkg-executor/executor/local"
+	"github.com/go-vela/pkg-executor/executor/lock"
+	"github.com/go-vela/pkg-executor/executor/mirror"
+	"github.com/go-vela/pkg-executor/executor/policy"
 
 	"github.com/go-vela/pkg-runtime/runtime"
 
@@ -38,6 +43,38 @@ type Setup struct {
 	Client *vela.Client
 	// engine used for creating runtime resources
 	Runtime runtime.Engine
+	// specifies the signal sent to containers when canceled
+	StopSignal string
+	// specifies the grace period before containers are killed
+	StopTimeout time.Duration
+	// specifies the time a gate waits for a manual approval
+	ApprovalTimeout time.Duration
+	// specifies the time the finalizers are given to run
+	FinalizerTimeout time.Duration
+	// specifies the time the services are given to be ready
+	ReadinessTimeout time.Duration
+	// specifies the image running the service readiness probes
+	ProbeImage string
+	// specifies if a failed stage stops every other stage
+	StageFailure string
+	// specifies the number of stages allowed to run at once
+	MaxStages int
+	// specifies the number of images pulled and services started at once
+	MaxPulls int
+	// specifies the number of times a failed image pull is retried
+	PullRetries int
+	// specifies the time waited before the first image pull retry
+	PullBackoff time.Duration
+	// specifies the time each attempt to pull an image is given
+	PullTimeout time.Duration
+	// rules rewriting the images for containers to registry mirrors
+	Mirrors mirror.Rules
+	// policy restricting the containers the build may run
+	Policy *policy.Policy
+	// semaphore limiting the containers running on the host
+	ContainerLimit *limit.Semaphore
+	// registry of the lock groups shared between builds
+	LockRegistry *lock.Registry
 
 	// Vela Resource Configuration
 
@@ -68,11 +105,27 @@ func (s *Setup) Linux() (Engine, error) {
 	//
 	// https://pkg.go.dev/github.com/go-vela/pkg-executor/executor/linux?tab=doc#New
 	return linux.New(
+		linux.WithApprovalTimeout(s.ApprovalTimeout),
 		linux.WithBuild(s.Build),
+		linux.WithContainerLimit(s.ContainerLimit),
+		linux.WithFinalizerTimeout(s.FinalizerTimeout),
 		linux.WithHostname(s.Hostname),
+		linux.WithLockRegistry(s.LockRegistry),
+		linux.WithMaxStages(s.MaxStages),
+		linux.WithMaxPulls(s.MaxPulls),
+		linux.WithPullRetries(s.PullRetries),
+		linux.WithPullBackoff(s.PullBackoff),
+		linux.WithPullTimeout(s.PullTimeout),
+		linux.WithMirrors(s.Mirrors),
+		linux.WithPolicy(s.Policy),
 		linux.WithPipeline(s.Pipeline),
+		linux.WithProbeImage(s.ProbeImage),
+		linux.WithReadinessTimeout(s.ReadinessTimeout),
 		linux.WithRepo(s.Repo),
 		linux.WithRuntime(s.Runtime),
+		linux.WithStageFailure(s.StageFailure),
+		linux.WithStopSignal(s.StopSignal),
+		linux.WithStopTimeout(s.StopTimeout),
 		linux.WithUser(s.User),
 		linux.WithVelaClient(s.Client),
 		linux.WithVersion(s.Version),
@@ -88,11 +141,27 @@ func (s *Setup) Local() (Engine, error) {
 	//
 	// https://pkg.go.dev/github.com/go-vela/pkg-executor/executor/local?tab=doc#New
 	return local.New(
+		local.WithApprovalTimeout(s.ApprovalTimeout),
 		local.WithBuild(s.Build),
+		local.WithContainerLimit(s.ContainerLimit),
+		local.WithFinalizerTimeout(s.FinalizerTimeout),
 		local.WithHostname(s.Hostname),
+		local.WithLockRegistry(s.LockRegistry),
+		local.WithMaxStages(s.MaxStages),
+		local.WithMaxPulls(s.MaxPulls),
+		local.WithPullRetries(s.PullRetries),
+		local.WithPullBackoff(s.PullBackoff),
+		local.WithPullTimeout(s.PullTimeout),
+		local.WithMirrors(s.Mirrors),
+		local.WithPolicy(s.Policy),
 		local.WithPipeline(s.Pipeline),
+		local.WithProbeImage(s.ProbeImage),
+		local.WithReadinessTimeout(s.ReadinessTimeout),
 		local.WithRepo(s.Repo),
 		local.WithRuntime(s.Runtime),
+		local.WithStageFailure(s.StageFailure),
+		local.WithStopSignal(s.StopSignal),
+		local.WithStopTimeout(s.StopTimeout),
 		local.WithUser(s.User),
 		local.WithVelaClient(s.Client),
 		local.WithVersion(s.Version),
@@ -0,0 +1,11 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+// Package status provides the summary Vela reports
+// for the progress of a build in execution.
+//
+// Usage:
+//
+// 	import "github.com/go-vela/pkg-executor/executor/status"
+package status
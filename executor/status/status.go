@@ -0,0 +1,61 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package status
+
+import (
+	"time"
+)
+
+// Summary represents a point in time copy of the
+// progress made by the executor for a build.
+type Summary struct {
+	Build    string   `json:"build"`
+	Phase    string   `json:"phase,omitempty"`
+	Paused   string   `json:"paused,omitempty"`
+	Running  []*Item  `json:"running"`
+	Queued   []string `json:"queued"`
+	Services []*Item  `json:"services"`
+	Steps    []*Item  `json:"steps"`
+
+	// Stages captures the outcome, by stage name, of each
+	// stage that has finished and the queued status of
+	// each stage waiting for a slot to run.
+	Stages map[string]string `json:"stages,omitempty"`
+
+	// Errors captures the error, by stage name,
+	// that each failed stage finished with.
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// Item represents the progress made by
+// the executor for a service or step.
+type Item struct {
+	Name    string `json:"name"`
+	Stage   string `json:"stage,omitempty"`
+	Status  string `json:"status"`
+	Elapsed int64  `json:"elapsed"`
+}
+
+// Elapsed returns the seconds that have passed from the
+// started timestamp to the finished timestamp, or until
+// now when the finished timestamp has not been set.
+func Elapsed(started, finished int64) int64 {
+	// check if the item has started
+	if started == 0 {
+		return 0
+	}
+
+	// check if the item has finished
+	if finished == 0 {
+		finished = time.Now().UTC().Unix()
+	}
+
+	// check if the timestamps are out of order
+	if finished < started {
+		return 0
+	}
+
+	return finished - started
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package status
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStatus_Elapsed(t *testing.T) {
+	// setup types
+	now := time.Now().UTC().Unix()
+
+	// setup tests
+	tests := []struct {
+		started  int64
+		finished int64
+		want     int64
+	}{
+		{ // item finished
+			started:  1563474077,
+			finished: 1563474087,
+			want:     10,
+		},
+		{ // item not started
+			started:  0,
+			finished: 0,
+			want:     0,
+		},
+		{ // item with timestamps out of order
+			started:  1563474087,
+			finished: 1563474077,
+			want:     0,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got := Elapsed(test.started, test.finished)
+
+		if got != test.want {
+			t.Errorf("Elapsed is %d, want %d", got, test.want)
+		}
+	}
+
+	// item still running
+	got := Elapsed(now-5, 0)
+	if got < 5 {
+		t.Errorf("Elapsed is %d, want at least %d", got, 5)
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package build
+
+import (
+	"fmt"
+
+	"github.com/go-vela/types/constants"
+)
+
+// transitions represents the statuses a build
+// is able to move to from its current status.
+//
+// A build moves from pending to running and then finishes
+// with a success, failure, error, canceled or killed status.
+// A failing build may still end in an error or be canceled
+// or killed, but every other finished status is final.
+var transitions = map[string][]string{
+	constants.StatusPending: {
+		constants.StatusRunning,
+		constants.StatusError,
+		constants.StatusCanceled,
+		constants.StatusKilled,
+	},
+	constants.StatusRunning: {
+		constants.StatusSuccess,
+		constants.StatusFailure,
+		constants.StatusError,
+		constants.StatusCanceled,
+		constants.StatusKilled,
+	},
+	constants.StatusFailure: {
+		constants.StatusError,
+		constants.StatusCanceled,
+		constants.StatusKilled,
+	},
+}
+
+// Transition returns an error if a build is not
+// able to move from one status to the other.
+func Transition(from, to string) error {
+	// check if the status is unchanged
+	if from == to {
+		return nil
+	}
+
+	// check if the build has no status
+	if len(from) == 0 {
+		from = constants.StatusPending
+	}
+
+	// check if the build is able to move to the status
+	for _, status := range transitions[from] {
+		if status == to {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid build status transition from %s to %s", from, to)
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package build
+
+import (
+	"testing"
+
+	"github.com/go-vela/types/constants"
+)
+
+func TestBuild_Transition(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		failure bool
+		from    string
+		to      string
+	}{
+		{ // unchanged status
+			failure: false,
+			from:    constants.StatusSuccess,
+			to:      constants.StatusSuccess,
+		},
+		{ // build without a status started
+			failure: false,
+			from:    "",
+			to:      constants.StatusRunning,
+		},
+		{ // pending build started
+			failure: false,
+			from:    constants.StatusPending,
+			to:      constants.StatusRunning,
+		},
+		{ // pending build killed
+			failure: false,
+			from:    constants.StatusPending,
+			to:      constants.StatusKilled,
+		},
+		{ // running build succeeded
+			failure: false,
+			from:    constants.StatusRunning,
+			to:      constants.StatusSuccess,
+		},
+		{ // running build failed
+			failure: false,
+			from:    constants.StatusRunning,
+			to:      constants.StatusFailure,
+		},
+		{ // running build canceled
+			failure: false,
+			from:    constants.StatusRunning,
+			to:      constants.StatusCanceled,
+		},
+		{ // failing build errored
+			failure: false,
+			from:    constants.StatusFailure,
+			to:      constants.StatusError,
+		},
+		{ // pending build succeeded
+			failure: true,
+			from:    constants.StatusPending,
+			to:      constants.StatusSuccess,
+		},
+		{ // failing build succeeded
+			failure: true,
+			from:    constants.StatusFailure,
+			to:      constants.StatusSuccess,
+		},
+		{ // errored build failed
+			failure: true,
+			from:    constants.StatusError,
+			to:      constants.StatusFailure,
+		},
+		{ // canceled build restarted
+			failure: true,
+			from:    constants.StatusCanceled,
+			to:      constants.StatusRunning,
+		},
+		{ // successful build canceled
+			failure: true,
+			from:    constants.StatusSuccess,
+			to:      constants.StatusCanceled,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := Transition(test.from, test.to)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("Transition from %s to %s should have returned err", test.from, test.to)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Transition from %s to %s returned err: %v", test.from, test.to, err)
+		}
+	}
+}
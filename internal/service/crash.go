@@ -0,0 +1,47 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-vela/types/pipeline"
+)
+
+const (
+	// CrashKey defines the reserved environment variable
+	// declaring the policy applied when the service exits
+	// before the build has finished.
+	CrashKey = "VELA_SERVICE_CRASH"
+
+	// CrashContinue defines the crash policy where the service
+	// is marked failed while the rest of the build continues.
+	CrashContinue = "continue"
+
+	// CrashFail defines the crash policy where the service
+	// is marked failed and the build is failed along with it.
+	CrashFail = "fail"
+
+	// CrashRestart defines the crash policy where the service
+	// is restarted until it exceeds the restarts allowed.
+	CrashRestart = "restart"
+)
+
+// CrashPolicy returns the crash policy declared by the service,
+// defaulting to continue when the service declares none, or an
+// error if the crash policy declared for the service is invalid.
+func CrashPolicy(c *pipeline.Container) (string, error) {
+	policy := strings.TrimSpace(c.Environment[CrashKey])
+
+	switch policy {
+	case "":
+		return CrashContinue, nil
+	case CrashContinue, CrashFail, CrashRestart:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("unsupported crash policy %s for service %s", policy, c.Name)
+	}
+}
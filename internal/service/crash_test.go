@@ -0,0 +1,69 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package service
+
+import (
+	"testing"
+
+	"github.com/go-vela/types/pipeline"
+)
+
+func TestService_CrashPolicy(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		failure bool
+		policy  string
+		want    string
+	}{
+		{
+			failure: false,
+			policy:  "",
+			want:    CrashContinue,
+		},
+		{
+			failure: false,
+			policy:  CrashContinue,
+			want:    CrashContinue,
+		},
+		{
+			failure: false,
+			policy:  CrashFail,
+			want:    CrashFail,
+		},
+		{
+			failure: false,
+			policy:  " restart ",
+			want:    CrashRestart,
+		},
+		{
+			failure: true,
+			policy:  "foo",
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got, err := CrashPolicy(&pipeline.Container{
+			Name:        "postgres",
+			Environment: map[string]string{CrashKey: test.policy},
+		})
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("CrashPolicy for %s should have returned err", test.policy)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("CrashPolicy for %s returned err: %v", test.policy, err)
+		}
+
+		if got != test.want {
+			t.Errorf("CrashPolicy for %s is %s, want %s", test.policy, got, test.want)
+		}
+	}
+}
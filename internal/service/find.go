@@ -0,0 +1,30 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package service
+
+import (
+	"fmt"
+
+	"github.com/go-vela/types/pipeline"
+)
+
+// Find attempts to capture the container representing
+// the service from the pipeline by its name.
+func Find(p *pipeline.Build, name string) (*pipeline.Container, error) {
+	// check if the pipeline provided is empty
+	if p == nil {
+		return nil, fmt.Errorf("empty pipeline provided")
+	}
+
+	// iterate through each service in the pipeline
+	for _, s := range p.Services {
+		// check if the service matches the name provided
+		if s.Name == name {
+			return s, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unable to find service %s", name)
+}
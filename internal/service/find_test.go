@@ -0,0 +1,73 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-vela/types/pipeline"
+)
+
+func TestService_Find(t *testing.T) {
+	// setup types
+	_service := &pipeline.Container{
+		ID:     "service_github_octocat_1_postgres",
+		Image:  "postgres:12-alpine",
+		Name:   "postgres",
+		Number: 1,
+	}
+
+	_pipeline := &pipeline.Build{
+		ID:       "github_octocat_1",
+		Services: pipeline.ContainerSlice{_service},
+	}
+
+	// setup tests
+	tests := []struct {
+		failure  bool
+		pipeline *pipeline.Build
+		name     string
+		want     *pipeline.Container
+	}{
+		{ // service in the pipeline
+			failure:  false,
+			pipeline: _pipeline,
+			name:     "postgres",
+			want:     _service,
+		},
+		{ // unknown service
+			failure:  true,
+			pipeline: _pipeline,
+			name:     "redis",
+		},
+		{ // empty pipeline
+			failure:  true,
+			pipeline: nil,
+			name:     "postgres",
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got, err := Find(test.pipeline, test.name)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("Find should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Find returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Find is %v, want %v", got, test.want)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-vela/types/pipeline"
+)
+
+const (
+	// ReadyKey defines the reserved environment variable
+	// declaring the probe that reports when the service is
+	// ready to accept connections from the steps for the
+	// build (i.e. "tcp:5432", "http:8080/health" or
+	// "cmd:pg_isready -h postgres").
+	ReadyKey = "VELA_READY"
+
+	// ProbeTCP defines the kind of probe that checks
+	// the service is listening on a port.
+	ProbeTCP = "tcp"
+
+	// ProbeHTTP defines the kind of probe that checks
+	// the service responds successfully to a request
+	// for an endpoint.
+	ProbeHTTP = "http"
+
+	// ProbeCommand defines the kind of probe that checks
+	// a command for the service exits with a zero code.
+	ProbeCommand = "cmd"
+)
+
+// Probe represents the readiness check declared for a service.
+type Probe struct {
+	Kind    string
+	Host    string
+	Port    int
+	Path    string
+	Command string
+}
+
+// Readiness returns the probe declared by the service, or nil
+// when the service declares none, or an error if the probe
+// declared for the service is invalid.
+func Readiness(c *pipeline.Container) (*Probe, error) {
+	value := strings.TrimSpace(c.Environment[ReadyKey])
+
+	// check if the service declares a probe
+	if len(value) == 0 {
+		return nil, nil
+	}
+
+	parts := strings.SplitN(value, ":", 2)
+
+	// check if the probe provides a target
+	if len(parts) != 2 || len(strings.TrimSpace(parts[1])) == 0 {
+		return nil, fmt.Errorf("readiness probe %s for service %s has no target", value, c.Name)
+	}
+
+	p := &Probe{
+		Kind: strings.TrimSpace(parts[0]),
+		Host: c.Name,
+	}
+
+	target := strings.TrimSpace(parts[1])
+
+	switch p.Kind {
+	case ProbeCommand:
+		p.Command = target
+
+		return p, nil
+	case ProbeTCP, ProbeHTTP:
+	default:
+		return nil, fmt.Errorf("unsupported readiness probe %s for service %s", p.Kind, c.Name)
+	}
+
+	port := target
+
+	// check if the probe provides an endpoint
+	if i := strings.Index(target, "/"); i >= 0 {
+		// check if the probe is able to request an endpoint
+		if p.Kind != ProbeHTTP {
+			return nil, fmt.Errorf("readiness probe %s for service %s does not support an endpoint", p.Kind, c.Name)
+		}
+
+		port = target[:i]
+		p.Path = target[i:]
+	}
+
+	// https://pkg.go.dev/strconv?tab=doc#Atoi
+	number, err := strconv.Atoi(port)
+	if err != nil || number <= 0 || number > 65535 {
+		return nil, fmt.Errorf("invalid port %s for readiness probe for service %s", port, c.Name)
+	}
+
+	p.Port = number
+
+	// check if the probe requests an endpoint
+	if p.Kind == ProbeHTTP && len(p.Path) == 0 {
+		p.Path = "/"
+	}
+
+	return p, nil
+}
+
+// String returns the probe as it is declared by the service.
+func (p *Probe) String() string {
+	switch p.Kind {
+	case ProbeCommand:
+		return fmt.Sprintf("%s:%s", p.Kind, p.Command)
+	case ProbeHTTP:
+		return fmt.Sprintf("%s:%d%s", p.Kind, p.Port, p.Path)
+	default:
+		return fmt.Sprintf("%s:%d", p.Kind, p.Port)
+	}
+}
+
+// Script returns the shell script run by the
+// probe container to check the service.
+func (p *Probe) Script() string {
+	switch p.Kind {
+	case ProbeCommand:
+		return p.Command
+	case ProbeHTTP:
+		return fmt.Sprintf("wget -q -O /dev/null http://%s:%d%s", p.Host, p.Port, p.Path)
+	default:
+		return fmt.Sprintf("nc -z -w 1 %s %d", p.Host, p.Port)
+	}
+}
+
+// Container returns the container, running the image provided
+// on the network for the build, that checks the service.
+func (p *Probe) Container(c *pipeline.Container, image string) *pipeline.Container {
+	return &pipeline.Container{
+		ID:          fmt.Sprintf("probe_%s", c.ID),
+		Directory:   c.Directory,
+		Entrypoint:  []string{"/bin/sh", "-c"},
+		Commands:    []string{p.Script()},
+		Environment: map[string]string{},
+		Image:       image,
+		Name:        fmt.Sprintf("probe-%s", c.Name),
+		Number:      c.Number,
+		Pull:        "not_present",
+	}
+}
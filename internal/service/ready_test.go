@@ -0,0 +1,149 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-vela/types/pipeline"
+)
+
+func TestService_Readiness(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		failure bool
+		ready   string
+		want    *Probe
+		script  string
+	}{
+		{
+			failure: false,
+			ready:   "tcp:5432",
+			want:    &Probe{Kind: ProbeTCP, Host: "postgres", Port: 5432},
+			script:  "nc -z -w 1 postgres 5432",
+		},
+		{
+			failure: false,
+			ready:   "http:8080/health",
+			want:    &Probe{Kind: ProbeHTTP, Host: "postgres", Port: 8080, Path: "/health"},
+			script:  "wget -q -O /dev/null http://postgres:8080/health",
+		},
+		{
+			failure: false,
+			ready:   "http:8080",
+			want:    &Probe{Kind: ProbeHTTP, Host: "postgres", Port: 8080, Path: "/"},
+			script:  "wget -q -O /dev/null http://postgres:8080/",
+		},
+		{
+			failure: false,
+			ready:   "cmd:pg_isready -h postgres",
+			want:    &Probe{Kind: ProbeCommand, Host: "postgres", Command: "pg_isready -h postgres"},
+			script:  "pg_isready -h postgres",
+		},
+		{
+			failure: false,
+			ready:   "",
+			want:    nil,
+		},
+		{
+			failure: true,
+			ready:   "tcp",
+		},
+		{
+			failure: true,
+			ready:   "udp:53",
+		},
+		{
+			failure: true,
+			ready:   "tcp:foo",
+		},
+		{
+			failure: true,
+			ready:   "tcp:70000",
+		},
+		{
+			failure: true,
+			ready:   "tcp:5432/health",
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got, err := Readiness(&pipeline.Container{
+			Name:        "postgres",
+			Environment: map[string]string{ReadyKey: test.ready},
+		})
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("Readiness for %s should have returned err", test.ready)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Readiness for %s returned err: %v", test.ready, err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Readiness for %s is %v, want %v", test.ready, got, test.want)
+		}
+
+		// check if a probe was declared
+		if got == nil {
+			continue
+		}
+
+		// check if the declared probe round trips through its string
+		if _, err := Readiness(&pipeline.Container{
+			Name:        "postgres",
+			Environment: map[string]string{ReadyKey: got.String()},
+		}); err != nil {
+			t.Errorf("Readiness for %s returned err: %v", got.String(), err)
+		}
+
+		if got.Script() != test.script {
+			t.Errorf("Script for %s is %s, want %s", test.ready, got.Script(), test.script)
+		}
+	}
+}
+
+func TestService_Probe_Container(t *testing.T) {
+	// setup types
+	_service := &pipeline.Container{
+		ID:          "service_github_octocat_1_postgres",
+		Directory:   "/vela/src/github.com/github/octocat",
+		Environment: map[string]string{ReadyKey: "tcp:5432"},
+		Image:       "postgres:12-alpine",
+		Name:        "postgres",
+		Number:      1,
+	}
+
+	want := &pipeline.Container{
+		ID:          "probe_service_github_octocat_1_postgres",
+		Directory:   "/vela/src/github.com/github/octocat",
+		Entrypoint:  []string{"/bin/sh", "-c"},
+		Commands:    []string{"nc -z -w 1 postgres 5432"},
+		Environment: map[string]string{},
+		Image:       "alpine:latest",
+		Name:        "probe-postgres",
+		Number:      1,
+		Pull:        "not_present",
+	}
+
+	// run test
+	p, err := Readiness(_service)
+	if err != nil {
+		t.Errorf("Readiness returned err: %v", err)
+	}
+
+	got := p.Container(_service, "alpine:latest")
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Container is %v, want %v", got, want)
+	}
+}
@@ -18,8 +18,9 @@ import (
 // Snapshot creates a moment in time record of the
 // service and attempts to upload it to the server.
 func Snapshot(ctn *pipeline.Container, b *library.Build, c *vela.Client, l *logrus.Entry, r *library.Repo, s *library.Service) {
-	// check if the build is not in a canceled status
-	if !strings.EqualFold(s.GetStatus(), constants.StatusCanceled) {
+	// check if the service is not in a canceled or error status
+	if !strings.EqualFold(s.GetStatus(), constants.StatusCanceled) &&
+		!strings.EqualFold(s.GetStatus(), constants.StatusError) {
 		// check if the container is running in headless mode
 		if !ctn.Detach {
 			// update the service fields to indicate a success
@@ -0,0 +1,11 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+// Package stage provides the ability for Vela to
+// manipulate and manage a stage from a pipeline.
+//
+// Usage:
+//
+// 	import "github.com/go-vela/pkg-executor/internal/stage"
+package stage
@@ -0,0 +1,55 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package stage
+
+import (
+	"errors"
+	"strings"
+)
+
+// Errors represents the errors, in pipeline
+// order, for the stages that failed.
+type Errors []error
+
+// Error returns the messages for every error.
+func (e Errors) Error() string {
+	messages := []string{}
+
+	// iterate through all errors
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// Is returns if any of the errors matches the target.
+//
+// https://pkg.go.dev/errors?tab=doc#Is
+func (e Errors) Is(target error) bool {
+	// iterate through all errors
+	for _, err := range e {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// As finds the first of the errors that matches
+// the target and sets the target to that error.
+//
+// https://pkg.go.dev/errors?tab=doc#As
+func (e Errors) As(target interface{}) bool {
+	// iterate through all errors
+	for _, err := range e {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
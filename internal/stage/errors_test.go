@@ -0,0 +1,41 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package stage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestStage_Errors(t *testing.T) {
+	// setup types
+	var err error = Errors{
+		errors.New("build stage: unable to exec step build"),
+		fmt.Errorf("test stage: %w", context.Canceled),
+	}
+
+	want := "build stage: unable to exec step build; test stage: context canceled"
+
+	// run test
+	if err.Error() != want {
+		t.Errorf("Error is %s, want %s", err.Error(), want)
+	}
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Is is false, want true")
+	}
+
+	if errors.Is(err, ErrNotRun) {
+		t.Errorf("Is is true, want false")
+	}
+
+	var target interface{ Unwrap() error }
+
+	if !errors.As(err, &target) {
+		t.Errorf("As is false, want true")
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package stage
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Load attempts to capture the tracker
+// for the stage, by name, from the map.
+func Load(name string, m *sync.Map) (*Tracker, error) {
+	// check if the map provided is empty
+	if m == nil {
+		return nil, fmt.Errorf("empty map provided")
+	}
+
+	// load the stage name as the tracker key from the map
+	result, ok := m.Load(name)
+	if !ok {
+		return nil, fmt.Errorf("unable to load stage %s", name)
+	}
+
+	// cast the value from the tracker key to the expected type
+	t, ok := result.(*Tracker)
+	if !ok {
+		return nil, fmt.Errorf("unable to cast value for stage %s", name)
+	}
+
+	return t, nil
+}
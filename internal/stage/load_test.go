@@ -0,0 +1,76 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package stage
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestStage_Load(t *testing.T) {
+	// setup types
+	_tracker := NewTracker()
+
+	goodMap := new(sync.Map)
+	goodMap.Store("test", _tracker)
+
+	badMap := new(sync.Map)
+	badMap.Store("test", make(chan error))
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		name    string
+		_map    *sync.Map
+		want    *Tracker
+	}{
+		{
+			failure: false,
+			name:    "test",
+			_map:    goodMap,
+			want:    _tracker,
+		},
+		{
+			failure: true,
+			name:    "test",
+			_map:    badMap,
+			want:    nil,
+		},
+		{
+			failure: true,
+			name:    "test",
+			_map:    new(sync.Map),
+			want:    nil,
+		},
+		{
+			failure: true,
+			name:    "test",
+			_map:    nil,
+			want:    nil,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got, err := Load(test.name, test._map)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("Load should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Load returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Load is %v, want %v", got, test.want)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package stage
+
+import (
+	"fmt"
+
+	"github.com/go-vela/types/pipeline"
+)
+
+const (
+	// PolicyKey defines the reserved environment variable, set on
+	// any step for a stage, overriding the failure policy of the
+	// build for the stage.
+	PolicyKey = "VELA_STAGE_FAILURE"
+
+	// FailFast defines the failure policy where a failed
+	// stage stops every other stage for the build.
+	FailFast = "fail-fast"
+
+	// ContinueIndependent defines the failure policy where a
+	// failed stage only skips the stages that need it, while
+	// every other stage for the build finishes.
+	ContinueIndependent = "continue-independent"
+)
+
+// ValidatePolicy returns an error if the
+// failure policy provided is not supported.
+func ValidatePolicy(policy string) error {
+	switch policy {
+	case FailFast, ContinueIndependent:
+		return nil
+	default:
+		return fmt.Errorf("unsupported stage failure policy %s", policy)
+	}
+}
+
+// Policy returns the failure policy set by a step for the
+// stage or the failure policy provided for the build.
+func Policy(s *pipeline.Stage, policy string) string {
+	// iterate through all steps for the stage
+	for _, _step := range s.Steps {
+		// capture the failure policy set by the step
+		p := _step.Environment[PolicyKey]
+
+		// check if the step sets a supported failure policy
+		if ValidatePolicy(p) == nil {
+			return p
+		}
+	}
+
+	return policy
+}
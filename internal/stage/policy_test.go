@@ -0,0 +1,100 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package stage
+
+import (
+	"testing"
+
+	"github.com/go-vela/types/pipeline"
+)
+
+func TestStage_ValidatePolicy(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		failure bool
+		policy  string
+	}{
+		{
+			failure: false,
+			policy:  FailFast,
+		},
+		{
+			failure: false,
+			policy:  ContinueIndependent,
+		},
+		{
+			failure: true,
+			policy:  "foo",
+		},
+		{
+			failure: true,
+			policy:  "",
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := ValidatePolicy(test.policy)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("ValidatePolicy should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("ValidatePolicy returned err: %v", err)
+		}
+	}
+}
+
+func TestStage_Policy(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		env    map[string]string
+		policy string
+		want   string
+	}{
+		{ // stage without a policy
+			env:    map[string]string{"FOO": "bar"},
+			policy: FailFast,
+			want:   FailFast,
+		},
+		{ // stage overriding the policy
+			env:    map[string]string{PolicyKey: ContinueIndependent},
+			policy: FailFast,
+			want:   ContinueIndependent,
+		},
+		{ // stage overriding the policy
+			env:    map[string]string{PolicyKey: FailFast},
+			policy: ContinueIndependent,
+			want:   FailFast,
+		},
+		{ // stage with an unsupported policy
+			env:    map[string]string{PolicyKey: "foo"},
+			policy: ContinueIndependent,
+			want:   ContinueIndependent,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		s := &pipeline.Stage{
+			Name: "test",
+			Steps: pipeline.ContainerSlice{
+				{Name: "echo", Environment: map[string]string{}},
+				{Name: "test", Environment: test.env},
+			},
+		}
+
+		got := Policy(s, test.policy)
+
+		if got != test.want {
+			t.Errorf("Policy is %s, want %s", got, test.want)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package stage
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/go-vela/types/constants"
+)
+
+// StatusSkipped defines the outcome for a stage that
+// is not run since a stage it needs did not succeed.
+// Stages are only tracked by the executor, so this is
+// never uploaded to the server.
+const StatusSkipped = "skipped"
+
+// StatusQueued defines the status for a stage that is
+// waiting for a slot to run with the other stages.
+const StatusQueued = "queued"
+
+// ErrNotRun defines the error returned when a stage is
+// not run since a stage it needs did not succeed.
+var ErrNotRun = errors.New("stage not run")
+
+// Tracker represents the outcome of a stage
+// that the stages which need it wait on.
+type Tracker struct {
+	once   sync.Once
+	done   chan struct{}
+	status string
+	err    error
+}
+
+// NewTracker returns a Tracker for a stage
+// that has not finished yet.
+func NewTracker() *Tracker {
+	return &Tracker{done: make(chan struct{})}
+}
+
+// Finish records the outcome of the stage and wakes
+// everything waiting on it. Only the first outcome
+// recorded for the stage is kept.
+func (t *Tracker) Finish(status string, err error) {
+	t.once.Do(func() {
+		t.status = status
+		t.err = err
+
+		close(t.done)
+	})
+}
+
+// Done returns a channel that is closed
+// once the stage has finished.
+func (t *Tracker) Done() <-chan struct{} {
+	return t.done
+}
+
+// Finished returns if the stage has finished.
+func (t *Tracker) Finished() bool {
+	select {
+	case <-t.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Status returns the outcome of the stage
+// or an empty value if it has not finished.
+func (t *Tracker) Status() string {
+	// check if the stage has finished
+	if !t.Finished() {
+		return ""
+	}
+
+	return t.status
+}
+
+// Err returns the error the stage finished with,
+// if any, or nil if it has not finished.
+func (t *Tracker) Err() error {
+	// check if the stage has finished
+	if !t.Finished() {
+		return nil
+	}
+
+	return t.err
+}
+
+// Outcome returns the status for a stage
+// based off the error it finished with.
+func Outcome(err error) string {
+	switch {
+	case err == nil:
+		return constants.StatusSuccess
+	case errors.Is(err, ErrNotRun):
+		return StatusSkipped
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return constants.StatusCanceled
+	default:
+		return constants.StatusFailure
+	}
+}
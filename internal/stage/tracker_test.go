@@ -0,0 +1,84 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package stage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-vela/types/constants"
+)
+
+func TestStage_Tracker_Finish(t *testing.T) {
+	// setup types
+	_tracker := NewTracker()
+
+	// check the tracker before the stage finished
+	if _tracker.Finished() {
+		t.Errorf("Finished is true, want false")
+	}
+
+	if len(_tracker.Status()) > 0 {
+		t.Errorf("Status is %s, want empty", _tracker.Status())
+	}
+
+	// run test
+	_tracker.Finish(constants.StatusFailure, errors.New("test"))
+	_tracker.Finish(constants.StatusSuccess, nil)
+
+	select {
+	case <-_tracker.Done():
+	default:
+		t.Errorf("Done channel is open, want closed")
+	}
+
+	if _tracker.Status() != constants.StatusFailure {
+		t.Errorf("Status is %s, want %s", _tracker.Status(), constants.StatusFailure)
+	}
+
+	if _tracker.Err() == nil {
+		t.Errorf("Err is nil, want err")
+	}
+}
+
+func TestStage_Outcome(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{ // stage succeeded
+			err:  nil,
+			want: constants.StatusSuccess,
+		},
+		{ // stage not run
+			err:  fmt.Errorf("%w: needs test stage", ErrNotRun),
+			want: StatusSkipped,
+		},
+		{ // stage canceled
+			err:  fmt.Errorf("unable to exec stage: %w", context.Canceled),
+			want: constants.StatusCanceled,
+		},
+		{ // stage timed out
+			err:  context.DeadlineExceeded,
+			want: constants.StatusCanceled,
+		},
+		{ // stage failed
+			err:  errors.New("test"),
+			want: constants.StatusFailure,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got := Outcome(test.err)
+
+		if got != test.want {
+			t.Errorf("Outcome is %s, want %s", got, test.want)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package stage
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-vela/types/pipeline"
+)
+
+// Validate returns an error if a stage needs a stage
+// that is not in the pipeline or if the stages
+// need each other in a cycle.
+func Validate(stages pipeline.StageSlice) error {
+	// create a map of the stages by name
+	names := make(map[string]*pipeline.Stage, len(stages))
+
+	// iterate through all stages in the pipeline
+	for _, s := range stages {
+		// check if the stage name is already used
+		if _, ok := names[s.Name]; ok {
+			return fmt.Errorf("duplicate stage %s", s.Name)
+		}
+
+		names[s.Name] = s
+	}
+
+	// iterate through all stages in the pipeline
+	for _, s := range stages {
+		// iterate through all stages the stage needs
+		for _, needs := range s.Needs {
+			// check if the stage needed is in the pipeline
+			if _, ok := names[needs]; !ok {
+				return fmt.Errorf("stage %s needs unknown stage %s", s.Name, needs)
+			}
+		}
+	}
+
+	// track the stages that are visited
+	visited := make(map[string]bool, len(stages))
+
+	// iterate through all stages in the pipeline
+	for _, s := range stages {
+		// check the stages needed by the stage for a cycle
+		path := cycle(s, names, visited, []string{})
+		if len(path) > 0 {
+			return fmt.Errorf("stages need each other in a cycle: %s", strings.Join(path, " -> "))
+		}
+	}
+
+	return nil
+}
+
+// cycle returns the path of stage names forming a cycle through
+// the stage provided or an empty path if there is no cycle.
+//
+// The visited map records whether each stage is still on the
+// path being checked (true) or has been fully checked (false).
+func cycle(s *pipeline.Stage, names map[string]*pipeline.Stage, visited map[string]bool, path []string) []string {
+	path = append(path, s.Name)
+
+	// check if the stage has been visited
+	onPath, ok := visited[s.Name]
+	if ok {
+		// check if the stage is on the path being checked
+		if onPath {
+			// trim the path to start from the first visit of the stage
+			for i, name := range path {
+				if name == s.Name {
+					return path[i:]
+				}
+			}
+		}
+
+		return nil
+	}
+
+	visited[s.Name] = true
+
+	// iterate through all stages the stage needs
+	for _, needs := range s.Needs {
+		found := cycle(names[needs], names, visited, path)
+		if len(found) > 0 {
+			return found
+		}
+	}
+
+	visited[s.Name] = false
+
+	return nil
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package stage
+
+import (
+	"testing"
+
+	"github.com/go-vela/types/pipeline"
+)
+
+func TestStage_Validate(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		failure bool
+		stages  pipeline.StageSlice
+	}{
+		{ // stages without needs
+			failure: false,
+			stages: pipeline.StageSlice{
+				{Name: "clone"},
+				{Name: "build"},
+			},
+		},
+		{ // stages with needs
+			failure: false,
+			stages: pipeline.StageSlice{
+				{Name: "clone"},
+				{Name: "build", Needs: []string{"clone"}},
+				{Name: "test", Needs: []string{"clone"}},
+				{Name: "publish", Needs: []string{"build", "test"}},
+			},
+		},
+		{ // stages with duplicate names
+			failure: true,
+			stages: pipeline.StageSlice{
+				{Name: "build"},
+				{Name: "build"},
+			},
+		},
+		{ // stage needs unknown stage
+			failure: true,
+			stages: pipeline.StageSlice{
+				{Name: "clone"},
+				{Name: "build", Needs: []string{"foo"}},
+			},
+		},
+		{ // stage needs itself
+			failure: true,
+			stages: pipeline.StageSlice{
+				{Name: "build", Needs: []string{"build"}},
+			},
+		},
+		{ // stages need each other
+			failure: true,
+			stages: pipeline.StageSlice{
+				{Name: "clone"},
+				{Name: "build", Needs: []string{"clone", "publish"}},
+				{Name: "test", Needs: []string{"build"}},
+				{Name: "publish", Needs: []string{"test"}},
+			},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := Validate(test.stages)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("Validate should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Validate returned err: %v", err)
+		}
+	}
+}
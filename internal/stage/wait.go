@@ -0,0 +1,58 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package stage
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/go-vela/types/constants"
+	"github.com/go-vela/types/pipeline"
+)
+
+// Wait blocks until the stages needed by the stage have finished
+// and returns an error, wrapping ErrNotRun, for the first stage
+// needed that did not succeed in the order they are needed.
+//
+// A stage needed that failed is ignored when the function
+// provided reports the stage runs once a stage it needs fails.
+//
+// Stages without a tracker in the map are not scheduled,
+// so the stage does not wait on them.
+func Wait(ctx context.Context, s *pipeline.Stage, m *sync.Map, onFailure func(*pipeline.Stage) bool) error {
+	// iterate through all stages the stage needs
+	for _, needs := range s.Needs {
+		// load the tracker for the stage from the map
+		t, err := Load(needs, m)
+		if err != nil {
+			continue
+		}
+
+		// wait for the stage to finish
+		select {
+		case <-ctx.Done():
+		case <-t.Done():
+		}
+
+		// check if the stage has not finished
+		if !t.Finished() {
+			return fmt.Errorf("unable to wait for %s stage: %w", needs, ctx.Err())
+		}
+
+		// check if the stage failed and the stage still runs,
+		// unless the context was canceled to stop every stage
+		if t.Status() == constants.StatusFailure && ctx.Err() == nil && onFailure(s) {
+			continue
+		}
+
+		// check if the stage did not succeed
+		if t.Status() != constants.StatusSuccess {
+			return fmt.Errorf("%w: needs %s stage which finished with %s", ErrNotRun, needs, t.Status())
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package stage
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/go-vela/types/constants"
+	"github.com/go-vela/types/pipeline"
+)
+
+func TestStage_Wait(t *testing.T) {
+	// setup types
+	success := NewTracker()
+	success.Finish(constants.StatusSuccess, nil)
+
+	failure := NewTracker()
+	failure.Finish(constants.StatusFailure, errors.New("test"))
+
+	skipped := NewTracker()
+	skipped.Finish(StatusSkipped, ErrNotRun)
+
+	m := new(sync.Map)
+	m.Store("success", success)
+	m.Store("failure", failure)
+	m.Store("skipped", skipped)
+	m.Store("running", NewTracker())
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// setup tests
+	tests := []struct {
+		ctx       context.Context
+		needs     []string
+		onFailure bool
+		want      error
+	}{
+		{ // stage without needs
+			ctx:   context.Background(),
+			needs: nil,
+			want:  nil,
+		},
+		{ // stage needs stages that succeeded
+			ctx:   context.Background(),
+			needs: []string{"success", "init"},
+			want:  nil,
+		},
+		{ // stage needs stage that failed
+			ctx:   context.Background(),
+			needs: []string{"success", "failure"},
+			want:  ErrNotRun,
+		},
+		{ // stage running on failure needs stage that failed
+			ctx:       context.Background(),
+			needs:     []string{"success", "failure"},
+			onFailure: true,
+			want:      nil,
+		},
+		{ // stage running on failure needs stage that was skipped
+			ctx:       context.Background(),
+			needs:     []string{"failure", "skipped"},
+			onFailure: true,
+			want:      ErrNotRun,
+		},
+		{ // stage needs stage that was skipped
+			ctx:   context.Background(),
+			needs: []string{"skipped"},
+			want:  ErrNotRun,
+		},
+		{ // stage canceled while waiting
+			ctx:   canceled,
+			needs: []string{"running"},
+			want:  context.Canceled,
+		},
+		{ // stage canceled after stage needed failed
+			ctx:       canceled,
+			needs:     []string{"failure"},
+			onFailure: true,
+			want:      ErrNotRun,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		onFailure := func(*pipeline.Stage) bool { return test.onFailure }
+
+		err := Wait(test.ctx, &pipeline.Stage{Name: "test", Needs: test.needs}, m, onFailure)
+
+		if !errors.Is(err, test.want) {
+			t.Errorf("Wait is %v, want %v", err, test.want)
+		}
+	}
+}
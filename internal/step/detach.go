@@ -0,0 +1,44 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package step
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-vela/types/pipeline"
+)
+
+const (
+	// DetachKey defines the reserved environment variable
+	// declaring the policy applied when a detached step
+	// exits on its own with a failure.
+	DetachKey = "VELA_DETACH_FAILURE"
+
+	// DetachIgnore defines the detach policy where a failed
+	// detached step is recorded without failing the build.
+	DetachIgnore = "ignore"
+
+	// DetachFail defines the detach policy where a failed
+	// detached step fails the build, unless the step
+	// continues on failure.
+	DetachFail = "fail"
+)
+
+// DetachPolicy returns the detach policy declared by the step,
+// defaulting to ignore when the step declares none, or an error
+// if the detach policy declared for the step is invalid.
+func DetachPolicy(c *pipeline.Container) (string, error) {
+	policy := strings.TrimSpace(c.Environment[DetachKey])
+
+	switch policy {
+	case "":
+		return DetachIgnore, nil
+	case DetachIgnore, DetachFail:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("unsupported detach policy %s for step %s", policy, c.Name)
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package step
+
+import (
+	"testing"
+
+	"github.com/go-vela/types/pipeline"
+)
+
+func TestStep_DetachPolicy(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		failure bool
+		policy  string
+		want    string
+	}{
+		{
+			failure: false,
+			policy:  "",
+			want:    DetachIgnore,
+		},
+		{
+			failure: false,
+			policy:  DetachIgnore,
+			want:    DetachIgnore,
+		},
+		{
+			failure: false,
+			policy:  " fail ",
+			want:    DetachFail,
+		},
+		{
+			failure: true,
+			policy:  "foo",
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got, err := DetachPolicy(&pipeline.Container{
+			Name:        "redis",
+			Detach:      true,
+			Environment: map[string]string{DetachKey: test.policy},
+		})
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("DetachPolicy for %s should have returned err", test.policy)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("DetachPolicy for %s returned err: %v", test.policy, err)
+		}
+
+		if got != test.want {
+			t.Errorf("DetachPolicy for %s is %s, want %s", test.policy, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package step
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-vela/types/pipeline"
+)
+
+const (
+	// ExitCodesKey defines the reserved environment variable
+	// mapping, separated by commas, exit codes for the step to
+	// the outcome they represent (i.e. "1:soft-failure,78:skipped").
+	ExitCodesKey = "VELA_EXIT_CODES"
+
+	// ExitSuccess defines the outcome for an exit code
+	// where the step succeeded.
+	ExitSuccess = "success"
+
+	// ExitFailure defines the outcome for an exit code
+	// where the step failed along with the build, unless
+	// the step continues on failure.
+	ExitFailure = "failure"
+
+	// ExitSoftFailure defines the outcome for an exit code
+	// where the step failed without failing the build.
+	ExitSoftFailure = "soft-failure"
+
+	// ExitSkipped defines the outcome for an exit code
+	// where the step had nothing to do. The step is recorded
+	// as a success along with its exit code since the server
+	// has no status for a skipped step.
+	ExitSkipped = "skipped"
+)
+
+// ExitCodes returns the outcome for each exit code mapped by the
+// step or an error if the mapping for the step is invalid.
+func ExitCodes(c *pipeline.Container) (map[int]string, error) {
+	codes := make(map[int]string)
+
+	// iterate through all exit codes mapped for the step
+	for _, entry := range strings.Split(c.Environment[ExitCodesKey], ",") {
+		entry = strings.TrimSpace(entry)
+
+		// check if the entry is empty
+		if len(entry) == 0 {
+			continue
+		}
+
+		parts := strings.SplitN(entry, ":", 2)
+
+		// check if the entry provides an outcome
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("exit code %s for step %s has no outcome", entry, c.Name)
+		}
+
+		// https://pkg.go.dev/strconv?tab=doc#Atoi
+		code, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid exit code %s for step %s", parts[0], c.Name)
+		}
+
+		outcome := strings.TrimSpace(parts[1])
+
+		switch outcome {
+		case ExitSuccess, ExitFailure, ExitSoftFailure, ExitSkipped:
+		default:
+			return nil, fmt.Errorf("unsupported outcome %s for exit code %d for step %s", outcome, code, c.Name)
+		}
+
+		// check if the exit code is already mapped
+		if _, ok := codes[code]; ok {
+			return nil, fmt.Errorf("exit code %d mapped more than once for step %s", code, c.Name)
+		}
+
+		codes[code] = outcome
+	}
+
+	return codes, nil
+}
+
+// ExitOutcome returns the outcome for the exit code of the step.
+//
+// An exit code the step does not map is a success when
+// it is zero and a failure otherwise.
+func ExitOutcome(c *pipeline.Container) string {
+	// capture the exit codes mapped by the step
+	//
+	// the mapping is validated when the step is created
+	codes, _ := ExitCodes(c)
+
+	// check if the exit code is mapped by the step
+	outcome, ok := codes[c.ExitCode]
+	if ok {
+		return outcome
+	}
+
+	// check if the container has an unsuccessful exit code
+	if c.ExitCode != 0 {
+		return ExitFailure
+	}
+
+	return ExitSuccess
+}
+
+// SoftFailure returns true when the exit code
+// for the step is mapped as a soft failure.
+func SoftFailure(c *pipeline.Container) bool {
+	return ExitOutcome(c) == ExitSoftFailure
+}
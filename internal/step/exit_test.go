@@ -0,0 +1,105 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package step
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-vela/types/pipeline"
+)
+
+func TestStep_ExitCodes(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		failure bool
+		codes   string
+		want    map[int]string
+	}{
+		{
+			failure: false,
+			codes:   "1:soft-failure, 78:skipped,3:success,",
+			want:    map[int]string{1: ExitSoftFailure, 78: ExitSkipped, 3: ExitSuccess},
+		},
+		{
+			failure: false,
+			codes:   "",
+			want:    map[int]string{},
+		},
+		{
+			failure: true,
+			codes:   "1",
+		},
+		{
+			failure: true,
+			codes:   "foo:skipped",
+		},
+		{
+			failure: true,
+			codes:   "1:foo",
+		},
+		{
+			failure: true,
+			codes:   "1:skipped,1:success",
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got, err := ExitCodes(&pipeline.Container{
+			Name:        "test",
+			Environment: map[string]string{ExitCodesKey: test.codes},
+		})
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("ExitCodes for %s should have returned err", test.codes)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("ExitCodes for %s returned err: %v", test.codes, err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("ExitCodes for %s is %v, want %v", test.codes, got, test.want)
+		}
+	}
+}
+
+func TestStep_ExitOutcome(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		code int
+		want string
+	}{
+		{code: 0, want: ExitSuccess},
+		{code: 1, want: ExitSoftFailure},
+		{code: 2, want: ExitFailure},
+		{code: 3, want: ExitSuccess},
+		{code: 78, want: ExitSkipped},
+	}
+
+	// run tests
+	for _, test := range tests {
+		ctn := &pipeline.Container{
+			Name:        "test",
+			Environment: map[string]string{ExitCodesKey: "1:soft-failure,3:success,78:skipped"},
+			ExitCode:    test.code,
+		}
+
+		got := ExitOutcome(ctn)
+
+		if got != test.want {
+			t.Errorf("ExitOutcome for %d is %s, want %s", test.code, got, test.want)
+		}
+
+		if SoftFailure(ctn) != (test.want == ExitSoftFailure) {
+			t.Errorf("SoftFailure for %d is %v, want %v", test.code, SoftFailure(ctn), test.want == ExitSoftFailure)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package step
+
+import (
+	"strconv"
+
+	"github.com/go-vela/types/pipeline"
+)
+
+// FinallyKey defines the reserved environment variable
+// marking a step as a finalizer, which always runs once
+// the build has finished regardless of its status.
+const FinallyKey = "VELA_FINALLY"
+
+// Finalizer returns true when the step is marked as a finalizer.
+func Finalizer(c *pipeline.Container) bool {
+	// capture the value marking the step as a finalizer
+	//
+	// https://pkg.go.dev/strconv?tab=doc#ParseBool
+	finally, err := strconv.ParseBool(c.Environment[FinallyKey])
+	if err != nil {
+		return false
+	}
+
+	return finally
+}
+
+// Finalizers returns the steps, and the steps for each stage,
+// marked as finalizers in the order they appear in the pipeline.
+func Finalizers(p *pipeline.Build) pipeline.ContainerSlice {
+	finalizers := pipeline.ContainerSlice{}
+
+	// check if a pipeline was provided
+	if p == nil {
+		return finalizers
+	}
+
+	// iterate through all steps in the pipeline
+	for _, s := range p.Steps {
+		// check if the step is a finalizer
+		if Finalizer(s) {
+			finalizers = append(finalizers, s)
+		}
+	}
+
+	// iterate through all stages in the pipeline
+	for _, stage := range p.Stages {
+		// iterate through all steps for the stage
+		for _, s := range stage.Steps {
+			// check if the step is a finalizer
+			if Finalizer(s) {
+				finalizers = append(finalizers, s)
+			}
+		}
+	}
+
+	return finalizers
+}
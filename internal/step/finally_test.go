@@ -0,0 +1,105 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package step
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-vela/types/pipeline"
+)
+
+func TestStep_Finalizer(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		env  map[string]string
+		want bool
+	}{
+		{
+			env:  map[string]string{FinallyKey: "true"},
+			want: true,
+		},
+		{
+			env:  map[string]string{FinallyKey: "false"},
+			want: false,
+		},
+		{
+			env:  map[string]string{FinallyKey: "foo"},
+			want: false,
+		},
+		{
+			env:  map[string]string{"FOO": "bar"},
+			want: false,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got := Finalizer(&pipeline.Container{Name: "test", Environment: test.env})
+
+		if got != test.want {
+			t.Errorf("Finalizer is %v, want %v", got, test.want)
+		}
+	}
+}
+
+func TestStep_Finalizers(t *testing.T) {
+	// setup types
+	_notify := &pipeline.Container{
+		Name:        "notify",
+		Environment: map[string]string{FinallyKey: "true"},
+	}
+
+	_upload := &pipeline.Container{
+		Name:        "upload",
+		Environment: map[string]string{FinallyKey: "true"},
+	}
+
+	_test := &pipeline.Container{
+		Name:        "test",
+		Environment: map[string]string{"FOO": "bar"},
+	}
+
+	// setup tests
+	tests := []struct {
+		pipeline *pipeline.Build
+		want     pipeline.ContainerSlice
+	}{
+		{
+			pipeline: &pipeline.Build{
+				Steps: pipeline.ContainerSlice{_test, _notify},
+			},
+			want: pipeline.ContainerSlice{_notify},
+		},
+		{
+			pipeline: &pipeline.Build{
+				Stages: pipeline.StageSlice{
+					{Name: "test", Steps: pipeline.ContainerSlice{_upload, _test}},
+					{Name: "notify", Steps: pipeline.ContainerSlice{_notify}},
+				},
+			},
+			want: pipeline.ContainerSlice{_upload, _notify},
+		},
+		{
+			pipeline: &pipeline.Build{
+				Steps: pipeline.ContainerSlice{_test},
+			},
+			want: pipeline.ContainerSlice{},
+		},
+		{
+			pipeline: nil,
+			want:     pipeline.ContainerSlice{},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got := Finalizers(test.pipeline)
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Finalizers is %v, want %v", got, test.want)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package step
+
+import (
+	"fmt"
+
+	"github.com/go-vela/types/pipeline"
+)
+
+// Find attempts to capture the container representing
+// the step from the pipeline by its stage and name.
+//
+// The stage is ignored for pipelines without stages.
+func Find(p *pipeline.Build, stage, name string) (*pipeline.Container, error) {
+	// check if the pipeline provided is empty
+	if p == nil {
+		return nil, fmt.Errorf("empty pipeline provided")
+	}
+
+	// iterate through each step in the pipeline
+	for _, s := range p.Steps {
+		// check if the step matches the name provided
+		if s.Name == name {
+			return s, nil
+		}
+	}
+
+	// iterate through each stage in the pipeline
+	for _, s := range p.Stages {
+		// check if the stage matches the stage provided
+		if s.Name != stage {
+			continue
+		}
+
+		// iterate through each step in the stage
+		for _, _step := range s.Steps {
+			// check if the step matches the name provided
+			if _step.Name == name {
+				return _step, nil
+			}
+		}
+	}
+
+	// check if a stage was provided
+	if len(stage) > 0 {
+		return nil, fmt.Errorf("unable to find step %s in stage %s", name, stage)
+	}
+
+	return nil, fmt.Errorf("unable to find step %s", name)
+}
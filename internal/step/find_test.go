@@ -0,0 +1,104 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package step
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-vela/types/pipeline"
+)
+
+func TestStep_Find(t *testing.T) {
+	// setup types
+	_step := &pipeline.Container{
+		ID:     "step_github_octocat_1_echo",
+		Image:  "alpine:latest",
+		Name:   "echo",
+		Number: 2,
+	}
+
+	_stageStep := &pipeline.Container{
+		ID:     "github_octocat_1_test_echo",
+		Image:  "alpine:latest",
+		Name:   "echo",
+		Number: 2,
+	}
+
+	_steps := &pipeline.Build{
+		ID:    "github_octocat_1",
+		Steps: pipeline.ContainerSlice{_step},
+	}
+
+	_stages := &pipeline.Build{
+		ID: "github_octocat_1",
+		Stages: pipeline.StageSlice{
+			{
+				Name:  "test",
+				Steps: pipeline.ContainerSlice{_stageStep},
+			},
+		},
+	}
+
+	// setup tests
+	tests := []struct {
+		failure  bool
+		pipeline *pipeline.Build
+		stage    string
+		name     string
+		want     *pipeline.Container
+	}{
+		{ // step in a steps pipeline
+			failure:  false,
+			pipeline: _steps,
+			name:     "echo",
+			want:     _step,
+		},
+		{ // step in a stages pipeline
+			failure:  false,
+			pipeline: _stages,
+			stage:    "test",
+			name:     "echo",
+			want:     _stageStep,
+		},
+		{ // step in an unknown stage
+			failure:  true,
+			pipeline: _stages,
+			stage:    "build",
+			name:     "echo",
+		},
+		{ // unknown step
+			failure:  true,
+			pipeline: _steps,
+			name:     "clone",
+		},
+		{ // empty pipeline
+			failure:  true,
+			pipeline: nil,
+			name:     "echo",
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got, err := Find(test.pipeline, test.stage, test.name)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("Find should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Find returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Find is %v, want %v", got, test.want)
+		}
+	}
+}
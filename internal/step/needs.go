@@ -0,0 +1,203 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package step
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-vela/types/pipeline"
+)
+
+// NeedsKey defines the reserved environment variable listing,
+// separated by commas, the steps that must finish before the
+// step runs.
+const NeedsKey = "VELA_STEP_NEEDS"
+
+// Needs returns the names of the steps the step declares it needs.
+func Needs(c *pipeline.Container) []string {
+	needs := []string{}
+
+	// iterate through all steps listed for the step
+	for _, name := range strings.Split(c.Environment[NeedsKey], ",") {
+		name = strings.TrimSpace(name)
+
+		// check if the name is empty
+		if len(name) == 0 {
+			continue
+		}
+
+		needs = append(needs, name)
+	}
+
+	return needs
+}
+
+// Dependencies returns the steps, by name, that each step needs
+// to finish before it runs or nil when no step declares the
+// steps it needs.
+//
+// A step that does not declare the steps it needs keeps the
+// original order of the pipeline by needing every step
+// before it. A step that declares the steps it needs still
+// needs the init and clone steps, and every step before them.
+func Dependencies(steps pipeline.ContainerSlice) (map[string][]string, error) {
+	declared := false
+
+	// iterate through all steps in the pipeline
+	for _, s := range steps {
+		// check if the step declares the steps it needs
+		if len(Needs(s)) > 0 {
+			declared = true
+
+			break
+		}
+	}
+
+	// check if any step declares the steps it needs
+	if !declared {
+		return nil, nil
+	}
+
+	// capture the steps every step needs
+	setup := implicit(steps)
+
+	// create a map of the steps each step needs
+	needs := make(map[string][]string, len(steps))
+
+	// iterate through all steps in the pipeline
+	for i, s := range steps {
+		// check if the step name is already used
+		if _, ok := needs[s.Name]; ok {
+			return nil, fmt.Errorf("duplicate step %s", s.Name)
+		}
+
+		needs[s.Name] = Needs(s)
+
+		// check if the step declares the steps it needs
+		if len(needs[s.Name]) > 0 {
+			// check if the step runs after the steps every step needs
+			if i >= len(setup) {
+				needs[s.Name] = merge(setup, needs[s.Name])
+			}
+
+			continue
+		}
+
+		// need every step before the step
+		for _, before := range steps[:i] {
+			needs[s.Name] = append(needs[s.Name], before.Name)
+		}
+	}
+
+	// iterate through all steps in the pipeline
+	for _, s := range steps {
+		// iterate through all steps the step needs
+		for _, name := range needs[s.Name] {
+			// check if the step needed is in the pipeline
+			if _, ok := needs[name]; !ok {
+				return nil, fmt.Errorf("step %s needs unknown step %s", s.Name, name)
+			}
+		}
+	}
+
+	// track the steps that are visited
+	visited := make(map[string]bool, len(steps))
+
+	// iterate through all steps in the pipeline
+	for _, s := range steps {
+		// check the steps needed by the step for a cycle
+		path := cycle(s.Name, needs, visited, []string{})
+		if len(path) > 0 {
+			return nil, fmt.Errorf("steps need each other in a cycle: %s", strings.Join(path, " -> "))
+		}
+	}
+
+	return needs, nil
+}
+
+// implicit returns the names of the steps every step needs,
+// which are the init and clone steps and every step before them.
+func implicit(steps pipeline.ContainerSlice) []string {
+	last := -1
+
+	// iterate through all steps in the pipeline
+	for i, s := range steps {
+		// TODO: remove hardcoded reference
+		if s.Name == "init" || s.Name == "clone" {
+			last = i
+		}
+	}
+
+	names := []string{}
+
+	// iterate through all steps up to the last step every step needs
+	for _, s := range steps[:last+1] {
+		names = append(names, s.Name)
+	}
+
+	return names
+}
+
+// merge returns the names provided, in order, without duplicates.
+func merge(first, second []string) []string {
+	names := []string{}
+
+	// track the names already added
+	seen := make(map[string]bool, len(first)+len(second))
+
+	// iterate through all names provided
+	for _, name := range append(append([]string{}, first...), second...) {
+		// check if the name is already added
+		if seen[name] {
+			continue
+		}
+
+		seen[name] = true
+
+		names = append(names, name)
+	}
+
+	return names
+}
+
+// cycle returns the path of step names forming a cycle through
+// the step provided or an empty path if there is no cycle.
+//
+// The visited map records whether each step is still on the
+// path being checked (true) or has been fully checked (false).
+func cycle(name string, needs map[string][]string, visited map[string]bool, path []string) []string {
+	path = append(path, name)
+
+	// check if the step has been visited
+	onPath, ok := visited[name]
+	if ok {
+		// check if the step is on the path being checked
+		if onPath {
+			// trim the path to start from the first visit of the step
+			for i, n := range path {
+				if n == name {
+					return path[i:]
+				}
+			}
+		}
+
+		return nil
+	}
+
+	visited[name] = true
+
+	// iterate through all steps the step needs
+	for _, n := range needs[name] {
+		found := cycle(n, needs, visited, path)
+		if len(found) > 0 {
+			return found
+		}
+	}
+
+	visited[name] = false
+
+	return nil
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2021 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package step
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-vela/types/pipeline"
+)
+
+func TestStep_Needs(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		env  map[string]string
+		want []string
+	}{
+		{
+			env:  map[string]string{NeedsKey: "clone"},
+			want: []string{"clone"},
+		},
+		{
+			env:  map[string]string{NeedsKey: "build, test,"},
+			want: []string{"build", "test"},
+		},
+		{
+			env:  map[string]string{"FOO": "bar"},
+			want: []string{},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got := Needs(&pipeline.Container{Name: "test", Environment: test.env})
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Needs is %v, want %v", got, test.want)
+		}
+	}
+}
+
+func TestStep_Dependencies(t *testing.T) {
+	// setup types
+	steps := func(needs ...string) pipeline.ContainerSlice {
+		s := pipeline.ContainerSlice{}
+
+		// create a step for each name with the steps it needs
+		for i, n := range needs {
+			name := strings.Split(n, ":")[0]
+
+			env := map[string]string{}
+
+			// check if the step needs other steps
+			if strings.Contains(n, ":") {
+				env[NeedsKey] = strings.Split(n, ":")[1]
+			}
+
+			s = append(s, &pipeline.Container{Name: name, Number: i + 1, Environment: env})
+		}
+
+		return s
+	}
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		steps   pipeline.ContainerSlice
+		want    map[string][]string
+	}{
+		{ // no step declares needs
+			failure: false,
+			steps:   steps("init", "clone", "build"),
+			want:    nil,
+		},
+		{ // steps fanning out and back in
+			failure: false,
+			steps:   steps("init", "clone", "build:clone", "test:clone", "publish"),
+			want: map[string][]string{
+				"init":    {},
+				"clone":   {"init"},
+				"build":   {"init", "clone"},
+				"test":    {"init", "clone"},
+				"publish": {"init", "clone", "build", "test"},
+			},
+		},
+		{ // step needs another step after the clone step
+			failure: false,
+			steps:   steps("init", "clone", "build", "test:build"),
+			want: map[string][]string{
+				"init":  {},
+				"clone": {"init"},
+				"build": {"init", "clone"},
+				"test":  {"init", "clone", "build"},
+			},
+		},
+		{ // step needs no steps after another step declares needs
+			failure: false,
+			steps:   steps("init", "clone", "build:", "test:clone"),
+			want: map[string][]string{
+				"init":  {},
+				"clone": {"init"},
+				"build": {"init", "clone"},
+				"test":  {"init", "clone"},
+			},
+		},
+		{ // step needs unknown step
+			failure: true,
+			steps:   steps("init", "clone", "build:foo"),
+		},
+		{ // steps need each other
+			failure: true,
+			steps:   steps("init", "build:test", "test"),
+		},
+		{ // duplicate step
+			failure: true,
+			steps:   steps("init", "build:init", "build"),
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got, err := Dependencies(test.steps)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("Dependencies should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Dependencies returned err: %v", err)
+		}
+
+		// check if the steps are expected to keep their order
+		if test.want == nil {
+			if got != nil {
+				t.Errorf("Dependencies is %v, want nil", got)
+			}
+
+			continue
+		}
+
+		// iterate through all steps expected
+		for name, want := range test.want {
+			if len(got[name]) == 0 && len(want) == 0 {
+				continue
+			}
+
+			if !reflect.DeepEqual(got[name], want) {
+				t.Errorf("Dependencies for %s is %v, want %v", name, got[name], want)
+			}
+		}
+	}
+}
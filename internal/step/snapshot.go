@@ -17,9 +17,13 @@ import (
 
 // Snapshot creates a moment in time record of the
 // step and attempts to upload it to the server.
+//
+// The status for the step is based off the outcome
+// mapped by the step for its exit code.
 func Snapshot(ctn *pipeline.Container, b *library.Build, c *vela.Client, l *logrus.Entry, r *library.Repo, s *library.Step) {
-	// check if the build is not in a canceled status
-	if !strings.EqualFold(s.GetStatus(), constants.StatusCanceled) {
+	// check if the step is not in a canceled or error status
+	if !strings.EqualFold(s.GetStatus(), constants.StatusCanceled) &&
+		!strings.EqualFold(s.GetStatus(), constants.StatusError) {
 		// check if the container is running in headless mode
 		if !ctn.Detach {
 			// update the step fields to indicate a success
@@ -29,6 +33,15 @@ func Snapshot(ctn *pipeline.Container, b *library.Build, c *vela.Client, l *logr
 
 		// check if the container has an unsuccessful exit code
 		if ctn.ExitCode != 0 {
+			// update the step fields with the exit code
+			s.SetExitCode(ctn.ExitCode)
+		}
+
+		// handle the step based off the outcome for the exit code
+		//
+		// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/step#ExitOutcome
+		switch ExitOutcome(ctn) {
+		case ExitFailure:
 			// check if container failures should be ignored
 			if !ctn.Ruleset.Continue {
 				// set build status to failure
@@ -36,7 +49,10 @@ func Snapshot(ctn *pipeline.Container, b *library.Build, c *vela.Client, l *logr
 			}
 
 			// update the step fields to indicate a failure
-			s.SetExitCode(ctn.ExitCode)
+			s.SetStatus(constants.StatusFailure)
+		case ExitSoftFailure:
+			// update the step fields to indicate a failure
+			// without updating the build status
 			s.SetStatus(constants.StatusFailure)
 		}
 	}
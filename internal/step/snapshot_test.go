@@ -265,3 +265,73 @@ func TestStep_SnapshotInit(t *testing.T) {
 		SnapshotInit(test.container, test.build, test.client, nil, test.repo, test.step, test.log)
 	}
 }
+
+func TestStep_Snapshot_ExitCodes(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		code      int
+		cont      bool
+		wantStep  string
+		wantBuild string
+		wantCode  int
+	}{
+		{code: 0, wantStep: "success", wantBuild: "success", wantCode: 0},
+		{code: 1, wantStep: "failure", wantBuild: "success", wantCode: 1},
+		{code: 2, wantStep: "failure", wantBuild: "failure", wantCode: 2},
+		{code: 2, cont: true, wantStep: "failure", wantBuild: "success", wantCode: 2},
+		{code: 3, wantStep: "success", wantBuild: "success", wantCode: 3},
+		{code: 78, wantStep: "success", wantBuild: "success", wantCode: 78},
+	}
+
+	// run tests
+	for _, test := range tests {
+		b := &library.Build{Status: vela.String("success")}
+
+		s := &library.Step{Status: vela.String("running")}
+
+		ctn := &pipeline.Container{
+			ID:          "step_github_octocat_1_lint",
+			Environment: map[string]string{ExitCodesKey: "1:soft-failure,3:success,78:skipped"},
+			ExitCode:    test.code,
+			Name:        "lint",
+			Ruleset:     pipeline.Ruleset{Continue: test.cont},
+		}
+
+		Snapshot(ctn, b, nil, nil, nil, s)
+
+		if s.GetStatus() != test.wantStep {
+			t.Errorf("Snapshot step status for %d is %s, want %s", test.code, s.GetStatus(), test.wantStep)
+		}
+
+		if s.GetExitCode() != test.wantCode {
+			t.Errorf("Snapshot step exit code for %d is %d, want %d", test.code, s.GetExitCode(), test.wantCode)
+		}
+
+		if b.GetStatus() != test.wantBuild {
+			t.Errorf("Snapshot build status for %d is %s, want %s", test.code, b.GetStatus(), test.wantBuild)
+		}
+	}
+}
+
+func TestStep_Snapshot_Error(t *testing.T) {
+	// setup types
+	b := &library.Build{Status: vela.String("running")}
+
+	s := &library.Step{Status: vela.String("error"), Error: vela.String("echo: container start failed")}
+
+	ctn := &pipeline.Container{
+		ID:   "step_github_octocat_1_echo",
+		Name: "echo",
+	}
+
+	// run test
+	Snapshot(ctn, b, nil, nil, nil, s)
+
+	if s.GetStatus() != "error" {
+		t.Errorf("Snapshot step status is %s, want error", s.GetStatus())
+	}
+
+	if s.GetError() != "echo: container start failed" {
+		t.Errorf("Snapshot step error is %s, want echo: container start failed", s.GetError())
+	}
+}
@@ -16,6 +16,9 @@ import (
 
 // Upload tracks the final state of the step
 // and attempts to upload it to the server.
+//
+// The status for the step is based off the outcome
+// mapped by the step for its exit code.
 func Upload(ctn *pipeline.Container, b *library.Build, c *vela.Client, l *logrus.Entry, r *library.Repo, s *library.Step) {
 	// handle the step based off the status provided
 	switch s.GetStatus() {
@@ -27,9 +30,12 @@ func Upload(ctn *pipeline.Container, b *library.Build, c *vela.Client, l *logrus
 		fallthrough
 	// step is in a failure state
 	case constants.StatusFailure:
-		// if the step is in a canceled, error
-		// or failure state we DO NOT want to
-		// update the state to be success
+		fallthrough
+	// step is in a killed state
+	case constants.StatusKilled:
+		// if the step is in a canceled, error,
+		// failure or killed state we DO NOT want
+		// to update the state to be success
 		break
 	// step is in a pending state
 	case constants.StatusPending:
@@ -62,8 +68,16 @@ func Upload(ctn *pipeline.Container, b *library.Build, c *vela.Client, l *logrus
 
 		// check the container for an unsuccessful exit code
 		if ctn.ExitCode != 0 {
-			// update the step fields to indicate a failure
+			// update the step fields with the exit code
 			s.SetExitCode(ctn.ExitCode)
+		}
+
+		// handle the step based off the outcome for the exit code
+		//
+		// https://pkg.go.dev/github.com/go-vela/pkg-executor/internal/step#ExitOutcome
+		switch ExitOutcome(ctn) {
+		case ExitFailure, ExitSoftFailure:
+			// update the step fields to indicate a failure
 			s.SetStatus(constants.StatusFailure)
 		}
 	}
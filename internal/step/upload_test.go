@@ -168,3 +168,37 @@ func TestStep_Upload(t *testing.T) {
 		Upload(test.container, test.build, test.client, nil, test.repo, test.step)
 	}
 }
+
+func TestStep_Upload_ExitCodes(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		code   int
+		status string
+		want   string
+	}{
+		{code: 0, status: "running", want: "success"},
+		{code: 1, status: "running", want: "failure"},
+		{code: 2, status: "running", want: "failure"},
+		{code: 3, status: "running", want: "success"},
+		{code: 78, status: "running", want: "success"},
+		{code: 0, status: "killed", want: "killed"},
+	}
+
+	// run tests
+	for _, test := range tests {
+		s := &library.Step{Status: vela.String(test.status)}
+
+		ctn := &pipeline.Container{
+			ID:          "step_github_octocat_1_lint",
+			Environment: map[string]string{ExitCodesKey: "1:soft-failure,3:success,78:skipped"},
+			ExitCode:    test.code,
+			Name:        "lint",
+		}
+
+		Upload(ctn, &library.Build{}, nil, nil, nil, s)
+
+		if s.GetStatus() != test.want {
+			t.Errorf("Upload step status for %d is %s, want %s", test.code, s.GetStatus(), test.want)
+		}
+	}
+}